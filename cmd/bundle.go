@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/validator-labs/validatorctl/pkg/cmd/validator"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	cmdutils "github.com/validator-labs/validatorctl/pkg/utils/cmd"
+)
+
+const bundleRefUsage = "OCI reference of the rules bundle, e.g., oci://registry.example.com/validator/rules:v1 (required)."
+
+// NewBundleCmd returns a new cobra command which is a container for rules bundle subcommands
+func NewBundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Push, pull and verify signed rules bundles",
+		Long: `Push, pull and verify signed rules bundles.
+
+A rules bundle is an OCI artifact containing validator plugin custom resources,
+e.g., a suite of approved preflight checks, which is published centrally and
+consumed by every site. Each file is stored as a layer of media type
+` + cfg.RulesBundleLayerMediaType + `, titled with its path.
+
+Bundles may be signed with a cosign private key when pushed, and verified with
+the corresponding public key when pulled. Signatures are compatible with cosign,
+e.g., 'cosign verify --key cosign.pub --insecure-ignore-tlog <ref>'. Registry
+credentials are read from the docker config file and cloud provider credential
+helpers.
+
+A bundle's rules can be evaluated directly, e.g.:
+
+  validatorctl rules check --custom-resources oci://registry.example.com/validator/rules:v1 --public-key cosign.pub
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(NewBundlePushCmd())
+	cmd.AddCommand(NewBundlePullCmd())
+	cmd.AddCommand(NewBundleVerifyCmd())
+
+	return cmd
+}
+
+// NewBundlePushCmd returns a new cobra command for pushing a rules bundle
+func NewBundlePushCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Package plugin custom resources into a rules bundle and push it to an OCI registry",
+		Long: `Package plugin custom resources into a rules bundle and push it to an OCI registry.
+
+The bundle's contents are read from a custom resource file or directory, via
+--custom-resources, or from the rules of each enabled plugin in a validator
+configuration file, via --config-file. Only the rules are bundled: plugin auth
+configuration is omitted, and rules containing inline credentials are rejected.
+
+Each custom resource file must contain at least one valid plugin custom resource.
+Variables referenced by custom resources are retained, so that each consumer can
+provide its own values; provide --var or --var-file so the custom resources can
+be validated. Variables referenced by a configuration file are replaced.
+
+Provide --key to sign the bundle with a cosign private key. The key's password
+is read from $` + cfg.CosignPasswordEnv + `.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.BundlePushCommand(tc); err != nil {
+				return fmt.Errorf("failed to push rules bundle: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.Ref, "ref", "", bundleRefUsage)
+	flags.StringVar(&tc.CustomResources, "custom-resources", "", "Path to a file or directory (read recursively) containing validator custom resources, as YAML or JSON documents.")
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file whose plugin rules are bundled.")
+	flags.StringSliceVar(&tc.Include, "include", nil, "Glob pattern(s) of files to bundle when --custom-resources is a directory, e.g., --include '*.yaml'. Default: all files.")
+	flags.StringSliceVar(&tc.Exclude, "exclude", nil, "Glob pattern(s) of files or directories to skip when --custom-resources is a directory. Takes precedence over --include.")
+	flags.StringVar(&tc.SigningKey, "key", "", "Cosign private key file used to sign the bundle (optional).")
+	addRegistryFlags(cmd, tc)
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "ref")
+	cmd.MarkFlagsOneRequired("custom-resources", "config-file")
+	cmd.MarkFlagsMutuallyExclusive("custom-resources", "config-file")
+
+	return cmd
+}
+
+// NewBundlePullCmd returns a new cobra command for pulling a rules bundle
+func NewBundlePullCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:           "pull",
+		Short:         "Pull a rules bundle from an OCI registry and write its files to a directory",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.BundlePullCommand(tc); err != nil {
+				return fmt.Errorf("failed to pull rules bundle: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.Ref, "ref", "", bundleRefUsage)
+	flags.StringVarP(&tc.OutputDir, "output-dir", "o", "", "Directory to write the bundle's files to (required).")
+	flags.StringSliceVar(&tc.PublicKeys, "public-key", nil, "Cosign public key file(s) used to verify the bundle's signature before it is pulled. The bundle must be signed by at least one key.")
+	addRegistryFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "ref")
+	cmdutils.MarkFlagRequired(cmd, "output-dir")
+
+	return cmd
+}
+
+// NewBundleVerifyCmd returns a new cobra command for verifying a rules bundle
+func NewBundleVerifyCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:           "verify",
+		Short:         "Verify the signature and contents of a rules bundle",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.BundleVerifyCommand(tc); err != nil {
+				return fmt.Errorf("failed to verify rules bundle: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.Ref, "ref", "", bundleRefUsage)
+	flags.StringSliceVar(&tc.PublicKeys, "public-key", nil, "Cosign public key file(s) used to verify the bundle's signature (required). The bundle must be signed by at least one key.")
+	addRegistryFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "ref")
+	cmdutils.MarkFlagRequired(cmd, "public-key")
+
+	return cmd
+}
+
+// addRegistryFlags adds flags for configuring TLS when connecting to an OCI registry
+func addRegistryFlags(cmd *cobra.Command, tc *cfg.TaskConfig) {
+	flags := cmd.Flags()
+	flags.StringVar(&tc.CAFile, "ca-file", "", "CA certificate file trusted when connecting to the OCI registry (optional).")
+	flags.BoolVar(&tc.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS verification when connecting to the OCI registry. Default: false.")
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/validator-labs/validatorctl/pkg/cmd/validator"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/encryption"
+	cmdutils "github.com/validator-labs/validatorctl/pkg/utils/cmd"
+)
+
+// NewConfigCmd returns a new cobra command which is a container for validator configuration file subcommands
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage validator configuration files",
+		Long: `Manage validator configuration files.
+
+Sensitive values within validator configuration files, e.g., passwords, tokens
+and cloud credentials, are base64-encoded. Run 'validatorctl config encrypt' to
+encrypt them at rest; encrypted files remain encrypted whenever they are saved.
+Configuration files created before encryption was supported are encrypted using
+the default keyring the next time they are saved.
+
+By default, values are encrypted using a key stored in a local keyring file,
+$HOME/.validator/keyring.yaml. The keyring's path is not recorded in the
+configuration file, so it can be shared with anyone holding the same keyring
+file. Alternatively, a passphrase or an age identity
+file can be used. The passphrase is read from the VALIDATOR_CTL_PASSPHRASE
+environment variable if set, otherwise it is prompted for.
+
+Sensitive values can also reference secrets stored outside of the
+configuration file, which makes it safe to commit to version control:
+
+  env:AWS_SECRET_ACCESS_KEY        an environment variable
+  file:/run/secrets/password       the contents of a file
+  k8s:namespace/secret#key         a key within a Kubernetes secret
+
+References are resolved whenever the configuration file is loaded, and are
+never replaced by their values when it is saved.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(NewEncryptConfigCmd())
+	cmd.AddCommand(NewDecryptConfigCmd())
+	cmd.AddCommand(NewRotateConfigKeyCmd())
+	cmd.AddCommand(NewValidateConfigCmd())
+	cmd.AddCommand(NewMigrateConfigCmd())
+	cmd.AddCommand(NewConfigSchemaCmd())
+
+	return cmd
+}
+
+// NewEncryptConfigCmd returns a new cobra command for encrypting a validator configuration file
+func NewEncryptConfigCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt sensitive values in a validator configuration file",
+		Long: `Encrypt sensitive values in a validator configuration file.
+
+If the configuration file is already encrypted, it is re-encrypted using the specified key source.
+If --key-provider=age and the --key-file does not exist, a new age identity is generated.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.EncryptConfigCommand(tc); err != nil {
+				return fmt.Errorf("failed to encrypt validator configuration: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	flags.StringVar(&tc.KeyProvider, "key-provider", encryption.ProviderKeyring, fmt.Sprintf("Key source used to encrypt the configuration file. One of: %v.", encryption.Providers))
+	flags.StringVar(&tc.KeyFile, "key-file", "", `Keyring file or age identity file. Required for --key-provider=age. (default "$HOME/.validator/keyring.yaml" for --key-provider=keyring)`)
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+
+	return cmd
+}
+
+// NewDecryptConfigCmd returns a new cobra command for decrypting a validator configuration file
+func NewDecryptConfigCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "decrypt",
+		Short: "Remove encryption from a validator configuration file",
+		Long: `Remove encryption from a validator configuration file.
+
+Sensitive values are base64-encoded rather than encrypted. The configuration
+file remains unencrypted until 'validatorctl config encrypt' is run again.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.DecryptConfigCommand(tc); err != nil {
+				return fmt.Errorf("failed to decrypt validator configuration: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+
+	return cmd
+}
+
+// NewRotateConfigKeyCmd returns a new cobra command for rotating the key used to encrypt a validator configuration file
+func NewRotateConfigKeyCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt a validator configuration file using a new key",
+		Long: `Re-encrypt a validator configuration file using a new key.
+
+A new data key is generated and all sensitive values are re-encrypted. Unless
+--key-provider is specified, the new data key is protected by the configuration
+file's existing key source. For the keyring key source, a new key is added to
+the keyring file; existing keys are retained so that other configuration files
+can still be decrypted.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.RotateConfigKeyCommand(tc); err != nil {
+				return fmt.Errorf("failed to rotate validator configuration key: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	flags.StringVar(&tc.KeyProvider, "key-provider", "", fmt.Sprintf("Key source used to re-encrypt the configuration file. One of: %v. Defaults to the existing key source.", encryption.Providers))
+	flags.StringVar(&tc.KeyFile, "key-file", "", "Keyring file or age identity file. Defaults to the existing key file.")
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+
+	return cmd
+}
+
+// NewValidateConfigCmd returns a new cobra command for validating a validator configuration file
+func NewValidateConfigCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a validator configuration file",
+		Long: `Validate a validator configuration file.
+
+The configuration file is checked against the validator configuration schema;
+unknown fields and values of the wrong type are reported. Next, the following
+rules are checked:
+
+- kubeconfig is set, unless a kind cluster is used.
+- Each enabled plugin has at least one rule.
+- Each secret reference can be resolved.
+
+Each error is reported along with its line and column within the file. Variables
+referenced by the file are replaced by their values prior to validation.
+
+To print the validator configuration schema, run 'validatorctl config schema'.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return validator.ValidateConfigCommand(tc)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+
+	return cmd
+}
+
+// NewMigrateConfigCmd returns a new cobra command for upgrading a validator configuration file to the current version
+func NewMigrateConfigCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade a validator configuration file to the current version",
+		Long: `Upgrade a validator configuration file to the current version.
+
+Validator configuration files are versioned. Files created by older versions of
+validatorctl are upgraded automatically whenever they're loaded, but are only
+rewritten when saved. This command rewrites the file in place, displaying a diff
+of the changes. The original file is backed up alongside it, e.g., to
+validator.yaml.v0.bak.
+
+Files which reference variables are not migrated, as the variables would be
+replaced by their values.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.MigrateConfigCommand(tc); err != nil {
+				return fmt.Errorf("failed to migrate validator configuration: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+
+	return cmd
+}
+
+// NewConfigSchemaCmd returns a new cobra command for printing the validator configuration schema
+func NewConfigSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for validator configuration files",
+		Long: `Print the JSON Schema for validator configuration files.
+
+The schema can be used by editors to provide completion and validation, e.g., using
+the yaml-language-server modeline:
+
+  # yaml-language-server: $schema=validator.schema.json
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return validator.ConfigSchemaCommand(os.Stdout)
+		},
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/validator-labs/validatorctl/pkg/cmd/validator"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	cfgmanager "github.com/validator-labs/validatorctl/pkg/config/manager"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+)
+
+// NewHistoryCmd returns a new cobra command which is a container for validation run history subcommands
+func NewHistoryCmd() *cobra.Command {
+	c := cfgmanager.Config()
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Inspect and compare past validation runs",
+		Long: `Inspect and compare past validation runs.
+
+Each 'rules check' run writes its validation results to a timestamped directory
+within the workspace, e.g., $HOME/.validator/validator-20240601120000. Runs are
+identified by their timestamp. The aliases 'latest' and 'previous' refer to the
+most recent run and the run before it.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			// history is read-only, so the workspace is not created if it does not exist
+			return c.ResolveWorkspace()
+		},
+	}
+
+	cmd.AddCommand(NewHistoryListCmd(c))
+	cmd.AddCommand(NewHistoryShowCmd(c))
+	cmd.AddCommand(NewHistoryDiffCmd(c))
+
+	return cmd
+}
+
+// NewHistoryListCmd returns a new cobra command for listing past validation runs
+func NewHistoryListCmd(c *cfg.Config) *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List past validation runs",
+		Long: `List past validation runs, oldest first.
+
+For each run, the number of validation results and the number of passed, failed and
+errored rules are displayed. The trend is the change in the number of failed and
+errored rules since the previous run.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.HistoryListCommand(c.WorkspaceLoc, tc); err != nil {
+				return fmt.Errorf("failed to list validation runs: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.OutputFormat, "output", cfg.OutputFormatTable, fmt.Sprintf("Output format. One of: %v. Structured formats are written to stdout; all other output is written to stderr.", cfg.OutputFormats))
+
+	return cmd
+}
+
+// NewHistoryShowCmd returns a new cobra command for displaying the validation results of a past run
+func NewHistoryShowCmd(c *cfg.Config) *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "show [run]",
+		Short: "Display the validation results of a past validation run",
+		Long: `Display the validation results of a past validation run.
+
+The run defaults to 'latest'. Results can be filtered using --plugin, --state, --rule
+and/or --only-failures. Provide --summary to print a single line per validation result,
+rather than a table per rule.
+`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, args []string) error {
+			ref := cfg.HistoryLatest
+			if len(args) > 0 {
+				ref = args[0]
+			}
+			if err := validator.HistoryShowCommand(c.WorkspaceLoc, tc, ref); err != nil {
+				return fmt.Errorf("failed to show validation run: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.OutputFormat, "output", cfg.OutputFormatTable, fmt.Sprintf("Output format for validation results. One of: %v. Structured formats are written to stdout; all other output is written to stderr.", cfg.OutputFormats))
+	flags.StringSliceVar(&tc.Plugins, "plugin", nil, fmt.Sprintf("Only display validation results from the specified plugin(s), e.g., --plugin aws,oci. One or more of: %v.", plugins.Codes()))
+	flags.StringVar(&tc.State, "state", "", fmt.Sprintf("Only display validation results in the specified state. One of: %v.", cfg.ValidationStates))
+	flags.StringVar(&tc.Rule, "rule", "", "Only display the validation rule with the specified name.")
+	flags.BoolVar(&tc.OnlyFailures, "only-failures", false, "Only display validation rules which failed. Default: false.")
+	flags.StringVar(&tc.SortBy, "sort", cfg.SortByName, fmt.Sprintf("Sort order for validation results. One of: %v.", cfg.SortOrders))
+	flags.BoolVar(&tc.Summary, "summary", false, "Print a single line summary of each validation result, with counts of passed, failed and errored rules. Only applies to table output. Default: false.")
+
+	return cmd
+}
+
+// NewHistoryDiffCmd returns a new cobra command for comparing two past validation runs
+func NewHistoryDiffCmd(c *cfg.Config) *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "diff [from] [to]",
+		Short: "Compare the validation rules evaluated in two past validation runs",
+		Long: `Compare the validation rules evaluated in two past validation runs.
+
+By default, the latest run is compared with the run before it. Provide a single run
+to compare it with the latest run. Rules are matched by validation result and rule name,
+and reported as:
+
+- Newly failing: failed or errored in the second run, but not in the first.
+- Fixed: failed or errored in the first run, and passed in the second.
+- Changed: failed or errored in both runs, with different outcomes or failure messages.
+- No longer evaluated: present in the first run, but not in the second.
+`,
+		Args:          cobra.MaximumNArgs(2),
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var from, to string
+			if len(args) > 0 {
+				from = args[0]
+			}
+			if len(args) > 1 {
+				to = args[1]
+			}
+			if err := validator.HistoryDiffCommand(c.WorkspaceLoc, tc, from, to); err != nil {
+				return fmt.Errorf("failed to compare validation runs: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.OutputFormat, "output", cfg.OutputFormatTable, fmt.Sprintf("Output format. One of: %v. Structured formats are written to stdout; all other output is written to stderr.", cfg.OutputFormats))
+
+	return cmd
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/validator-labs/validatorctl/pkg/cmd/validator"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	cmdutils "github.com/validator-labs/validatorctl/pkg/utils/cmd"
+)
+
+const profileDirUsage = "Directory of local profiles, one per YAML file (optional). Local profiles override embedded profiles with the same name."
+
+// NewProfilesCmd returns a new cobra command which is a container for rule profile subcommands
+func NewProfilesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "List, inspect and apply rule profiles",
+		Long: `List, inspect and apply rule profiles.
+
+A rule profile is a named preset of validation rules for one or more plugins,
+e.g., the IAM permissions required by an EKS node role. Several profiles are
+embedded in validatorctl. Additional profiles can be loaded from a local
+directory using --profile-dir. Each profile is a YAML file of the form:
+
+  name: my-profile
+  title: My profile
+  description: |
+    What the profile validates, and which placeholder values to replace.
+  rules:
+  - kind: NetworkValidator
+    spec:
+      dnsRules:
+      - name: resolve example.com
+        host: example.com
+
+Run 'validatorctl profiles show --profile <name>' to print a profile, e.g., as
+a starting point for a local profile.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(NewProfilesListCmd())
+	cmd.AddCommand(NewProfilesShowCmd())
+	cmd.AddCommand(NewProfilesApplyCmd())
+
+	return cmd
+}
+
+// NewProfilesListCmd returns a new cobra command for listing rule profiles
+func NewProfilesListCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:           "list",
+		Short:         "List the available rule profiles",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.ProfilesListCommand(tc); err != nil {
+				return fmt.Errorf("failed to list profiles: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.ProfileDir, "profile-dir", "", profileDirUsage)
+	flags.StringVar(&tc.OutputFormat, "output", cfg.OutputFormatTable, fmt.Sprintf("Output format. One of: %v. Structured formats are written to stdout; all other output is written to stderr.", cfg.OutputFormats))
+
+	return cmd
+}
+
+// NewProfilesShowCmd returns a new cobra command for printing a rule profile
+func NewProfilesShowCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:           "show",
+		Short:         "Print a rule profile",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.ProfilesShowCommand(tc); err != nil {
+				return fmt.Errorf("failed to show profile: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&tc.Profile, "profile", "", "Name of the profile to print (required).")
+	flags.StringVar(&tc.ProfileDir, "profile-dir", "", profileDirUsage)
+
+	cmdutils.MarkFlagRequired(cmd, "profile")
+
+	return cmd
+}
+
+// NewProfilesApplyCmd returns a new cobra command for applying a rule profile to a validator configuration file
+func NewProfilesApplyCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Merge the rules in a rule profile into a validator configuration file",
+		Long: `Merge the rules in a rule profile into a validator configuration file.
+
+Rules are matched by name. Rules in the configuration file with the same name
+as a rule in the profile are replaced; all other rules are added. Plugins used
+by the profile are enabled if required; their installation and credentials must
+then be configured, e.g., via 'validatorctl install --reconfigure'.
+
+Most profiles contain placeholder values, e.g., role names or hosts, which must
+be replaced after applying the profile. See the profile's description.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.ProfilesApplyCommand(tc); err != nil {
+				return fmt.Errorf("failed to apply profile: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	flags.StringVar(&tc.Profile, "profile", "", "Name of the profile to apply (required).")
+	flags.StringVar(&tc.ProfileDir, "profile-dir", "", profileDirUsage)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+	cmdutils.MarkFlagRequired(cmd, "profile")
+
+	return cmd
+}
@@ -40,6 +40,9 @@ func Execute() {
 	if errors.Is(err, validator.ErrValidationFailed{}) {
 		os.Exit(2)
 	}
+	if errors.Is(err, validator.ErrDriftDetected{}) {
+		os.Exit(3)
+	}
 	log.FatalCLI("failed to execute command", "error", err)
 }
 
@@ -67,10 +70,16 @@ Use 'validator help <sub-command>' to explore all of the functionality the Valid
 
 	// add base commands
 	rootCmd.AddCommand(NewInstallValidatorCmd())
+	rootCmd.AddCommand(NewRenderValidatorCmd())
 	rootCmd.AddCommand(NewValidatorRulesCmd())
 	rootCmd.AddCommand(NewUpgradeValidatorCmd())
 	rootCmd.AddCommand(NewUndeployValidatorCmd())
 	rootCmd.AddCommand(NewDescribeValidationResultsCmd())
+	rootCmd.AddCommand(NewWatchValidationResultsCmd())
+	rootCmd.AddCommand(NewHistoryCmd())
+	rootCmd.AddCommand(NewProfilesCmd())
+	rootCmd.AddCommand(NewBundleCmd())
+	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewValidatorDocsCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 
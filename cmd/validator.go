@@ -10,6 +10,7 @@ import (
 	"github.com/validator-labs/validatorctl/pkg/cmd/validator"
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	cfgmanager "github.com/validator-labs/validatorctl/pkg/config/manager"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
 	cmdutils "github.com/validator-labs/validatorctl/pkg/utils/cmd"
 	"github.com/validator-labs/validatorctl/pkg/utils/embed"
 	"github.com/validator-labs/validatorctl/pkg/utils/exec"
@@ -32,31 +33,51 @@ to a Kubernetes cluster or your choosing.
 Optionally provide the --apply and/or --wait flags to configure and apply
 plugin rules and wait for validation, in addition to installation. This
 is equivalent to first running 'validatorctl install', then running 
-'validatorctl rules apply --config-file <config-file> --wait'.
+'validatorctl rules apply --config-file <config-file> --wait'. Use
+--wait-timeout, --fail-fast, --plugin and --selector to control waiting.
 
 Run 'validatorctl install --reconfigure --config-file <config-file>' to
 reconfigure the validator and plugin(s) prior to installation.
 
+To install without a TTY, answer configuration prompts using --values and/or
+--set, and provide --non-interactive to fail on any unanswered prompt. Answers
+are keyed by their path within the validator configuration file, e.g.,
+--set kindConfig.useKindCluster=false answers the "Provision & use kind cluster"
+prompt, and --values accepts partial validator configuration files.
+
 Run 'validatorctl install --update-passwords --config-file <config-file>' to
 update passwords in the validator configuration file. Optionally add
 the --apply flag to update passwords for plugin(s) as well.
 
+Provide --dry-run to print a diff between the cluster and the validator
+configuration without making any changes, or --diff to print the diff
+prior to making changes.
+
+Exit codes:
+- 0 indicates success. With --dry-run, the cluster matches the validator configuration.
+- 1 indicates that an unexpected error occurred.
+- 2 indicates that one or more rules failed validation. Only applies when --wait is set.
+- 3 indicates that --dry-run found differences between the cluster and the validator configuration.
+
 For more information about validator, see: https://github.com/validator-labs/validator.
 `,
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
 		SilenceUsage:  false,
 		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
-			if err := exec.CheckBinaries([]exec.Binary{exec.HelmBin, exec.KubectlBin}); err != nil {
+			if err := checkHelmBackend(tc); err != nil {
 				return err
 			}
 			return validator.InitWorkspace(c, cfg.Validator, cfg.ValidatorSubdirs, true)
 		},
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := c.Save(""); err != nil {
 				return err
 			}
 			if err := validator.InstallValidatorCommand(c, tc); err != nil {
+				if errors.Is(err, validator.ErrDriftDetected{}) || errors.Is(err, validator.ErrValidationFailed{}) {
+					cmd.SilenceUsage = true
+				}
 				return fmt.Errorf("failed to install validator: %w", err)
 			}
 			return nil
@@ -68,13 +89,20 @@ For more information about validator, see: https://github.com/validator-labs/val
 	flags.BoolVarP(&tc.CreateConfigOnly, "config-only", "o", false, "Generate configuration file only. Do not proceed with installation. Default: false.")
 	flags.BoolVarP(&tc.UpdatePasswords, "update-passwords", "p", false, "Update passwords only. Do not proceed with installation. The --config-file flag must be provided. Default: false.")
 	flags.BoolVarP(&tc.Reconfigure, "reconfigure", "r", false, "Re-configure validator and plugin(s) prior to installation. The --config-file flag must be provided. Default: false.")
+	addVarFlags(cmd, tc)
 
 	flags.BoolVar(&tc.Apply, "apply", false, "Configure and apply validator plugin rules. Default: false")
 	flags.BoolVar(&tc.Wait, "wait", false, "Wait for validation to succeed and describe results. Only applies when --apply is set. Default: false")
+	addWaitFlags(cmd, tc)
+	flags.DurationVar(&tc.InstallTimeout, "install-timeout", cfg.DefaultInstallTimeout, "Maximum duration to wait for validator and validator plugin(s) to be installed. On timeout, diagnostics are written to the run directory. Set to 0 to disable.")
+	addAnswersFlags(cmd, tc)
+	addDiffFlags(cmd, tc)
+	addHelmBackendFlag(cmd, tc)
 
 	cmd.MarkFlagsMutuallyExclusive("config-only", "wait")
 	cmd.MarkFlagsMutuallyExclusive("update-passwords", "reconfigure")
 	cmd.MarkFlagsMutuallyExclusive("update-passwords", "wait")
+	markDiffFlagsExclusive(cmd)
 
 	return cmd
 }
@@ -123,22 +151,44 @@ Plugin-specific custom resources containing rule definitions will be
 generated and applied to a Kubernetes cluster or your choosing. Useful
 for continuous validation and alerting.
 
+Provide --wait to wait for validation to complete and describe results.
+Use --wait-timeout to bound the wait, --fail-fast to stop waiting as soon
+as any rule fails, and --plugin and/or --selector to only wait for
+matching validation results.
+
+To reconfigure plugin rules without a TTY, answer configuration prompts using
+--values and/or --set, and provide --non-interactive to fail on any unanswered
+prompt, e.g., --set plugins.aws.validator.iamRoleRules=[].
+
+Provide --dry-run to print a diff between the cluster and the validator
+configuration without making any changes, or --diff to print the diff
+prior to making changes.
+
+Exit codes:
+- 0 indicates success. With --dry-run, the cluster matches the validator configuration.
+- 1 indicates that an unexpected error occurred.
+- 2 indicates that one or more rules failed validation. Only applies when --wait is set.
+- 3 indicates that --dry-run found differences between the cluster and the validator configuration.
+
 For more information about validator, see: https://github.com/validator-labs/validator.
 `,
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
 		SilenceUsage:  false,
 		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
-			if err := exec.CheckBinaries([]exec.Binary{exec.HelmBin, exec.KubectlBin}); err != nil {
+			if err := checkHelmBackend(tc); err != nil {
 				return err
 			}
 			return validator.InitWorkspace(c, cfg.Validator, cfg.ValidatorSubdirs, true)
 		},
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := c.Save(""); err != nil {
 				return err
 			}
 			if err := validator.ConfigureCommand(c, tc); err != nil {
+				if errors.Is(err, validator.ErrDriftDetected{}) || errors.Is(err, validator.ErrValidationFailed{}) {
+					cmd.SilenceUsage = true
+				}
 				return fmt.Errorf("failed to configure and apply validator rules: %w", err)
 			}
 			return nil
@@ -151,12 +201,19 @@ For more information about validator, see: https://github.com/validator-labs/val
 	flags.BoolVarP(&tc.UpdatePasswords, "update-passwords", "p", false, "Update passwords only. Do not proceed with checks. Default: false.")
 	flags.BoolVarP(&tc.Reconfigure, "reconfigure", "r", false, "Re-configure plugin rules prior to running checks. Default: false.")
 	flags.BoolVar(&tc.Wait, "wait", false, "Wait for validation to succeed and describe results. Default: false")
+	addWaitFlags(cmd, tc)
+	flags.DurationVar(&tc.InstallTimeout, "install-timeout", cfg.DefaultInstallTimeout, "Maximum duration to wait for validator and validator plugin(s) to be installed. On timeout, diagnostics are written to the run directory. Set to 0 to disable.")
+	addAnswersFlags(cmd, tc)
+	addDiffFlags(cmd, tc)
+	addHelmBackendFlag(cmd, tc)
+	addVarFlags(cmd, tc)
 
 	cmdutils.MarkFlagRequired(cmd, "config-file")
 
 	cmd.MarkFlagsMutuallyExclusive("config-only", "wait")
 	cmd.MarkFlagsMutuallyExclusive("update-passwords", "reconfigure")
 	cmd.MarkFlagsMutuallyExclusive("update-passwords", "wait")
+	markDiffFlagsExclusive(cmd)
 
 	return cmd
 }
@@ -176,8 +233,50 @@ func NewCheckValidatorCmd() *cobra.Command {
 
 Plugin rules will be evaluated directly, in-process. Useful for preflight checks or debugging.
 
+Configuration prompts can be answered using --values and/or --set. Provide
+--non-interactive to fail on any unanswered prompt, e.g., when running in CI.
+
+The configuration file and custom resources may reference variables, using
+${NAME} placeholders or Go templates, e.g., {{ .NAME }}, so that the same files
+can be used across environments. Values are read from the environment, then
+--var-file, then --var. Undefined variables are an error. Write $${NAME} for a
+literal ${NAME}. For example:
+
+  iamRoleRules:
+  - iamRoleName: ${CLUSTER_NAME}-node-role
+
+  validatorctl rules check --custom-resources rules.yaml --var CLUSTER_NAME=prod
+
+When a configuration file which references variables is saved, only the fields
+which changed are written back to it, leaving its variables in place. Fields
+whose values reference variables are never overwritten.
+
+Custom resources may be read from a local file or directory, from stdin ('-'),
+from an https:// URL, or from a rules bundle in an OCI registry, e.g., one
+published centrally and consumed by every site. Each layer of a rules bundle is
+a custom resource file. Registry credentials are read from the docker config
+file and cloud provider credential helpers. Provide --public-key to verify the
+bundle's cosign signature before it is read. For example:
+
+  validatorctl rules check --custom-resources ./rules --include '*.yaml' --exclude testdata
+  kubectl get networkvalidators -o yaml | validatorctl rules check --custom-resources -
+  validatorctl rules check --custom-resources oci://registry.example.com/validator/rules:v1 --public-key cosign.pub
+
+Known, accepted failures can be suppressed using a baseline file, provided via
+--baseline, or read from .validatorignore in the current directory if it exists.
+Suppressed failures are reported, but do not fail the check. Once a suppression
+expires, the failures it matches fail the check again. For example:
+
+  suppressions:
+  - plugin: AWS
+    rule: validation-service-quota-*
+    failures:                       # optional regular expressions; all failures must match
+    - "^Usage for VPCs per Region"
+    expires: 2024-12-31             # suppressions expire at the end of this date
+    justification: VPC quota increase requested, see OPS-123
+
 Exit codes:
-- 0 indicates that all rules passed validation.
+- 0 indicates that all rules passed validation, or that all failures were suppressed.
 - 1 indicates that an unexpected error occurred.
 - 2 indicates that one or more rules failed validation.
 
@@ -209,16 +308,33 @@ For more information about validator, see: https://github.com/validator-labs/val
 
 	flags := cmd.Flags()
 	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file.")
-	flags.StringVar(&tc.CustomResources, "custom-resources", "", "Path to a file or directory containing validator custom resource YAML documents.")
+	flags.StringVar(&tc.CustomResources, "custom-resources", "", "Validator custom resources, as YAML or JSON documents: a file, a directory (read recursively), '-' for stdin, an https:// URL or an oci:// rules bundle. Each document is either a custom resource or a List of custom resources.")
+	flags.StringSliceVar(&tc.Include, "include", nil, "Glob pattern(s) of files to read when --custom-resources is a directory, e.g., --include '*.yaml'. Patterns containing a '/' match the path relative to the directory; others match the file name. Default: all files.")
+	flags.StringSliceVar(&tc.Exclude, "exclude", nil, "Glob pattern(s) of files or directories to skip when --custom-resources is a directory, e.g., --exclude testdata. Takes precedence over --include.")
+	flags.StringVar(&tc.CAFile, "ca-file", "", "CA certificate file trusted when fetching --custom-resources from an https:// URL or an OCI registry (optional).")
+	flags.BoolVar(&tc.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "Skip TLS verification when fetching --custom-resources from an https:// URL or an OCI registry. Default: false.")
+	flags.StringSliceVar(&tc.PublicKeys, "public-key", nil, "Cosign public key file(s) used to verify the signature of an oci:// rules bundle before it is read (optional). The bundle must be signed by at least one key.")
+	flags.StringVar(&tc.JUnitReport, "junit-report", "", "Path to write a JUnit XML report of validation results to (optional).")
+	flags.StringVar(&tc.SARIFReport, "sarif-report", "", "Path to write a SARIF 2.1.0 report of validation failures to (optional).")
+	flags.StringVar(&tc.Baseline, "baseline", "", fmt.Sprintf("Baseline file listing known, accepted validation rule failures to suppress (optional). Defaults to %s in the current directory, if it exists.", cfg.BaselineFile))
 	flags.BoolVarP(&tc.CreateConfigOnly, "config-only", "o", false, "Update configuration file only. Do not proceed with checks. Default: false.")
 	flags.BoolVarP(&tc.UpdatePasswords, "update-passwords", "p", false, "Update passwords only. Do not proceed with checks. Default: false.")
 	flags.BoolVarP(&tc.Reconfigure, "reconfigure", "r", false, "Re-configure plugin rules prior to running checks. Default: false.")
+	flags.StringVar(&tc.OutputFormat, "output", cfg.OutputFormatTable, fmt.Sprintf("Output format for validation results. One of: %v. Structured formats are written to stdout; all other output is written to stderr.", cfg.OutputFormats))
+	flags.IntVar(&tc.Parallelism, "parallelism", cfg.DefaultPluginParallelism, "Maximum number of plugins to evaluate concurrently.")
+	flags.DurationVar(&tc.PluginTimeout, "plugin-timeout", cfg.DefaultPluginTimeout, "Maximum duration to wait for a single plugin's rules to be evaluated. Set to 0 to disable.")
+	flags.DurationVar(&tc.Timeout, "timeout", 0, "Maximum duration to wait for all plugins' rules to be evaluated. Set to 0 to disable. Default: 0.")
+	addAnswersFlags(cmd, tc)
+	addVarFlags(cmd, tc)
 
 	cmd.MarkFlagsMutuallyExclusive("update-passwords", "reconfigure")
 	cmd.MarkFlagsMutuallyExclusive("config-file", "custom-resources")
 	cmd.MarkFlagsMutuallyExclusive("config-only", "custom-resources")
 	cmd.MarkFlagsMutuallyExclusive("update-passwords", "custom-resources")
 	cmd.MarkFlagsMutuallyExclusive("reconfigure", "custom-resources")
+	for _, f := range []string{"include", "exclude", "ca-file", "insecure-skip-tls-verify", "public-key"} {
+		cmd.MarkFlagsMutuallyExclusive(f, "config-file")
+	}
 
 	return cmd
 }
@@ -233,19 +349,31 @@ func NewUpgradeValidatorCmd() *cobra.Command {
 		Short: "Upgrade validator & re-configure validator plugin(s)",
 		Long: `Upgrade validator & re-configure validator plugin(s).
 
+Provide --dry-run to print a diff between the cluster and the validator
+configuration without making any changes, or --diff to print the diff
+prior to making changes.
+
+Exit codes:
+- 0 indicates success. With --dry-run, the cluster matches the validator configuration.
+- 1 indicates that an unexpected error occurred.
+- 3 indicates that --dry-run found differences between the cluster and the validator configuration.
+
 For more information about validator, see: https://github.com/validator-labs/validator.
 `,
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
 		SilenceUsage:  false,
 		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
-			if err := exec.CheckBinaries([]exec.Binary{exec.HelmBin, exec.KubectlBin}); err != nil {
+			if err := checkHelmBackend(tc); err != nil {
 				return err
 			}
 			return validator.InitWorkspace(c, cfg.Validator, cfg.ValidatorSubdirs, true)
 		},
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := validator.UpgradeValidatorCommand(c, tc); err != nil {
+				if errors.Is(err, validator.ErrDriftDetected{}) {
+					cmd.SilenceUsage = true
+				}
 				return fmt.Errorf("failed to upgrade validator: %w", err)
 			}
 			return nil
@@ -254,8 +382,13 @@ For more information about validator, see: https://github.com/validator-labs/val
 
 	flags := cmd.Flags()
 	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Upgrade using a configuration file")
+	flags.DurationVar(&tc.InstallTimeout, "install-timeout", cfg.DefaultInstallTimeout, "Maximum duration to wait for validator and validator plugin(s) to be installed. On timeout, diagnostics are written to the run directory. Set to 0 to disable.")
+	addDiffFlags(cmd, tc)
+	addHelmBackendFlag(cmd, tc)
+	addVarFlags(cmd, tc)
 
 	cmdutils.MarkFlagRequired(cmd, "config-file")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "diff")
 
 	return cmd
 }
@@ -273,7 +406,7 @@ func NewUndeployValidatorCmd() *cobra.Command {
 		SilenceErrors: true,
 		SilenceUsage:  false,
 		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
-			if err := exec.CheckBinaries([]exec.Binary{exec.HelmBin}); err != nil {
+			if err := checkHelmBackend(tc); err != nil {
 				return err
 			}
 			return validator.InitWorkspace(c, cfg.Validator, cfg.ValidatorSubdirs, true)
@@ -289,24 +422,134 @@ func NewUndeployValidatorCmd() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required)")
 	flags.BoolVarP(&tc.DeleteCluster, "delete-cluster", "d", true, "Delete the validator kind cluster. Does not apply if using a preexisting K8s cluster. Default: true.")
+	addVarFlags(cmd, tc)
+	addHelmBackendFlag(cmd, tc)
 
 	cmdutils.MarkFlagRequired(cmd, "config-file")
 
 	return cmd
 }
 
+// NewRenderValidatorCmd returns a new cobra command for rendering validator & validator plugin manifests
+func NewRenderValidatorCmd() *cobra.Command {
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render validator & validator plugin manifests without installing them",
+		Long: `Render validator & validator plugin manifests without installing them.
+
+The Helm values, manifests and custom resources which 'validatorctl install --apply'
+would install are written to an output directory. No connection to a cluster is
+required. The output is intended to be applied by GitOps tooling, e.g., Argo CD or
+Flux, rather than by validatorctl. It contains:
+
+- chart.yaml:                  the validator Helm chart's name, repository and version
+- values.yaml:                 the final Helm values for the validator Helm chart
+- validator-config-spec.yaml:  the ValidatorConfigSpec, i.e., each plugin's Helm release & values
+- namespace.yaml:              the validator namespace
+- secrets/:                    the Helm release secret, if any
+- manifests/:                  a custom resource containing each enabled plugin's rules
+
+Rendered files contain credentials unless --redact is provided.
+
+For more information about validator, see: https://github.com/validator-labs/validator.
+`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := validator.RenderCommand(tc); err != nil {
+				return fmt.Errorf("failed to render validator manifests: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file (required).")
+	flags.StringVarP(&tc.OutputDir, "output-dir", "o", "", "Directory to write rendered files to (required).")
+	flags.BoolVar(&tc.Redact, "redact", false, "Replace credentials with a placeholder in rendered files. Default: false.")
+	addVarFlags(cmd, tc)
+
+	cmdutils.MarkFlagRequired(cmd, "config-file")
+	cmdutils.MarkFlagRequired(cmd, "output-dir")
+
+	return cmd
+}
+
 // NewDescribeValidationResultsCmd returns a new cobra command for describing validation results
 func NewDescribeValidationResultsCmd() *cobra.Command {
 	c := cfgmanager.Config()
 	var tc = &cfg.TaskConfig{CliVersion: Version}
 
 	cmd := &cobra.Command{
-		Use:   "describe",
-		Short: "Describe all validation results in a Kubernetes cluster",
-		Long: `Describe all validation results in a Kubernetes cluster
+		Use:   "describe [name]",
+		Short: "Describe validation results in a Kubernetes cluster",
+		Long: `Describe validation results in a Kubernetes cluster
 
 Validation results in the cluster specified by the KUBECONFIG environment variable will be described.
 If the --config-file flag is specified, the KUBECONFIG specified in the validator configuration file will be used instead.
+
+Provide a name to describe a single validation result. Otherwise, all validation results in all
+namespaces are described, unless filtered using --namespace, --plugin, --state, --rule and/or
+--only-failures. Provide --summary to print a single line per validation result, rather than
+a table per rule.
+`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return validator.InitWorkspace(c, cfg.Validator, cfg.ValidatorSubdirs, true)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if err := validator.DescribeValidationResultsCommand(tc, name); err != nil {
+				return fmt.Errorf("failed to describe validation results: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file to read kubeconfig from (optional)")
+	flags.StringVar(&tc.SARIFReport, "sarif-report", "", "Path to write a SARIF 2.1.0 report of validation failures to (optional).")
+	flags.StringVar(&tc.OutputFormat, "output", cfg.OutputFormatTable, fmt.Sprintf("Output format for validation results. One of: %v. Structured formats are written to stdout; all other output is written to stderr.", cfg.OutputFormats))
+	flags.StringVarP(&tc.Namespace, "namespace", "n", "", "Only describe validation results in the specified namespace. Default: all namespaces.")
+	flags.StringSliceVar(&tc.Plugins, "plugin", nil, fmt.Sprintf("Only describe validation results from the specified plugin(s), e.g., --plugin aws,oci. One or more of: %v.", plugins.Codes()))
+	flags.StringVar(&tc.State, "state", "", fmt.Sprintf("Only describe validation results in the specified state. One of: %v.", cfg.ValidationStates))
+	flags.StringVar(&tc.Rule, "rule", "", "Only describe the validation rule with the specified name.")
+	flags.BoolVar(&tc.OnlyFailures, "only-failures", false, "Only describe validation rules which failed. Default: false.")
+	flags.StringVar(&tc.SortBy, "sort", cfg.SortByName, fmt.Sprintf("Sort order for validation results. One of: %v.", cfg.SortOrders))
+	flags.BoolVar(&tc.Summary, "summary", false, "Print a single line summary of each validation result, with counts of passed, failed and errored rules. Only applies to table output. Default: false.")
+	addVarFlags(cmd, tc)
+
+	return cmd
+}
+
+// NewWatchValidationResultsCmd returns a new cobra command for continuously watching validation results
+func NewWatchValidationResultsCmd() *cobra.Command {
+	c := cfgmanager.Config()
+	var tc = &cfg.TaskConfig{CliVersion: Version}
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Continuously watch validation results in a Kubernetes cluster",
+		Long: `Continuously watch validation results in a Kubernetes cluster
+
+Displays a live view of each validation result's state, sink emission state, last validation
+time and most recent state transition, until interrupted. Useful when running validator in
+continuous mode.
+
+Provide --events to instead print a line for each state transition to stdout, for piping
+into other tools. Each line contains the following tab-separated fields: time, namespace,
+name, plugin, previous state and new state.
+
+Validation results in the cluster specified by the KUBECONFIG environment variable will be watched.
+If the --config-file flag is specified, the KUBECONFIG specified in the validator configuration file will be used instead.
 `,
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
@@ -315,8 +558,8 @@ If the --config-file flag is specified, the KUBECONFIG specified in the validato
 			return validator.InitWorkspace(c, cfg.Validator, cfg.ValidatorSubdirs, true)
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
-			if err := validator.DescribeValidationResultsCommand(tc); err != nil {
-				return fmt.Errorf("failed to describe validation results: %w", err)
+			if err := validator.WatchCommand(tc); err != nil {
+				return fmt.Errorf("failed to watch validation results: %w", err)
 			}
 			return nil
 		},
@@ -324,6 +567,11 @@ If the --config-file flag is specified, the KUBECONFIG specified in the validato
 
 	flags := cmd.Flags()
 	flags.StringVarP(&tc.ConfigFile, "config-file", "f", "", "Validator configuration file to read kubeconfig from (optional)")
+	flags.StringVarP(&tc.Namespace, "namespace", "n", "", "Only watch validation results in the specified namespace. Default: all namespaces.")
+	flags.StringSliceVar(&tc.Plugins, "plugin", nil, fmt.Sprintf("Only watch validation results from the specified plugin(s), e.g., --plugin aws,oci. One or more of: %v.", plugins.Codes()))
+	flags.StringVar(&tc.Selector, "selector", "", "Label selector used to filter the validation results which are watched, e.g., --selector team=platform.")
+	flags.BoolVar(&tc.Events, "events", false, "Print a line for each state transition, rather than a live view. Default: false.")
+	addVarFlags(cmd, tc)
 
 	return cmd
 }
@@ -352,3 +600,59 @@ For more information about validator, see: https://github.com/validator-labs/val
 
 	return cmd
 }
+
+// addAnswersFlags adds flags for answering configuration prompts non-interactively
+func addAnswersFlags(cmd *cobra.Command, tc *cfg.TaskConfig) {
+	flags := cmd.Flags()
+	flags.StringArrayVar(&tc.Answers, "set", nil, "Answer a configuration prompt by its path within the validator configuration file, e.g., --set kindConfig.useKindCluster=false. Values are parsed as YAML.")
+	flags.StringSliceVar(&tc.AnswersFiles, "values", nil, "Partial validator configuration file(s) answering configuration prompts. Answers provided via --set take precedence.")
+	flags.BoolVar(&tc.NonInteractive, "non-interactive", false, "Fail if a configuration prompt has no answer, rather than prompting for input. Default: false.")
+}
+
+// addVarFlags adds flags for providing the values of variables referenced in configuration files and custom resources
+func addVarFlags(cmd *cobra.Command, tc *cfg.TaskConfig) {
+	flags := cmd.Flags()
+	flags.StringArrayVar(&tc.Vars, "var", nil, "Set a variable referenced via ${NAME} or {{ .NAME }} in the configuration file or custom resources, e.g., --var REGION=us-east-1. Takes precedence over --var-file and environment variables.")
+	flags.StringSliceVar(&tc.VarFiles, "var-file", nil, "YAML file(s) mapping variable names to values. Takes precedence over environment variables.")
+}
+
+// addWaitFlags adds flags for controlling how long, and for which validation results, --wait waits
+func addWaitFlags(cmd *cobra.Command, tc *cfg.TaskConfig) {
+	flags := cmd.Flags()
+	flags.DurationVar(&tc.WaitTimeout, "wait-timeout", 0, "Maximum duration to wait for validation to complete. Only applies when --wait is set. Set to 0 to disable. Default: 0.")
+	flags.BoolVar(&tc.FailFast, "fail-fast", false, "Stop waiting as soon as any validation result fails. Only applies when --wait is set. Default: false.")
+	flags.StringSliceVar(&tc.Plugins, "plugin", nil, fmt.Sprintf("Only wait for validation results from the specified plugin(s), e.g., --plugin aws,oci. One or more of: %v. Only applies when --wait is set.", plugins.Codes()))
+	flags.StringVar(&tc.Selector, "selector", "", "Label selector used to filter the validation results which are waited for, e.g., --selector team=platform. Only applies when --wait is set.")
+}
+
+// addHelmBackendFlag adds a flag for selecting how Helm releases are installed, upgraded and uninstalled
+func addHelmBackendFlag(cmd *cobra.Command, tc *cfg.TaskConfig) {
+	flags := cmd.Flags()
+	flags.StringVar(&tc.HelmBackend, "helm-backend", cfg.HelmBackendSDK, fmt.Sprintf("How Helm releases are installed, upgraded and uninstalled. One of: %v. The sdk backend uses the embedded Helm SDK; the binary backend uses the helm binary.", cfg.HelmBackends))
+}
+
+// checkHelmBackend validates the Helm backend, ensuring that the helm binary is available if it is used
+func checkHelmBackend(tc *cfg.TaskConfig) error {
+	switch tc.HelmBackend {
+	case cfg.HelmBackendSDK:
+		return nil
+	case cfg.HelmBackendBinary:
+		return exec.CheckBinaries([]exec.Binary{exec.HelmBin})
+	}
+	return fmt.Errorf("invalid --helm-backend %s. One of: %v", tc.HelmBackend, cfg.HelmBackends)
+}
+
+// addDiffFlags adds flags for comparing the cluster with the validator configuration
+func addDiffFlags(cmd *cobra.Command, tc *cfg.TaskConfig) {
+	flags := cmd.Flags()
+	flags.BoolVar(&tc.DryRun, "dry-run", false, "Print a diff between the cluster and the validator configuration without making any changes. Exits with code 3 if they differ. Default: false.")
+	flags.BoolVar(&tc.Diff, "diff", false, "Print a diff between the cluster and the validator configuration prior to making changes. Default: false.")
+}
+
+// markDiffFlagsExclusive marks the diff flags as mutually exclusive with each other and with flags that they don't apply to
+func markDiffFlagsExclusive(cmd *cobra.Command) {
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "diff")
+	for _, f := range []string{"config-only", "update-passwords", "wait"} {
+		cmd.MarkFlagsMutuallyExclusive("dry-run", f)
+	}
+}
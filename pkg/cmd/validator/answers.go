@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
+)
+
+// initAnswers configures prompts to be answered using the answers provided via --values and --set.
+// In non-interactive mode, prompts without an answer fail rather than waiting for user input.
+func initAnswers(tc *cfg.TaskConfig) error {
+	if len(tc.AnswersFiles) == 0 && len(tc.Answers) == 0 && !tc.NonInteractive {
+		return nil
+	}
+	a, err := answers.Load(components.Schema(), tc.AnswersFiles, tc.Answers)
+	if err != nil {
+		return err
+	}
+	answers.Install(a, tc.NonInteractive)
+	return nil
+}
+
+// applyAnswers applies the answers provided via --values and --set to a validator configuration
+// prior to prompting, so that answered prompts are skipped or default to their answers
+func applyAnswers(vc *components.ValidatorConfig) error {
+	if err := answers.Apply(vc); err != nil {
+		return err
+	}
+	vc.InitPlugins()
+	return nil
+}
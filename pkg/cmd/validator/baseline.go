@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+)
+
+// baseline is a set of known, accepted validation rule failures. Failures matching an
+// unexpired suppression are reported as suppressed, and do not fail a 'rules check' run.
+type baseline struct {
+	Suppressions []*suppression `yaml:"suppressions"`
+
+	now time.Time
+}
+
+// suppression matches the failures of one or more validation rules
+type suppression struct {
+	// Plugin is the code of the plugin which evaluates the rule, e.g., AWS
+	Plugin string `yaml:"plugin"`
+	// Rule is the name of the validation rule. Glob patterns are supported.
+	Rule string `yaml:"rule"`
+	// Failures are optional regular expressions. If provided, every failure message must match one of them.
+	Failures []string `yaml:"failures,omitempty"`
+	// Expires is the date, YYYY-MM-DD, after which the suppression no longer applies
+	Expires string `yaml:"expires"`
+	// Justification explains why the failure is accepted
+	Justification string `yaml:"justification"`
+
+	expiry   time.Time
+	patterns []*regexp.Regexp
+}
+
+// suppressedFailure is a validation rule failure which was suppressed by the baseline
+type suppressedFailure struct {
+	Plugin           string `json:"plugin"`
+	ValidationResult string `json:"validationResult"`
+	Rule             string `json:"rule"`
+	Justification    string `json:"justification"`
+	Expires          string `json:"expires"`
+}
+
+// initBaseline loads the baseline file, if any
+func initBaseline(tc *cfg.TaskConfig) (*baseline, error) {
+	file := resolveBaselinePath(tc)
+	if file == "" {
+		return nil, nil
+	}
+	return loadBaseline(file, time.Now())
+}
+
+// resolveBaselinePath returns the baseline file to use: the file provided via --baseline,
+// or the default baseline file in the current directory, if it exists
+func resolveBaselinePath(tc *cfg.TaskConfig) string {
+	if tc.Baseline != "" {
+		return tc.Baseline
+	}
+	if _, err := os.Stat(cfg.BaselineFile); err == nil {
+		log.InfoCLI("Using baseline file %s", cfg.BaselineFile)
+		return cfg.BaselineFile
+	}
+	return ""
+}
+
+// loadBaseline reads and validates a baseline file. Expired suppressions are retained,
+// but never match, so that the failures they previously suppressed fail the run again.
+func loadBaseline(file string, now time.Time) (*baseline, error) {
+	bs, err := os.ReadFile(file) //#nosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	b := &baseline{now: now}
+	dec := yaml.NewDecoder(bytes.NewReader(bs))
+	dec.KnownFields(true)
+	if err := dec.Decode(b); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %w", file, err)
+	}
+
+	errs := make([]error, 0)
+	for i, s := range b.Suppressions {
+		if err := s.init(); err != nil {
+			errs = append(errs, fmt.Errorf("suppression %d: %w", i, err))
+			continue
+		}
+		if !s.active(now) {
+			log.InfoCLI("Suppression for %s rule %s expired on %s; its failures are no longer suppressed", s.Plugin, s.Rule, s.Expires)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid baseline file %s: %w", file, errors.Join(errs...))
+	}
+	return b, nil
+}
+
+// init validates the suppression and compiles its failure patterns
+func (s *suppression) init() error {
+	if s.Plugin == "" {
+		return errors.New("plugin is required")
+	}
+	if _, err := validationPluginFilter([]string{s.Plugin}); err != nil {
+		return err
+	}
+	if s.Rule == "" {
+		return errors.New("rule is required")
+	}
+	if _, err := path.Match(s.Rule, ""); err != nil {
+		return fmt.Errorf("invalid rule pattern %q: %w", s.Rule, err)
+	}
+	if strings.TrimSpace(s.Justification) == "" {
+		return errors.New("justification is required")
+	}
+	if s.Expires == "" {
+		return errors.New("expires is required")
+	}
+	expiry, err := time.ParseInLocation(time.DateOnly, s.Expires, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid expiry date %q: must be formatted as YYYY-MM-DD", s.Expires)
+	}
+	s.expiry = expiry
+
+	s.patterns = make([]*regexp.Regexp, 0, len(s.Failures))
+	for _, f := range s.Failures {
+		re, err := regexp.Compile(f)
+		if err != nil {
+			return fmt.Errorf("invalid failure pattern %q: %w", f, err)
+		}
+		s.patterns = append(s.patterns, re)
+	}
+	return nil
+}
+
+// active reports whether the suppression applies at the given time. Suppressions expire at the end of their expiry date.
+func (s *suppression) active(now time.Time) bool {
+	return now.Before(s.expiry.AddDate(0, 0, 1))
+}
+
+// matches reports whether the suppression applies to a validation rule failure
+func (s *suppression) matches(plugin string, c vapi.ValidationCondition) bool {
+	if !strings.EqualFold(s.Plugin, plugin) {
+		return false
+	}
+	if ok, _ := path.Match(s.Rule, c.ValidationRule); !ok {
+		return false
+	}
+	if len(s.patterns) == 0 {
+		return true
+	}
+	msgs := c.Failures
+	if len(msgs) == 0 {
+		msgs = []string{c.Message}
+	}
+	for _, m := range msgs {
+		matched := false
+		for _, re := range s.patterns {
+			if re.MatchString(m) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns the first unexpired suppression which applies to a failed validation rule, if any
+func (b *baseline) match(plugin string, c vapi.ValidationCondition) *suppression {
+	if b == nil || c.Status == corev1.ConditionTrue {
+		return nil
+	}
+	for _, s := range b.Suppressions {
+		if s.active(b.now) && s.matches(plugin, c) {
+			return s
+		}
+	}
+	return nil
+}
+
+// matcher returns a function reporting whether a failed validation rule evaluated by a plugin is suppressed
+func (b *baseline) matcher(plugin string) func(vapi.ValidationCondition) bool {
+	return func(c vapi.ValidationCondition) bool {
+		return b.match(plugin, c) != nil
+	}
+}
+
+// suppressedFailures lists the validation rule failures suppressed by the baseline
+func (b *baseline) suppressedFailures(vrs []*vapi.ValidationResult) []suppressedFailure {
+	var suppressed []suppressedFailure
+	for _, vr := range vrs {
+		for _, c := range vr.Status.ValidationConditions {
+			if s := b.match(vr.Spec.Plugin, c); s != nil {
+				suppressed = append(suppressed, suppressedFailure{
+					Plugin:           vr.Spec.Plugin,
+					ValidationResult: vr.Name,
+					Rule:             c.ValidationRule,
+					Justification:    s.Justification,
+					Expires:          s.Expires,
+				})
+			}
+		}
+	}
+	return suppressed
+}
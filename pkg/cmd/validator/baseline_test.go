@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+)
+
+const testBaseline = `suppressions:
+- plugin: aws
+  rule: validation-quota-*
+  failures:
+  - "^Usage for .* exceeds"
+  expires: 2024-06-30
+  justification: Quota increase requested
+- plugin: OCI
+  rule: validation-registry
+  expires: 2024-05-31
+  justification: Registry migration in progress
+`
+
+func writeTestBaseline(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".validatorignore")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadBaseline(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	b, err := loadBaseline(writeTestBaseline(t, testBaseline), now)
+	assert.NoError(t, err)
+	assert.Len(t, b.Suppressions, 2)
+	assert.True(t, b.Suppressions[0].active(now))
+	assert.True(t, b.Suppressions[0].active(time.Date(2024, 6, 30, 23, 59, 0, 0, time.Local)))
+	assert.False(t, b.Suppressions[0].active(time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local)))
+	assert.False(t, b.Suppressions[1].active(now))
+
+	empty, err := loadBaseline(writeTestBaseline(t, ""), now)
+	assert.NoError(t, err)
+	assert.Empty(t, empty.Suppressions)
+}
+
+func TestLoadBaselineInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "unknown field", content: "suppressions:\n- plugin: AWS\n  reason: x\n", err: "field reason not found"},
+		{name: "missing plugin", content: "suppressions:\n- rule: a\n", err: "suppression 0: plugin is required"},
+		{name: "unknown plugin", content: "suppressions:\n- plugin: gcp\n  rule: a\n", err: `invalid plugin "gcp"`},
+		{name: "missing rule", content: "suppressions:\n- plugin: AWS\n", err: "rule is required"},
+		{name: "missing justification", content: "suppressions:\n- plugin: AWS\n  rule: a\n  expires: 2024-01-01\n", err: "justification is required"},
+		{name: "missing expiry", content: "suppressions:\n- plugin: AWS\n  rule: a\n  justification: x\n", err: "expires is required"},
+		{name: "invalid expiry", content: "suppressions:\n- plugin: AWS\n  rule: a\n  justification: x\n  expires: 01/01/2024\n", err: `invalid expiry date "01/01/2024"`},
+		{name: "invalid pattern", content: "suppressions:\n- plugin: AWS\n  rule: a\n  justification: x\n  expires: 2024-01-01\n  failures: ['(']\n", err: `invalid failure pattern "("`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadBaseline(writeTestBaseline(t, tt.content), time.Now())
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestBaselineMatch(t *testing.T) {
+	b, err := loadBaseline(writeTestBaseline(t, testBaseline), time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+
+	quota := testCondition("validation-quota-vpc", corev1.ConditionFalse, "Usage for VPCs exceeds 80%")
+	assert.NotNil(t, b.match("AWS", quota))
+	assert.Nil(t, b.match("Azure", quota))
+
+	// all failures must match a pattern
+	quota.Failures = append(quota.Failures, "Usage for EIPs is unknown")
+	assert.Nil(t, b.match("AWS", quota))
+
+	// passed rules are never suppressed
+	assert.Nil(t, b.match("AWS", testCondition("validation-quota-vpc", corev1.ConditionTrue)))
+
+	// expired suppressions never match
+	assert.Nil(t, b.match("OCI", testCondition("validation-registry", corev1.ConditionFalse, "not found")))
+
+	// a nil baseline suppresses nothing
+	var none *baseline
+	assert.Nil(t, none.match("AWS", testCondition("validation-quota-vpc", corev1.ConditionFalse)))
+}
+
+func TestValidationResponseOkSuppressed(t *testing.T) {
+	b, err := loadBaseline(writeTestBaseline(t, testBaseline), time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+
+	vrr := types.ValidationResponse{}
+	quota := testCondition("validation-quota-vpc", corev1.ConditionFalse, "Usage for VPCs exceeds 80%")
+	vrr.AddResult(&types.ValidationRuleResult{Condition: &quota, State: util.Ptr(vapi.ValidationFailed)}, nil)
+	assert.False(t, validationResponseOk(1, vrr, nil, logr.Discard()))
+	assert.True(t, validationResponseOk(1, vrr, b.matcher("AWS"), logr.Discard()))
+
+	// rule errors are also suppressed
+	errored := testCondition("validation-quota-eip", corev1.ConditionFalse, "Usage for EIPs exceeds 90%")
+	vrr.AddResult(&types.ValidationRuleResult{Condition: &errored, State: util.Ptr(vapi.ValidationFailed)}, errors.New("throttled"))
+	assert.True(t, validationResponseOk(2, vrr, b.matcher("AWS"), logr.Discard()))
+
+	// unexpected result counts are never suppressed
+	assert.False(t, validationResponseOk(3, vrr, b.matcher("AWS"), logr.Discard()))
+}
+
+func TestSuppressedReports(t *testing.T) {
+	b, err := loadBaseline(writeTestBaseline(t, testBaseline), time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+
+	vr := newTestValidationResult("aws", "AWS", vapi.ValidationFailed,
+		testCondition("validation-quota-vpc", corev1.ConditionFalse, "Usage for VPCs exceeds 80%"),
+		testCondition("validation-iam", corev1.ConditionFalse, "missing permission"),
+		testCondition("validation-ami", corev1.ConditionTrue),
+	)
+
+	report := newValidationReport([]vapi.ValidationResult{*vr}, b)
+	assert.Equal(t, []pluginSummary{{Plugin: "AWS", Passed: 1, Failed: 1, Suppressed: 1}}, report.Summary)
+	assert.Equal(t, []suppressedFailure{{
+		Plugin: "AWS", ValidationResult: "aws", Rule: "validation-quota-vpc",
+		Justification: "Quota increase requested", Expires: "2024-06-30",
+	}}, report.Suppressed)
+	assert.Len(t, b.suppressedFailures([]*vapi.ValidationResult{vr}), 1)
+
+	junit := newJUnitReport([]*vapi.ValidationResult{vr}, b)
+	assert.Equal(t, 1, junit.Skipped)
+	assert.Equal(t, 1, junit.Failures)
+	assert.Equal(t, "suppressed: Quota increase requested (expires 2024-06-30)", junit.Suites[0].Cases[0].Skipped.Message)
+	assert.Nil(t, junit.Suites[0].Cases[0].Failure)
+
+	sl := newSARIFLog([]*vapi.ValidationResult{vr}, b, "validator.yaml")
+	assert.Len(t, sl.Runs[0].Results, 2)
+	assert.Equal(t, []sarifSuppression{{Kind: "external", Status: "accepted", Justification: "Quota increase requested (expires 2024-06-30)"}}, sl.Runs[0].Results[0].Suppressions)
+	assert.Empty(t, sl.Runs[0].Results[1].Suppressions)
+
+	vrObjs, err := toUnstructuredResults([]*vapi.ValidationResult{vr})
+	assert.NoError(t, err)
+	s, err := buildValidationResultString(vrObjs[0], b)
+	assert.NoError(t, err)
+	assert.Regexp(t, `Suppressed:\s+Quota increase requested \(expires 2024-06-30\)`, s)
+}
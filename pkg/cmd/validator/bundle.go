@@ -0,0 +1,503 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	cmutate "github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	cstatic "github.com/sigstore/cosign/v2/pkg/oci/static"
+	sigpayload "github.com/sigstore/sigstore/pkg/signature/payload"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	ociauth "github.com/validator-labs/validator-plugin-oci/pkg/auth"
+	ocic "github.com/validator-labs/validator-plugin-oci/pkg/ociclient"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
+)
+
+// BundlePushCommand packages plugin custom resources, or the rules in a validator configuration file,
+// into a rules bundle, pushes it to an OCI registry and optionally signs it
+func BundlePushCommand(tc *cfg.TaskConfig) error {
+	ref, err := parseBundleRef(tc.Ref)
+	if err != nil {
+		return err
+	}
+	files, err := bundleSourceFiles(tc)
+	if err != nil {
+		return err
+	}
+	v, err := vars.Load(tc.VarFiles, tc.Vars)
+	if err != nil {
+		return err
+	}
+	if err := validateBundleFiles(files, v); err != nil {
+		return err
+	}
+
+	img, err := newBundleImage(files)
+	if err != nil {
+		return fmt.Errorf("failed to build rules bundle: %w", err)
+	}
+	opts, err := bundleRemoteOptions(ref, tc)
+	if err != nil {
+		return err
+	}
+
+	log.InfoCLI("Pushing rules bundle with %d file(s) to %s", len(files), ref)
+	if err := remote.Write(ref, img, opts...); err != nil {
+		return fmt.Errorf("failed to push rules bundle: %w", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return fmt.Errorf("failed to get rules bundle digest: %w", err)
+	}
+	dref := ref.Context().Digest(digest.String())
+
+	if tc.SigningKey != "" {
+		if err := signBundle(dref, tc.SigningKey, opts); err != nil {
+			return err
+		}
+		log.InfoCLI("Signed rules bundle %s", dref)
+	}
+
+	log.InfoCLI("\nPushed rules bundle %s. Evaluate its rules via:\n  validatorctl rules check --custom-resources %s%s", dref, ocic.Scheme, dref)
+	return nil
+}
+
+// BundlePullCommand pulls a rules bundle from an OCI registry, optionally verifying its signature,
+// and writes its files to a directory
+func BundlePullCommand(tc *cfg.TaskConfig) error {
+	ref, img, err := pullBundle(tc.Ref, tc)
+	if err != nil {
+		return err
+	}
+	if len(tc.PublicKeys) == 0 {
+		log.InfoCLI("WARNING: the signature of %s was not verified. Provide --public-key to verify it.", ref)
+	}
+	if err := checkBundle(ref, img); err != nil {
+		return err
+	}
+	files, err := readBundle(ref, img)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		rel := filepath.FromSlash(f.name)
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("invalid file name %q in rules bundle %s", f.name, ref)
+		}
+		path := filepath.Join(tc.OutputDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.data, 0600); err != nil {
+			return err
+		}
+		log.InfoCLI("Wrote %s", path)
+	}
+	return nil
+}
+
+// BundleVerifyCommand verifies the signature and contents of a rules bundle
+func BundleVerifyCommand(tc *cfg.TaskConfig) error {
+	if len(tc.PublicKeys) == 0 {
+		return ocic.ErrNoPublicKeys
+	}
+	ref, img, err := pullBundle(tc.Ref, tc)
+	if err != nil {
+		return err
+	}
+	if err := checkBundle(ref, img); err != nil {
+		return err
+	}
+	files, err := readBundle(ref, img)
+	if err != nil {
+		return err
+	}
+
+	log.InfoCLI("Rules bundle %s is valid and contains %d file(s):", ref, len(files))
+	for _, f := range files {
+		log.InfoCLI("  %s", f.name)
+	}
+	return nil
+}
+
+// parseBundleRef parses a rules bundle reference, with or without the oci:// scheme
+func parseBundleRef(s string) (name.Reference, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(s, ocic.Scheme))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %s: %w", s, err)
+	}
+	return ref, nil
+}
+
+// bundleSourceFiles returns the files to package into a rules bundle, named by their path relative to
+// --custom-resources, or one file per plugin if the rules are read from a validator configuration file
+func bundleSourceFiles(tc *cfg.TaskConfig) ([]customResourceFile, error) {
+	if tc.ConfigFile != "" {
+		vc, err := components.NewValidatorFromConfig(tc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validator configuration file: %w", err)
+		}
+		files, err := configBundleFiles(vc)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no plugin rules found in %s", tc.ConfigFile)
+		}
+		return files, nil
+	}
+
+	fi, err := os.Stat(tc.CustomResources)
+	if err != nil {
+		return nil, err
+	}
+	files, err := readLocalCustomResources(tc.CustomResources, tc.Include, tc.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range files {
+		rel := filepath.Base(f.name)
+		if fi.IsDir() {
+			rel, err = filepath.Rel(tc.CustomResources, f.name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		files[i].name = filepath.ToSlash(rel)
+	}
+	return files, nil
+}
+
+// bundleCredentialKeys are the keys of inline credentials, which must never be pushed in a rules bundle
+var bundleCredentialKeys = []string{"apiToken", "clientSecret", "password", "secretAccessKey", "sessionToken"}
+
+// configBundleFiles converts the rules of each enabled plugin in a validator configuration into a custom resource file.
+// Only the rules are bundled; each plugin's auth configuration, and any other fields, are omitted. Rules which
+// contain inline credentials are rejected, as a bundle is meant to be shared.
+func configBundleFiles(vc *components.ValidatorConfig) ([]customResourceFile, error) {
+	files := make([]customResourceFile, 0)
+	for _, p := range plugins.Enabled(vc) {
+		if p.Spec(vc).ResultCount() == 0 {
+			continue
+		}
+		spec, err := plugins.Rules(p.Spec(vc))
+		if err != nil {
+			return nil, err
+		}
+		if err := checkInlineCredentials(spec); err != nil {
+			return nil, fmt.Errorf("refusing to bundle %s rules: %w", p.Code(), err)
+		}
+		data, err := sigsyaml.Marshal(map[string]any{"kind": p.Kind(), "spec": spec})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s rules: %w", p.Code(), err)
+		}
+		files = append(files, customResourceFile{name: strings.ToLower(p.Code()) + ".yaml", data: data})
+	}
+	return files, nil
+}
+
+// checkInlineCredentials returns an error if any field of a plugin spec contains an inline credential
+func checkInlineCredentials(spec any) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if path := findInlineCredential(doc, "spec"); path != "" {
+		return fmt.Errorf("%s contains an inline credential; reference a Kubernetes secret instead", path)
+	}
+	return nil
+}
+
+// findInlineCredential returns the path of the first non-empty credential within a JSON document, if any
+func findInlineCredential(doc any, path string) string {
+	switch v := doc.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if s, ok := v[k].(string); ok && s != "" && slices.Contains(bundleCredentialKeys, k) {
+				return path + "." + k
+			}
+			if p := findInlineCredential(v[k], path+"."+k); p != "" {
+				return p
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if p := findInlineCredential(e, fmt.Sprintf("%s[%d]", path, i)); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// validateBundleFiles ensures that each file contains at least one valid plugin custom resource.
+// Variables are rendered for validation only; the bundle retains them, so each consumer can provide its own values.
+func validateBundleFiles(files []customResourceFile, v vars.Vars) error {
+	for _, f := range files {
+		data, err := v.Render(f.name, f.data)
+		if err != nil {
+			return err
+		}
+		specs, err := decodePluginSpecs(bytes.NewReader(data), f.name)
+		if err != nil {
+			return err
+		}
+		if len(specs) == 0 {
+			return fmt.Errorf("no plugin custom resources found in %s", f.name)
+		}
+	}
+	return nil
+}
+
+// newBundleImage returns an OCI artifact containing one layer per file, titled with the file's name
+func newBundleImage(files []customResourceFile) (ggcrv1.Image, error) {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.MediaType(cfg.RulesBundleConfigMediaType))
+
+	adds := make([]mutate.Addendum, 0, len(files))
+	for _, f := range files {
+		adds = append(adds, mutate.Addendum{
+			Layer:       static.NewLayer(f.data, types.MediaType(cfg.RulesBundleLayerMediaType)),
+			Annotations: map[string]string{cfg.OCIImageTitleAnnotation: f.name},
+		})
+	}
+	return mutate.Append(img, adds...)
+}
+
+// bundleRemoteOptions returns the options for pushing a rules bundle. The OCI client only supports pulling.
+func bundleRemoteOptions(ref name.Reference, tc *cfg.TaskConfig) ([]remote.Option, error) {
+	tlsConfig, err := newTLSConfig(tc)
+	if err != nil {
+		return nil, err
+	}
+	transport := remote.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return []remote.Option{
+		remote.WithAuthFromKeychain(authn.NewMultiKeychain(ociauth.GetKeychain(ref.Context().RegistryStr())...)),
+		remote.WithTransport(transport),
+	}, nil
+}
+
+// signBundle signs a rules bundle with a cosign private key, whose password is read from $COSIGN_PASSWORD.
+// The signature is stored in the bundle's repository, as 'cosign sign --key' would.
+func signBundle(ref name.Digest, keyFile string, opts []remote.Option) error {
+	key, err := os.ReadFile(keyFile) // #nosec
+	if err != nil {
+		return fmt.Errorf("failed to read signing key: %w", err)
+	}
+	signer, err := cosign.LoadPrivateKey(key, []byte(os.Getenv(cfg.CosignPasswordEnv)))
+	if err != nil {
+		return fmt.Errorf("failed to load signing key %s: %w", keyFile, err)
+	}
+
+	payload, err := (&sigpayload.Cosign{Image: ref}).MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal signature payload: %w", err)
+	}
+	sig, err := signer.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to sign rules bundle: %w", err)
+	}
+	ociSig, err := cstatic.NewSignature(payload, base64.StdEncoding.EncodeToString(sig))
+	if err != nil {
+		return err
+	}
+
+	ociOpts := ociremote.WithRemoteOptions(opts...)
+	se, err := ociremote.SignedEntity(ref, ociOpts)
+	if err != nil {
+		return fmt.Errorf("failed to get rules bundle %s: %w", ref, err)
+	}
+	se, err = cmutate.AttachSignatureToEntity(se, ociSig)
+	if err != nil {
+		return err
+	}
+	if err := ociremote.WriteSignatures(ref.Repository, se, ociOpts); err != nil {
+		return fmt.Errorf("failed to push signature of %s: %w", ref, err)
+	}
+	return nil
+}
+
+// pullBundle pulls a rules bundle from an OCI registry, verifying its signature first if public keys were provided.
+// Tags are resolved to a digest up front, so that the bundle which is verified is the bundle which is pulled.
+func pullBundle(src string, tc *cfg.TaskConfig) (name.Reference, ggcrv1.Image, error) {
+	ref, err := parseBundleRef(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := newBundleClient(ref, tc)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref, err = resolveBundleDigest(client, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(tc.PublicKeys) > 0 {
+		if err := verifyBundle(client, ref); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	log.InfoCLI("Pulling rules bundle %s", ref)
+	img, err := client.PullImage(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ref, img, nil
+}
+
+// resolveBundleDigest returns a reference to the digest of a rules bundle
+func resolveBundleDigest(client *ocic.Client, ref name.Reference) (name.Digest, error) {
+	if d, ok := ref.(name.Digest); ok {
+		return d, nil
+	}
+	desc, err := client.Head(ref)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("failed to resolve digest of %s: %w", ref, err)
+	}
+	d := ref.Context().Digest(desc.Digest.String())
+	log.InfoCLI("Resolved rules bundle %s to %s", ref, d)
+	return d, nil
+}
+
+// newBundleClient returns an OCI client for the registry hosting a rules bundle.
+// Credentials are read from the docker config file and cloud provider credential helpers.
+func newBundleClient(ref name.Reference, tc *cfg.TaskConfig) (*ocic.Client, error) {
+	opts := []ocic.Option{
+		ocic.WithMultiAuth(ociauth.GetKeychain(ref.Context().RegistryStr())),
+		ocic.WithTLSConfig(tc.InsecureSkipTLSVerify, "", tc.CAFile),
+	}
+	if len(tc.PublicKeys) > 0 {
+		keys := make([][]byte, 0, len(tc.PublicKeys))
+		for _, f := range tc.PublicKeys {
+			key, err := os.ReadFile(f) // #nosec
+			if err != nil {
+				return nil, fmt.Errorf("failed to read public key: %w", err)
+			}
+			keys = append(keys, key)
+		}
+		opts = append(opts,
+			ocic.WithVerificationPublicKeys(keys),
+			ocic.WithVerificationTimeout(ocic.VerificationTimeout),
+		)
+	}
+
+	client, err := ocic.NewOCIClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCI client: %w", err)
+	}
+	return client, nil
+}
+
+// verifyBundle verifies that a rules bundle was signed by one of the client's public keys
+func verifyBundle(client *ocic.Client, ref name.Reference) error {
+	details, errs := client.VerifySignature(context.Background(), ref)
+	for _, d := range details {
+		log.Debug("%s", d)
+	}
+	if len(errs) > 0 {
+		msgs := make([]string, 0, len(errs))
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return fmt.Errorf("failed to verify signature of %s: %s", ref, strings.Join(msgs, "; "))
+	}
+	log.InfoCLI("Verified signature of %s", ref)
+	return nil
+}
+
+// checkBundle ensures that an OCI artifact is a rules bundle pushed via 'validatorctl bundle push'
+func checkBundle(ref name.Reference, img ggcrv1.Image) error {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return fmt.Errorf("failed to get manifest for %s: %w", ref, err)
+	}
+	if manifest.Config.MediaType != types.MediaType(cfg.RulesBundleConfigMediaType) {
+		return fmt.Errorf("%s is not a rules bundle: unexpected config media type %s", ref, manifest.Config.MediaType)
+	}
+	for _, desc := range manifest.Layers {
+		if desc.MediaType != types.MediaType(cfg.RulesBundleLayerMediaType) {
+			return fmt.Errorf("%s is not a rules bundle: unexpected media type %s for layer %s", ref, desc.MediaType, desc.Digest)
+		}
+		if desc.Annotations[cfg.OCIImageTitleAnnotation] == "" {
+			return fmt.Errorf("%s is not a rules bundle: layer %s has no title", ref, desc.Digest)
+		}
+	}
+	return nil
+}
+
+// readBundle reads the files in a rules bundle, i.e., its layers. Each file is named by
+// its layer's title annotation, or its layer's digest if it has no title.
+func readBundle(ref name.Reference, img ggcrv1.Image) ([]customResourceFile, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest for %s: %w", ref, err)
+	}
+
+	files := make([]customResourceFile, 0, len(manifest.Layers))
+	for _, desc := range manifest.Layers {
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get layer %s of %s: %w", desc.Digest, ref, err)
+		}
+		data, err := readLayer(layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layer %s of %s: %w", desc.Digest, ref, err)
+		}
+		title := desc.Annotations[cfg.OCIImageTitleAnnotation]
+		if title == "" {
+			title = desc.Digest.String()
+		}
+		files = append(files, customResourceFile{name: title, data: data})
+	}
+	return files, nil
+}
+
+// readLayer reads the contents of an OCI layer
+func readLayer(layer ggcrv1.Layer) ([]byte, error) {
+	r, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close() //nolint:errcheck
+	return io.ReadAll(r)
+}
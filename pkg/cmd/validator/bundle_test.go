@@ -0,0 +1,211 @@
+package validator
+
+import (
+	"io"
+	stdlog "log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/stretchr/testify/assert"
+
+	awsapi "github.com/validator-labs/validator-plugin-aws/api/v1alpha1"
+	maasapi "github.com/validator-labs/validator-plugin-maas/api/v1alpha1"
+	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+	ociapi "github.com/validator-labs/validator-plugin-oci/api/v1alpha1"
+	vsphereapi "github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
+)
+
+// httptestServer starts a test server and returns its host
+func httptestServer(t *testing.T, h http.Handler) string {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+// writeKeyPair writes a cosign key pair to a directory and returns the paths of the private and public keys
+func writeKeyPair(t *testing.T, dir, prefix string) (string, string) {
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return []byte("password"), nil })
+	assert.NoError(t, err)
+	priv, pub := filepath.Join(dir, prefix+".key"), filepath.Join(dir, prefix+".pub")
+	assert.NoError(t, os.WriteFile(priv, keys.PrivateBytes, 0600))
+	assert.NoError(t, os.WriteFile(pub, keys.PublicBytes, 0600))
+	return priv, pub
+}
+
+func TestBundlePushPullVerify(t *testing.T) {
+	srv := httptestServer(t, registry.New(registry.Logger(stdlog.New(io.Discard, "", 0))))
+	dir := t.TempDir()
+	t.Setenv(cfg.CosignPasswordEnv, "password")
+	key, pub := writeKeyPair(t, dir, "cosign")
+	_, otherPub := writeKeyPair(t, dir, "other")
+
+	src := filepath.Join(dir, "rules")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "network"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "network", "dns.yaml"), []byte(testCustomResource), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "README.md"), []byte("ignored"), 0600))
+
+	ref := "oci://" + srv + "/rules:v1"
+	tc := &cfg.TaskConfig{Ref: ref, CustomResources: src, Include: []string{"*.yaml"}, SigningKey: key}
+	assert.NoError(t, BundlePushCommand(tc))
+
+	// the bundle's signature is verified with the matching public key only
+	assert.NoError(t, BundleVerifyCommand(&cfg.TaskConfig{Ref: ref, PublicKeys: []string{pub}}))
+	assert.ErrorContains(t, BundleVerifyCommand(&cfg.TaskConfig{Ref: ref, PublicKeys: []string{otherPub}}), "failed to verify signature")
+	assert.ErrorContains(t, BundleVerifyCommand(&cfg.TaskConfig{Ref: ref}), "no public keys provided")
+
+	out := filepath.Join(dir, "out")
+	assert.NoError(t, BundlePullCommand(&cfg.TaskConfig{Ref: ref, OutputDir: out, PublicKeys: []string{pub}}))
+	data, err := os.ReadFile(filepath.Join(out, "network", "dns.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, testCustomResource, string(data))
+	assert.NoFileExists(t, filepath.Join(out, "README.md"))
+
+	// the bundle can be consumed directly by 'rules check'
+	specs, err := readPluginSpecs(&cfg.TaskConfig{CustomResources: ref, PublicKeys: []string{pub}}, vars.Vars{})
+	assert.NoError(t, err)
+	assert.Len(t, specs, 1)
+
+	// unsigned bundles fail verification
+	unsigned := "oci://" + srv + "/rules:unsigned"
+	assert.NoError(t, BundlePushCommand(&cfg.TaskConfig{Ref: unsigned, CustomResources: filepath.Join(src, "network", "dns.yaml")}))
+	assert.ErrorContains(t, BundlePullCommand(&cfg.TaskConfig{Ref: unsigned, OutputDir: out, PublicKeys: []string{pub}}), "failed to verify signature")
+}
+
+func TestPullBundleResolvesDigest(t *testing.T) {
+	// the bundle's tag may only be resolved once, after which it points to another artifact
+	reg := registry.New(registry.Logger(stdlog.New(io.Discard, "", 0)))
+	var tagRequests atomic.Int32
+	srv := httptestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/manifests/v1") && r.Method != http.MethodPut && tagRequests.Add(1) > 1 {
+			http.Error(w, "tag moved", http.StatusConflict)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	dir := t.TempDir()
+	t.Setenv(cfg.CosignPasswordEnv, "password")
+	key, pub := writeKeyPair(t, dir, "cosign")
+
+	file := filepath.Join(dir, "dns.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(testCustomResource), 0600))
+	src := "oci://" + srv + "/rules:v1"
+	assert.NoError(t, BundlePushCommand(&cfg.TaskConfig{Ref: src, CustomResources: file, SigningKey: key}))
+	tagRequests.Store(0)
+
+	ref, img, err := pullBundle(src, &cfg.TaskConfig{PublicKeys: []string{pub}})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), tagRequests.Load())
+
+	digest, ok := ref.(name.Digest)
+	assert.True(t, ok)
+	imgDigest, err := img.Digest()
+	assert.NoError(t, err)
+	assert.Equal(t, imgDigest.String(), digest.DigestStr())
+}
+
+func TestBundlePushInvalid(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules.yaml")
+
+	assert.NoError(t, os.WriteFile(file, []byte("kind: GcpValidator\nspec: {}\n"), 0600))
+	err := BundlePushCommand(&cfg.TaskConfig{Ref: "oci://localhost/rules:v1", CustomResources: file})
+	assert.ErrorContains(t, err, "unknown plugin kind: GcpValidator")
+
+	assert.NoError(t, os.WriteFile(file, []byte("# no documents\n"), 0600))
+	err = BundlePushCommand(&cfg.TaskConfig{Ref: "oci://localhost/rules:v1", CustomResources: file})
+	assert.ErrorContains(t, err, "no plugin custom resources found in rules.yaml")
+
+	// variables are required for validation
+	assert.NoError(t, os.WriteFile(file, []byte(strings.ReplaceAll(testCustomResource, "registry.example.com", "${HOST}")), 0600))
+	err = BundlePushCommand(&cfg.TaskConfig{Ref: "oci://localhost/rules:v1", CustomResources: file})
+	assert.ErrorContains(t, err, "undefined variable(s) in rules.yaml: HOST (line 5)")
+}
+
+func TestBundlePullNotABundle(t *testing.T) {
+	srv := httptestServer(t, registry.New(registry.Logger(stdlog.New(io.Discard, "", 0))))
+	ref, err := parseBundleRef(srv + "/image:v1")
+	assert.NoError(t, err)
+	img, err := mutate.AppendLayers(empty.Image, static.NewLayer([]byte(testCustomResource), types.MediaType("application/yaml")))
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref, img))
+
+	err = BundlePullCommand(&cfg.TaskConfig{Ref: ref.String(), OutputDir: t.TempDir()})
+	assert.ErrorContains(t, err, "is not a rules bundle: unexpected config media type")
+}
+
+func TestConfigBundleFiles(t *testing.T) {
+	vc := components.NewValidatorConfig()
+	networkConfig(vc).Enabled = true
+	networkConfig(vc).Validator.DNSRules = []netapi.DNSRule{{RuleName: "resolve registry", Host: "registry.example.com"}}
+	ociConfig(vc).Enabled = true
+
+	files, err := configBundleFiles(vc)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "network.yaml", files[0].name)
+	assert.Equal(t, `kind: NetworkValidator
+spec:
+  caCerts: {}
+  dnsRules:
+  - host: registry.example.com
+    name: resolve registry
+`, string(files[0].data))
+	assert.NoError(t, validateBundleFiles(files, vars.Vars{}))
+}
+
+func TestConfigBundleFilesOmitsSecrets(t *testing.T) {
+	vc := components.NewValidatorConfig()
+	vsphereConfig(vc).Enabled = true
+	vsphereConfig(vc).Validator.Auth.Account = &vcenter.Account{Username: "admin", Password: "vsphere-s3cr3t", Host: "vcenter.example.com"}
+	vsphereConfig(vc).Validator.NTPValidationRules = []vsphereapi.NTPValidationRule{{RuleName: "ntp", ClusterName: "cluster"}}
+	maasConfig(vc).Enabled = true
+	maasConfig(vc).Validator.Auth.APIToken = "maas-s3cr3t"
+	maasConfig(vc).Validator.ImageRules = []maasapi.ImageRule{{RuleName: "images"}}
+	awsConfig(vc).Enabled = true
+	awsConfig(vc).Validator.Auth.Credentials = &awsapi.Credentials{AccessKeyID: "AKIAS3CR3T", SecretAccessKey: "aws-s3cr3t"}
+	awsConfig(vc).Validator.IamRoleRules = []awsapi.IamRoleRule{{IamRoleName: "role"}}
+
+	files, err := configBundleFiles(vc)
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	img, err := newBundleImage(files)
+	assert.NoError(t, err)
+	layers, err := img.Layers()
+	assert.NoError(t, err)
+	assert.Len(t, layers, 3)
+	for _, l := range layers {
+		data, err := readLayer(l)
+		assert.NoError(t, err)
+		for _, secret := range []string{"vsphere-s3cr3t", "maas-s3cr3t", "AKIAS3CR3T", "aws-s3cr3t"} {
+			assert.NotContains(t, string(data), secret)
+		}
+	}
+
+	// rules w/ inline credentials are rejected
+	ociConfig(vc).Enabled = true
+	ociConfig(vc).Validator.OciRegistryRules = []ociapi.OciRegistryRule{{
+		RuleName: "registry",
+		Host:     "registry.example.com",
+		Auth:     ociapi.Auth{Basic: &ociapi.BasicAuth{Username: "user", Password: "oci-s3cr3t"}},
+	}}
+	_, err = configBundleFiles(vc)
+	assert.EqualError(t, err, "refusing to bundle OCI rules: spec.ociRegistryRules[0].auth.basic.password contains an inline credential; reference a Kubernetes secret instead")
+}
@@ -0,0 +1,250 @@
+package validator
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/encryption"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+	"github.com/validator-labs/validatorctl/pkg/utils/schema"
+)
+
+// EncryptConfigCommand encrypts the sensitive fields of a validator configuration file
+func EncryptConfigCommand(tc *cfg.TaskConfig) error {
+	vc, err := newWritableValidatorConfig(tc)
+	if err != nil {
+		return err
+	}
+	if tc.KeyProvider == "" {
+		tc.KeyProvider = encryption.ProviderKeyring
+	}
+	if err := setKeySource(vc, tc.KeyProvider, tc.KeyFile); err != nil {
+		return err
+	}
+	log.InfoCLI("Encrypting validator configuration using %s key source", tc.KeyProvider)
+	return components.SaveValidatorConfig(vc, tc)
+}
+
+// DecryptConfigCommand removes encryption from a validator configuration file.
+// Sensitive fields are base64-encoded, and remain so until the file is encrypted again.
+// Legacy files are upgraded to the current version, so that they aren't encrypted on save.
+func DecryptConfigCommand(tc *cfg.TaskConfig) error {
+	vc, err := newWritableValidatorConfig(tc)
+	if err != nil {
+		return err
+	}
+	if !vc.Encrypted() {
+		log.InfoCLI("validator configuration file is not encrypted: %s", tc.ConfigFile)
+		if !vc.Legacy() {
+			return nil
+		}
+	}
+	vc.RemoveEncryption()
+	return components.SaveValidatorConfig(vc, tc)
+}
+
+// RotateConfigKeyCommand re-encrypts a validator configuration file using a new data key.
+// Unless a different key source is specified, the data key is wrapped using the existing key source.
+// For the keyring key source, a new key is added to the keyring.
+func RotateConfigKeyCommand(tc *cfg.TaskConfig) error {
+	vc, err := newWritableValidatorConfig(tc)
+	if err != nil {
+		return err
+	}
+	if !vc.Encrypted() {
+		return errors.New("validator configuration file is not encrypted. Run 'validatorctl config encrypt' first")
+	}
+	if tc.KeyProvider == "" {
+		tc.KeyProvider = vc.Encryption.Provider
+		if tc.KeyFile == "" {
+			tc.KeyFile = vc.Encryption.KeyFile
+		}
+	}
+	if err := setKeySource(vc, tc.KeyProvider, tc.KeyFile); err != nil {
+		return err
+	}
+	log.InfoCLI("Rotating validator configuration data key using %s key source", tc.KeyProvider)
+	return components.SaveValidatorConfig(vc, tc)
+}
+
+// newWritableValidatorConfig loads a validator configuration file which is to be rewritten in place.
+// Files which reference variables are rejected up front, as they can't be saved.
+func newWritableValidatorConfig(tc *cfg.TaskConfig) (*components.ValidatorConfig, error) {
+	vc, err := components.NewValidatorFromConfig(tc)
+	if err != nil {
+		return nil, err
+	}
+	if vc.Templated() {
+		return nil, fmt.Errorf("refusing to overwrite validator config file %s: %w", tc.ConfigFile, components.ErrTemplated)
+	}
+	return vc, nil
+}
+
+// setKeySource generates a new data key for the validator configuration, wrapped by the specified key source.
+// If no key file is specified for the keyring key source, the default keyring is used.
+func setKeySource(vc *components.ValidatorConfig, provider, keyFile string) error {
+	if keyFile != "" {
+		var err error
+		keyFile, err = filepath.Abs(keyFile)
+		if err != nil {
+			return err
+		}
+	}
+	ks, err := encryption.NewKeySource(provider, keyFile)
+	if err != nil {
+		return err
+	}
+	return vc.SetKeySource(ks)
+}
+
+// ValidateConfigCommand validates a validator configuration file, reporting the location of each error.
+// Variables referenced by the file are replaced by their values prior to validation.
+func ValidateConfigCommand(tc *cfg.TaskConfig) error {
+	_, data, err := components.ReadValidatorConfig(tc)
+	if err != nil {
+		return err
+	}
+	errs, err := validateConfig(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", tc.ConfigFile, err)
+	}
+	if len(errs) > 0 {
+		for _, e := range errs {
+			log.InfoCLI("%s:%s", tc.ConfigFile, e)
+		}
+		return fmt.Errorf("validator configuration file %s is invalid: %d error(s)", tc.ConfigFile, len(errs))
+	}
+	log.InfoCLI("validator configuration file is valid: %s", tc.ConfigFile)
+	return nil
+}
+
+// MigrateConfigCommand upgrades a validator configuration file to the current version in place.
+// The original file is backed up and a diff of the changes is displayed.
+// Files which reference variables are not migrated, as their values would be written to disk.
+func MigrateConfigCommand(tc *cfg.TaskConfig) error {
+	data, rendered, err := components.ReadValidatorConfig(tc)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(data, rendered) {
+		return fmt.Errorf("refusing to migrate validator config file %s: %w", tc.ConfigFile, components.ErrTemplated)
+	}
+	migrated, applied, err := components.MigrateConfig(data)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		log.InfoCLI("validator configuration file is already at version %d: %s", components.ConfigVersion, tc.ConfigFile)
+		return nil
+	}
+
+	for _, m := range applied {
+		log.InfoCLI("Migrating validator configuration from version %d to %d: %s", m.From, m.From+1, m.Description)
+	}
+	backup := fmt.Sprintf("%s.v%d.bak", tc.ConfigFile, applied[0].From)
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		return fmt.Errorf("failed to back up validator config file: %w", err)
+	}
+
+	lc, err := components.LoadValidatorConfig(tc)
+	if err != nil {
+		return err
+	}
+	if lc.Legacy() && !lc.Encrypted() {
+		// the sensitive values of legacy files are encrypted, which requires the file to be decoded
+		vc, err := components.NewValidatorFromConfig(tc)
+		if err != nil {
+			return err
+		}
+		if err := components.SaveValidatorConfig(vc, tc); err != nil {
+			return err
+		}
+		if migrated, err = os.ReadFile(tc.ConfigFile); err != nil {
+			return fmt.Errorf("failed to read validator config file: %w", err)
+		}
+	} else if err := os.WriteFile(tc.ConfigFile, migrated, 0600); err != nil {
+		return fmt.Errorf("failed to write validator config file: %w", err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(data)),
+		B:        difflib.SplitLines(string(migrated)),
+		FromFile: fmt.Sprintf("%s (version %d)", tc.ConfigFile, applied[0].From),
+		ToFile:   fmt.Sprintf("%s (version %d)", tc.ConfigFile, components.ConfigVersion),
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to diff validator configuration: %w", err)
+	}
+	log.InfoCLI("\n%s", diff)
+	log.InfoCLI("validator configuration file migrated to version %d: %s (backup: %s)", components.ConfigVersion, tc.ConfigFile, backup)
+	return nil
+}
+
+// ConfigSchemaCommand writes the JSON Schema for validator configuration files
+func ConfigSchemaCommand(w io.Writer) error {
+	bs, err := json.MarshalIndent(components.Schema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal validator configuration schema: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(bs))
+	return err
+}
+
+// validateConfig validates a validator configuration file against its schema. If the file is
+// structurally valid, rules which span multiple fields are checked and secret references are resolved.
+func validateConfig(data []byte) ([]schema.Error, error) {
+	root, err := schema.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	// migrate in place, so that errors are reported relative to the original file
+	if _, err := components.MigrateNode(root); err != nil {
+		return nil, err
+	}
+	if errs := schema.Validate(components.Schema(), root); len(errs) > 0 {
+		return errs, nil
+	}
+
+	data, _, err = components.MigrateConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	vc := &components.ValidatorConfig{}
+	if err := yaml.Unmarshal(data, vc); err != nil {
+		return nil, err
+	}
+
+	errs := make([]schema.Error, 0)
+	if !vc.KindConfig.UseKindCluster && vc.Kubeconfig == "" {
+		errs = append(errs, schema.ErrorAt(root, "kubeconfig", "kubeconfig is required unless kindConfig.useKindCluster is true"))
+	}
+	for _, p := range plugins.Enabled(vc) {
+		spec := p.Spec(vc)
+		if spec == nil || reflect.ValueOf(spec).IsNil() || spec.ResultCount() == 0 {
+			path := "plugins." + p.Key() + ".validator"
+			errs = append(errs, schema.ErrorAt(root, path, fmt.Sprintf("%s plugin is enabled, but has no rules", p.Code())))
+		}
+	}
+	for field, err := range vc.CheckSecretRefs() {
+		errs = append(errs, schema.ErrorAt(root, field, err.Error()))
+	}
+
+	slices.SortFunc(errs, func(a, b schema.Error) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column), cmp.Compare(a.Path, b.Path))
+	})
+	return errs, nil
+}
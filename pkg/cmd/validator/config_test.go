@@ -0,0 +1,232 @@
+package validator
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/encryption"
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
+)
+
+func TestConfigEncryptionLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, cfg.KeyringFile)
+
+	defaultKeyringFile := encryption.DefaultKeyringFile
+	encryption.DefaultKeyringFile = func() (string, error) {
+		return keyFile, nil
+	}
+	defer func() { encryption.DefaultKeyringFile = defaultKeyringFile }()
+
+	// a configuration file written prior to encryption being introduced
+	secret := base64.StdEncoding.EncodeToString([]byte("hunter2"))
+	legacy := []byte(`sinkConfig:
+  values:
+    token: ` + secret + `
+azurePlugin:
+  enabled: true
+  clientSecret: ` + secret + `
+`)
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile)}
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, legacy, 0600))
+
+	// legacy files load, and are encrypted using the default keyring, which is not recorded in the file, on save
+	vc, err := components.NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", azureConfig(vc).ClientSecret)
+	assert.True(t, vc.Legacy())
+	assert.False(t, vc.Encrypted())
+
+	assert.NoError(t, components.SaveValidatorConfig(vc, tc))
+	assert.Equal(t, "hunter2", azureConfig(vc).ClientSecret)
+
+	vc = loadConfig(t, tc)
+	assert.Equal(t, components.ConfigVersion, vc.Version)
+	assert.Equal(t, encryption.ProviderKeyring, vc.Encryption.Provider)
+	assert.Empty(t, vc.Encryption.KeyFile)
+	assert.True(t, strings.HasPrefix(azureConfig(vc).ClientSecret, "enc:v1:"))
+	assert.True(t, strings.HasPrefix(vc.SinkConfig.Values["token"], "enc:v1:"))
+	assert.FileExists(t, keyFile)
+
+	// encryption uses the default keyring unless another key source is specified
+	assert.NoError(t, EncryptConfigCommand(tc))
+	vc = loadConfig(t, tc)
+	assert.Equal(t, encryption.ProviderKeyring, vc.Encryption.Provider)
+	assert.Empty(t, vc.Encryption.KeyFile)
+	assert.True(t, encryption.IsEncrypted(azureConfig(vc).ClientSecret))
+	assert.True(t, encryption.IsEncrypted(vc.SinkConfig.Values["token"]))
+	keyID := vc.Encryption.KeyID
+
+	// rotation generates a new keyring key
+	assert.NoError(t, RotateConfigKeyCommand(tc))
+	vc = loadConfig(t, tc)
+	assert.NotEqual(t, keyID, vc.Encryption.KeyID)
+	assert.Empty(t, vc.Encryption.KeyFile)
+
+	vc, err = components.NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", azureConfig(vc).ClientSecret)
+	assert.Equal(t, "hunter2", vc.SinkConfig.Values["token"])
+
+	// decryption reverts to base64-encoded values, which remain so when next saved
+	assert.NoError(t, DecryptConfigCommand(tc))
+	vc = loadConfig(t, tc)
+	assert.Nil(t, vc.Encryption)
+	assert.Equal(t, secret, azureConfig(vc).ClientSecret)
+
+	vc, err = components.NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.NoError(t, components.SaveValidatorConfig(vc, tc))
+	vc = loadConfig(t, tc)
+	assert.Nil(t, vc.Encryption)
+	assert.Equal(t, secret, azureConfig(vc).ClientSecret)
+
+	assert.Error(t, RotateConfigKeyCommand(tc))
+
+	// decrypting a legacy file upgrades it, so that it isn't encrypted on save
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, legacy, 0600))
+	assert.NoError(t, DecryptConfigCommand(tc))
+	vc, err = components.NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.False(t, vc.Legacy())
+	assert.NoError(t, components.SaveValidatorConfig(vc, tc))
+	vc = loadConfig(t, tc)
+	assert.Nil(t, vc.Encryption)
+	assert.Equal(t, secret, azureConfig(vc).ClientSecret)
+}
+
+// loadConfig loads a validator configuration file without decrypting it
+func loadConfig(t *testing.T, tc *cfg.TaskConfig) *components.ValidatorConfig {
+	vc, err := components.LoadValidatorConfig(tc)
+	assert.NoError(t, err)
+	return vc
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Setenv("VALIDATOR_TEST_SECRET", "hunter2")
+
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{
+			name: "valid",
+			data: `kubeconfig: /tmp/kubeconfig
+plugins:
+  azure:
+    enabled: true
+    clientSecret: env:VALIDATOR_TEST_SECRET
+    validator:
+      rbacRules:
+      - name: rule-1
+`,
+			expected: []string{},
+		},
+		{
+			name: "kind cluster",
+			data: `kindConfig:
+  useKindCluster: true
+`,
+			expected: []string{},
+		},
+		{
+			name: "schema errors",
+			data: `kubeconfig: /tmp/kubeconfig
+plugins:
+  awz:
+    enabled: true
+`,
+			expected: []string{`3:3: plugins: unknown field "awz" (did you mean "aws"?)`},
+		},
+		{
+			name: "cross-field errors, legacy plugin sections",
+			data: `azurePlugin:
+  enabled: true
+  clientSecret: env:VALIDATOR_TEST_UNSET
+vspherePlugin:
+  enabled: true
+`,
+			expected: []string{
+				"1:1: kubeconfig: kubeconfig is required unless kindConfig.useKindCluster is true",
+				"2:3: plugins.azure.validator: Azure plugin is enabled, but has no rules",
+				"3:17: plugins.azure.clientSecret: failed to resolve secret reference env:VALIDATOR_TEST_UNSET: environment variable VALIDATOR_TEST_UNSET is not set",
+				"5:3: plugins.vsphere.validator: vSphere plugin is enabled, but has no rules",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := validateConfig([]byte(tt.data))
+			assert.NoError(t, err)
+
+			msgs := make([]string, 0)
+			for _, e := range errs {
+				msgs = append(msgs, e.Error())
+			}
+			assert.Equal(t, tt.expected, msgs)
+		})
+	}
+}
+
+func TestMigrateConfigCommand(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, cfg.KeyringFile)
+
+	defaultKeyringFile := encryption.DefaultKeyringFile
+	encryption.DefaultKeyringFile = func() (string, error) {
+		return keyFile, nil
+	}
+	defer func() { encryption.DefaultKeyringFile = defaultKeyringFile }()
+
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile)}
+	legacy := []byte("kubeconfig: /tmp/kubeconfig\nazurePlugin:\n  clientSecret: " + base64.StdEncoding.EncodeToString([]byte("hunter2")) + "\n")
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, legacy, 0600))
+
+	assert.NoError(t, MigrateConfigCommand(tc))
+
+	backup, err := os.ReadFile(tc.ConfigFile + ".v0.bak")
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, backup)
+
+	// the sensitive values of legacy files are encrypted
+	vc := loadConfig(t, tc)
+	assert.Equal(t, components.ConfigVersion, vc.Version)
+	assert.Equal(t, "/tmp/kubeconfig", vc.Kubeconfig)
+	assert.Equal(t, encryption.ProviderKeyring, vc.Encryption.Provider)
+	assert.True(t, strings.HasPrefix(azureConfig(vc).ClientSecret, "enc:v1:"))
+
+	// migrating the current version is a no-op
+	assert.NoError(t, MigrateConfigCommand(tc))
+}
+
+func TestConfigCommandsRenderVars(t *testing.T) {
+	dir := t.TempDir()
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile)}
+	templated := []byte("kubeconfig: ${KUBECONFIG_PATH}\nkindConfig:\n  useKindCluster: {{ .USE_KIND }}\n")
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, templated, 0600))
+
+	// undefined variables are reported
+	var undefined vars.UndefinedError
+	assert.ErrorAs(t, ValidateConfigCommand(tc), &undefined)
+
+	tc.Vars = []string{"KUBECONFIG_PATH=/tmp/kubeconfig", "USE_KIND=false"}
+	assert.NoError(t, ValidateConfigCommand(tc))
+
+	// templated files are never overwritten with their variables' values
+	for _, cmd := range []func(*cfg.TaskConfig) error{MigrateConfigCommand, EncryptConfigCommand, DecryptConfigCommand, RotateConfigKeyCommand} {
+		assert.ErrorIs(t, cmd(tc), components.ErrTemplated)
+	}
+
+	data, err := os.ReadFile(tc.ConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, templated, data)
+	assert.NoFileExists(t, tc.ConfigFile+".v0.bak")
+}
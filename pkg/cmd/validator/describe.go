@@ -0,0 +1,194 @@
+package validator
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/utils/embed"
+)
+
+// stateFilters maps validation result state filters to validation states
+var stateFilters = map[string]vapi.ValidationState{
+	cfg.ValidationStateFailed:     vapi.ValidationFailed,
+	cfg.ValidationStateSucceeded:  vapi.ValidationSucceeded,
+	cfg.ValidationStateInProgress: vapi.ValidationInProgress,
+}
+
+// resultFilter selects the validation results, and the rules within them, which are described
+type resultFilter struct {
+	plugin       func(string) bool
+	state        vapi.ValidationState
+	rule         string
+	onlyFailures bool
+}
+
+// resultSummary is a single line summary of a validation result
+type resultSummary struct {
+	Namespace string
+	Name      string
+	Plugin    string
+	State     string
+	Passed    int
+	Failed    int
+	Errored   int
+}
+
+// newResultFilter builds a resultFilter from the describe command's flags
+func newResultFilter(tc *cfg.TaskConfig) (resultFilter, error) {
+	pluginFilter, err := validationPluginFilter(tc.Plugins)
+	if err != nil {
+		return resultFilter{}, err
+	}
+	f := resultFilter{
+		plugin:       pluginFilter,
+		rule:         tc.Rule,
+		onlyFailures: tc.OnlyFailures,
+	}
+	if tc.State != "" {
+		state, ok := stateFilters[strings.ToLower(tc.State)]
+		if !ok {
+			return resultFilter{}, fmt.Errorf("invalid state %q: must be one of %v", tc.State, cfg.ValidationStates)
+		}
+		f.state = state
+	}
+	if tc.SortBy != "" && !slices.Contains(cfg.SortOrders, tc.SortBy) {
+		return resultFilter{}, fmt.Errorf("invalid sort order %q: must be one of %v", tc.SortBy, cfg.SortOrders)
+	}
+	return f, nil
+}
+
+// filterValidationResults returns the validation results matching the filter. If the filter selects
+// specific rules, all other rules are removed from the results, and results without any matching rules are omitted.
+func filterValidationResults(vrs []*vapi.ValidationResult, f resultFilter) []*vapi.ValidationResult {
+	filtered := make([]*vapi.ValidationResult, 0, len(vrs))
+	for _, vr := range vrs {
+		if !f.plugin(vr.Spec.Plugin) {
+			continue
+		}
+		if f.state != "" && vr.Status.State != f.state {
+			continue
+		}
+		if f.rule == "" && !f.onlyFailures {
+			filtered = append(filtered, vr)
+			continue
+		}
+
+		conditions := make([]vapi.ValidationCondition, 0)
+		for _, c := range vr.Status.ValidationConditions {
+			if f.rule != "" && c.ValidationRule != f.rule {
+				continue
+			}
+			if f.onlyFailures && c.Status == corev1.ConditionTrue {
+				continue
+			}
+			conditions = append(conditions, c)
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+		vr = vr.DeepCopy()
+		vr.Status.ValidationConditions = conditions
+		filtered = append(filtered, vr)
+	}
+	return filtered
+}
+
+// sortValidationResults sorts validation results in place. Ties are broken by namespace and name.
+func sortValidationResults(vrs []*vapi.ValidationResult, sortBy string) {
+	byName := func(a, b *vapi.ValidationResult) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	}
+	slices.SortStableFunc(vrs, func(a, b *vapi.ValidationResult) int {
+		switch sortBy {
+		case cfg.SortByPlugin:
+			return cmp.Or(cmp.Compare(a.Spec.Plugin, b.Spec.Plugin), byName(a, b))
+		case cfg.SortByState:
+			return cmp.Or(cmp.Compare(a.Status.State, b.Status.State), byName(a, b))
+		case cfg.SortByFailures:
+			_, aFailed, aErrored := countValidationConditions(a)
+			_, bFailed, bErrored := countValidationConditions(b)
+			return cmp.Or(cmp.Compare(bFailed+bErrored, aFailed+aErrored), byName(a, b))
+		default:
+			return byName(a, b)
+		}
+	})
+}
+
+// Validation rule outcomes
+const (
+	rulePassed  = "Passed"
+	ruleFailed  = "Failed"
+	ruleErrored = "Errored"
+)
+
+// conditionOutcome returns whether a validation rule passed, failed, or could not be evaluated
+func conditionOutcome(c vapi.ValidationCondition) string {
+	switch {
+	case c.Status == corev1.ConditionTrue:
+		return rulePassed
+	case c.Message == types.ErrValidationFailed:
+		return ruleErrored
+	default:
+		return ruleFailed
+	}
+}
+
+// countValidationConditions tallies the passed, failed and errored rules in a validation result
+func countValidationConditions(vr *vapi.ValidationResult) (passed, failed, errored int) {
+	for _, c := range vr.Status.ValidationConditions {
+		switch conditionOutcome(c) {
+		case rulePassed:
+			passed++
+		case ruleErrored:
+			errored++
+		default:
+			failed++
+		}
+	}
+	return
+}
+
+// buildValidationResultSummaryString builds a table with a single line summary of each validation result
+func buildValidationResultSummaryString(vrs []*vapi.ValidationResult) (string, error) {
+	summaries := make([]resultSummary, 0, len(vrs))
+	for _, vr := range vrs {
+		passed, failed, errored := countValidationConditions(vr)
+		summaries = append(summaries, resultSummary{
+			Namespace: vr.Namespace,
+			Name:      vr.Name,
+			Plugin:    vr.Spec.Plugin,
+			State:     string(vr.Status.State),
+			Passed:    passed,
+			Failed:    failed,
+			Errored:   errored,
+		})
+	}
+	sb := &strings.Builder{}
+	if err := embed.EFS.PrintTableTemplate(sb, summaries, cfg.Validator, "validation-result-summary.tmpl"); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// toUnstructuredResults converts validation results to unstructured objects
+func toUnstructuredResults(vrs []*vapi.ValidationResult) ([]unstructured.Unstructured, error) {
+	objs := make([]unstructured.Unstructured, 0, len(vrs))
+	for _, vr := range vrs {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(vr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert validation result %s: %w", vr.Name, err)
+		}
+		objs = append(objs, unstructured.Unstructured{Object: m})
+	}
+	return objs, nil
+}
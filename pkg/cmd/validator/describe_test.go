@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+func newTestValidationResult(name, plugin string, state vapi.ValidationState, conditions ...vapi.ValidationCondition) *vapi.ValidationResult {
+	return &vapi.ValidationResult{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "validator"},
+		Spec:       vapi.ValidationResultSpec{Plugin: plugin},
+		Status:     vapi.ValidationResultStatus{State: state, ValidationConditions: conditions},
+	}
+}
+
+func testValidationResults() []*vapi.ValidationResult {
+	pass := func(rule string) vapi.ValidationCondition {
+		return vapi.ValidationCondition{ValidationRule: rule, Status: corev1.ConditionTrue}
+	}
+	fail := func(rule string) vapi.ValidationCondition {
+		return vapi.ValidationCondition{ValidationRule: rule, Status: corev1.ConditionFalse}
+	}
+	errored := func(rule string) vapi.ValidationCondition {
+		return vapi.ValidationCondition{ValidationRule: rule, Status: corev1.ConditionFalse, Message: types.ErrValidationFailed}
+	}
+	return []*vapi.ValidationResult{
+		newTestValidationResult("oci", "OCI", vapi.ValidationFailed, pass("rule-a"), fail("rule-b")),
+		newTestValidationResult("aws", "AWS", vapi.ValidationSucceeded, pass("rule-a"), pass("rule-c")),
+		newTestValidationResult("network", "Network", vapi.ValidationFailed, fail("rule-a"), fail("rule-d"), errored("rule-e")),
+	}
+}
+
+func resultNames(vrs []*vapi.ValidationResult) []string {
+	ns := make([]string, 0, len(vrs))
+	for _, vr := range vrs {
+		ns = append(ns, vr.Name)
+	}
+	return ns
+}
+
+func TestFilterValidationResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		tc         *cfg.TaskConfig
+		expected   []string
+		conditions int
+	}{
+		{name: "no filter", tc: &cfg.TaskConfig{}, expected: []string{"oci", "aws", "network"}, conditions: 7},
+		{name: "plugin", tc: &cfg.TaskConfig{Plugins: []string{"aws", "oci"}}, expected: []string{"oci", "aws"}, conditions: 4},
+		{name: "state", tc: &cfg.TaskConfig{State: "failed"}, expected: []string{"oci", "network"}, conditions: 5},
+		{name: "rule", tc: &cfg.TaskConfig{Rule: "rule-a"}, expected: []string{"oci", "aws", "network"}, conditions: 3},
+		{name: "only failures", tc: &cfg.TaskConfig{OnlyFailures: true}, expected: []string{"oci", "network"}, conditions: 4},
+		{name: "rule and only failures", tc: &cfg.TaskConfig{Rule: "rule-a", OnlyFailures: true}, expected: []string{"network"}, conditions: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newResultFilter(tt.tc)
+			assert.NoError(t, err)
+
+			vrs := testValidationResults()
+			filtered := filterValidationResults(vrs, f)
+			assert.Equal(t, tt.expected, resultNames(filtered))
+
+			conditions := 0
+			for _, vr := range filtered {
+				conditions += len(vr.Status.ValidationConditions)
+			}
+			assert.Equal(t, tt.conditions, conditions)
+
+			// the input must not be modified
+			assert.Len(t, vrs[2].Status.ValidationConditions, 3)
+		})
+	}
+}
+
+func TestNewResultFilterInvalid(t *testing.T) {
+	_, err := newResultFilter(&cfg.TaskConfig{State: "pending"})
+	assert.ErrorContains(t, err, `invalid state "pending"`)
+
+	_, err = newResultFilter(&cfg.TaskConfig{SortBy: "age"})
+	assert.ErrorContains(t, err, `invalid sort order "age"`)
+}
+
+func TestSortValidationResults(t *testing.T) {
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: cfg.SortByName, expected: []string{"aws", "network", "oci"}},
+		{sortBy: cfg.SortByPlugin, expected: []string{"aws", "network", "oci"}},
+		{sortBy: cfg.SortByState, expected: []string{"network", "oci", "aws"}},
+		{sortBy: cfg.SortByFailures, expected: []string{"network", "oci", "aws"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			vrs := testValidationResults()
+			sortValidationResults(vrs, tt.sortBy)
+			assert.Equal(t, tt.expected, resultNames(vrs))
+		})
+	}
+}
+
+func TestBuildValidationResultSummaryString(t *testing.T) {
+	summary, err := buildValidationResultSummaryString(testValidationResults())
+	assert.NoError(t, err)
+	assert.Contains(t, summary, "NAMESPACE")
+	assert.Regexp(t, `validator\s+network\s+Network\s+Failed\s+0\s+2\s+1`, summary)
+	assert.Regexp(t, `validator\s+aws\s+AWS\s+Succeeded\s+2\s+0\s+0`, summary)
+}
+
+func TestToUnstructuredResults(t *testing.T) {
+	vrs := testValidationResults()
+	objs, err := toUnstructuredResults(vrs)
+	assert.NoError(t, err)
+	assert.Len(t, objs, len(vrs))
+
+	vr, err := toValidationResult(objs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, vrs[0].Status, vr.Status)
+}
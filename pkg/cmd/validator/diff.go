@@ -0,0 +1,325 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+	"github.com/validator-labs/validatorctl/pkg/utils/exec"
+	helmsdk "github.com/validator-labs/validatorctl/pkg/utils/helm"
+	"github.com/validator-labs/validatorctl/pkg/utils/kube"
+)
+
+// redactedChangedValue replaces a live sensitive value which differs from the desired value in a diff
+const redactedChangedValue = components.RedactedValue + " (changed)"
+
+// ErrDriftDetected is returned by dry runs when the cluster differs from the validator configuration
+type ErrDriftDetected struct{}
+
+// Error returns the error message for ErrDriftDetected
+func (e ErrDriftDetected) Error() string {
+	return "the cluster differs from the validator configuration"
+}
+
+// getReleaseValues returns the values of a live Helm release, using the specified Helm backend.
+// ok is false if the release is not installed.
+var getReleaseValues = func(kubeconfig, backend, name, namespace string) (values string, ok bool, err error) {
+	if backend != cfg.HelmBackendBinary {
+		return helmsdk.NewSDKClient(kubeconfig).Values(name, namespace)
+	}
+
+	kubeconfigArg := fmt.Sprintf("--kubeconfig=%s", kubeconfig)
+
+	list := osexec.Command(exec.Helm, "list", "-n", namespace, "--filter", fmt.Sprintf("^%s$", name), "-q", kubeconfigArg) // #nosec
+	out, stderr, err := exec.Execute(false, list)
+	if err != nil {
+		return "", false, errors.Wrap(err, stderr)
+	}
+	if strings.TrimSpace(out) == "" {
+		return "", false, nil
+	}
+
+	get := osexec.Command(exec.Helm, "get", "values", name, "-n", namespace, "-o", "yaml", kubeconfigArg) // #nosec
+	out, stderr, err = exec.Execute(false, get)
+	if err != nil {
+		return "", false, errors.Wrap(err, stderr)
+	}
+	return out, true, nil
+}
+
+// getLiveObject returns the live version of an object. It returns nil if the object does not exist.
+var getLiveObject = func(kubeconfig string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	client, err := kube.GetResourceClient(kubeconfig, gvk, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := client.Get(context.Background(), obj.GetName(), metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+	}
+	return live, nil
+}
+
+// diffValidator prints a diff between the validator Helm release's live values and those rendered from
+// the validator configuration. The release's values are read using the specified Helm backend. If
+// includePlugins is true, the diff also covers each enabled plugin's custom resource. Sensitive values
+// are redacted. It returns true if any differences were found.
+func diffValidator(vc *components.ValidatorConfig, helmBackend string, includePlugins bool) (bool, error) {
+	log.Header("Comparing validator configuration with cluster")
+
+	// the redacted configuration is rendered alongside the actual one to locate sensitive values
+	rc, err := vc.Redacted()
+	if err != nil {
+		return false, err
+	}
+
+	// the cluster may not exist yet, e.g., if a kind cluster has yet to be created
+	_, err = os.Stat(vc.Kubeconfig)
+	clusterExists := err == nil
+	if !clusterExists {
+		log.InfoCLI("kubeconfig %s not found; assuming that validator is not installed", vc.Kubeconfig)
+	}
+
+	drift := false
+
+	var values, redactedValues string
+	values, _, err = renderValidatorValues(vc)
+	if err != nil {
+		return false, err
+	}
+	redactedValues, _, err = renderValidatorValues(rc)
+	if err != nil {
+		return false, err
+	}
+	var liveValues string
+	if clusterExists {
+		liveValues, _, err = getReleaseValues(vc.Kubeconfig, helmBackend, cfg.Validator, cfg.Validator)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get validator Helm release values")
+		}
+	}
+	changed, err := printDiff(fmt.Sprintf("helm release %s/%s values", cfg.Validator, cfg.Validator), []byte(liveValues), []byte(values), []byte(redactedValues))
+	if err != nil {
+		return false, err
+	}
+	drift = drift || changed
+
+	if includePlugins {
+		for _, p := range plugins.Enabled(vc) {
+			manifest, err := renderValidatorManifest(p.Chart(), p.RulesTemplate(), p.Spec(vc))
+			if err != nil {
+				return false, err
+			}
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal(manifest, &obj.Object); err != nil {
+				return false, errors.Wrapf(err, "failed to unmarshal %s validator manifest", p.Chart())
+			}
+			redactedManifest, err := renderValidatorManifest(p.Chart(), p.RulesTemplate(), p.Spec(rc))
+			if err != nil {
+				return false, err
+			}
+			redactedObj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal(redactedManifest, &redactedObj.Object); err != nil {
+				return false, errors.Wrapf(err, "failed to unmarshal %s validator manifest", p.Chart())
+			}
+
+			var live *unstructured.Unstructured
+			if clusterExists {
+				live, err = getLiveObject(vc.Kubeconfig, obj)
+				if err != nil {
+					return false, err
+				}
+			}
+			liveSpec := []byte{}
+			if live != nil {
+				if liveSpec, err = yaml.Marshal(live.Object["spec"]); err != nil {
+					return false, err
+				}
+			}
+			spec, err := yaml.Marshal(obj.Object["spec"])
+			if err != nil {
+				return false, err
+			}
+			redactedSpec, err := yaml.Marshal(redactedObj.Object["spec"])
+			if err != nil {
+				return false, err
+			}
+
+			name := fmt.Sprintf("%s %s/%s spec", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+			changed, err := printDiff(name, liveSpec, spec, redactedSpec)
+			if err != nil {
+				return false, err
+			}
+			drift = drift || changed
+		}
+	}
+
+	if !drift {
+		log.InfoCLI("No differences found")
+	}
+	return drift, nil
+}
+
+// printDiff prints a unified diff between the live and desired versions of a YAML document.
+// Both versions are normalized prior to comparison. Each value which is redacted within the
+// redacted version of the desired document is redacted in both versions prior to printing.
+// It returns true if they differ.
+func printDiff(name string, live, desired, redacted []byte) (bool, error) {
+	liveYAML, err := normalizeYAML(live)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to normalize live %s", name)
+	}
+	desiredYAML, err := normalizeYAML(desired)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to normalize desired %s", name)
+	}
+	if liveYAML == desiredYAML {
+		return false, nil
+	}
+	liveYAML, desiredYAML, err = redactYAML(live, desired, redacted)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to redact %s", name)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(desiredYAML),
+		FromFile: fmt.Sprintf("%s (live)", name),
+		ToFile:   fmt.Sprintf("%s (desired)", name),
+		Context:  3,
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to diff %s", name)
+	}
+	log.InfoCLI("\n%s", diff)
+	return true, nil
+}
+
+// redactYAML returns normalized copies of the live and desired versions of a YAML document in
+// which each value that is redacted within the redacted version of the desired document is redacted
+func redactYAML(live, desired, redacted []byte) (string, string, error) {
+	var liveObj, desiredObj, redactedObj any
+	for _, u := range []struct {
+		data []byte
+		obj  *any
+	}{{live, &liveObj}, {desired, &desiredObj}, {redacted, &redactedObj}} {
+		if err := yaml.Unmarshal(u.data, u.obj); err != nil {
+			return "", "", err
+		}
+	}
+	liveObj, desiredObj = redactValues(liveObj, desiredObj, redactedObj)
+
+	liveYAML, err := yaml.Marshal(liveObj)
+	if err != nil {
+		return "", "", err
+	}
+	desiredYAML, err := yaml.Marshal(desiredObj)
+	if err != nil {
+		return "", "", err
+	}
+	l, err := normalizeYAML(liveYAML)
+	if err != nil {
+		return "", "", err
+	}
+	d, err := normalizeYAML(desiredYAML)
+	if err != nil {
+		return "", "", err
+	}
+	return l, d, nil
+}
+
+// redactValues walks the live, desired and redacted versions of a document in parallel, redacting
+// each live and desired value whose redacted counterpart is redacted. Live values which differ from
+// the desired value are replaced by redactedChangedValue so that drift remains visible.
+func redactValues(live, desired, redacted any) (any, any) {
+	switch r := redacted.(type) {
+	case string:
+		d, ok := desired.(string)
+		if !ok || r != components.RedactedValue || d == r {
+			return live, desired
+		}
+		switch l := live.(type) {
+		case nil:
+			return nil, r
+		case string:
+			if l == d {
+				return r, r
+			}
+		}
+		return redactedChangedValue, r
+	case map[string]any:
+		d, ok := desired.(map[string]any)
+		if !ok {
+			return live, desired
+		}
+		l, _ := live.(map[string]any)
+		for k, rv := range r {
+			lv, inLive := l[k]
+			lv, d[k] = redactValues(lv, d[k], rv)
+			if inLive {
+				l[k] = lv
+			}
+		}
+	case []any:
+		d, ok := desired.([]any)
+		if !ok {
+			return live, desired
+		}
+		l, _ := live.([]any)
+		for i := 0; i < len(r) && i < len(d); i++ {
+			var lv any
+			if i < len(l) {
+				lv = l[i]
+			}
+			lv, d[i] = redactValues(lv, d[i], r[i])
+			if i < len(l) {
+				l[i] = lv
+			}
+		}
+	}
+	return live, desired
+}
+
+// normalizeYAML re-marshals a YAML document so that its keys are sorted and formatting is consistent
+func normalizeYAML(data []byte) (string, error) {
+	s := strings.TrimSpace(string(data))
+	if s == "" || s == "null" || s == "{}" {
+		return "", nil
+	}
+	j, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return "", err
+	}
+	y, err := yaml.JSONToYAML(j)
+	if err != nil {
+		return "", err
+	}
+	return string(y), nil
+}
+
+// dryRunResult returns ErrDriftDetected if a dry run found differences between the cluster and the validator configuration
+func dryRunResult(drift bool) error {
+	log.InfoCLI("\nDry run: no changes were made to the cluster")
+	if drift {
+		return ErrDriftDetected{}
+	}
+	return nil
+}
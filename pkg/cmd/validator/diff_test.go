@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+)
+
+func TestDiffValidator(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	assert.NoError(t, os.WriteFile(kubeconfig, []byte{}, 0600))
+
+	vc := components.NewValidatorConfig()
+	vc.Kubeconfig = kubeconfig
+	vc.Release.Chart = vapi.HelmChart{Name: "validator", Repository: "validator", Version: "v0.0.1"}
+	networkConfig(vc).Enabled = true
+	networkConfig(vc).Release.Chart = vapi.HelmChart{Name: cfg.ValidatorPluginNetwork, Repository: cfg.ValidatorPluginNetwork, Version: "v0.0.1"}
+	networkConfig(vc).Validator.DNSRules = []netapi.DNSRule{{RuleName: "dns", Host: "example.com"}}
+
+	values, _, err := renderValidatorValues(vc)
+	assert.NoError(t, err)
+	manifest, err := renderValidatorManifest(cfg.ValidatorPluginNetwork, cfg.ValidatorPluginNetworkTemplate, networkConfig(vc).Validator)
+	assert.NoError(t, err)
+	rendered := &unstructured.Unstructured{}
+	assert.NoError(t, yaml.Unmarshal(manifest, &rendered.Object))
+
+	changedSpec := rendered.DeepCopy()
+	changedSpec.Object["spec"] = map[string]any{"dnsRules": []any{map[string]any{"name": "dns", "host": "example.org"}}}
+
+	tests := []struct {
+		name           string
+		values         string
+		installed      bool
+		live           *unstructured.Unstructured
+		includePlugins bool
+		drift          bool
+	}{
+		{name: "not installed", includePlugins: true, drift: true},
+		{name: "up to date", values: values, installed: true, live: rendered, includePlugins: true},
+		{name: "values differ", values: "foo: bar", installed: true, live: rendered, includePlugins: true, drift: true},
+		{name: "plugin missing", values: values, installed: true, includePlugins: true, drift: true},
+		{name: "plugin differs", values: values, installed: true, live: changedSpec, includePlugins: true, drift: true},
+		{name: "plugins excluded", values: values, installed: true, includePlugins: false},
+	}
+
+	defaultGetReleaseValues, defaultGetLiveObject := getReleaseValues, getLiveObject
+	defer func() { getReleaseValues, getLiveObject = defaultGetReleaseValues, defaultGetLiveObject }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getReleaseValues = func(_, _, _, _ string) (string, bool, error) {
+				return tt.values, tt.installed, nil
+			}
+			getLiveObject = func(_ string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+				assert.Equal(t, rendered.GetName(), obj.GetName())
+				return tt.live, nil
+			}
+			drift, err := diffValidator(vc, cfg.HelmBackendSDK, tt.includePlugins)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.drift, drift)
+		})
+	}
+}
+
+func TestDiffValidatorRedactsSecrets(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	assert.NoError(t, os.WriteFile(kubeconfig, []byte{}, 0600))
+
+	vc := components.NewValidatorConfig()
+	vc.Kubeconfig = kubeconfig
+	vc.Release.Chart = vapi.HelmChart{Name: "validator", Repository: "validator", Version: "v0.0.1"}
+	azureConfig(vc).Enabled = true
+	azureConfig(vc).Release.Chart = vapi.HelmChart{Name: cfg.ValidatorPluginAzure, Repository: cfg.ValidatorPluginAzure, Version: "v0.0.1"}
+
+	// the live release was installed using a previous client secret
+	azureConfig(vc).ClientSecret = "0ld-s3cr3t"
+	liveValues, _, err := renderValidatorValues(vc)
+	assert.NoError(t, err)
+	azureConfig(vc).ClientSecret = "hunter2"
+	values, _, err := renderValidatorValues(vc)
+	assert.NoError(t, err)
+
+	defaultGetReleaseValues := getReleaseValues
+	defer func() { getReleaseValues = defaultGetReleaseValues }()
+
+	tests := []struct {
+		name     string
+		values   string
+		drift    bool
+		contains string
+	}{
+		{name: "secret changed", values: liveValues, drift: true, contains: "AZURE_CLIENT_SECRET: " + components.RedactedValue + " (changed)"},
+		{name: "release missing", drift: true, contains: "AZURE_CLIENT_SECRET: " + components.RedactedValue},
+		{name: "up to date", values: values},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getReleaseValues = func(_, _, _, _ string) (string, bool, error) {
+				return tt.values, tt.values != "", nil
+			}
+
+			out := &bytes.Buffer{}
+			log.SetConsoleOutput(out)
+			defer log.SetConsoleOutput(os.Stdout)
+
+			drift, err := diffValidator(vc, cfg.HelmBackendSDK, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.drift, drift)
+			assert.Contains(t, out.String(), tt.contains)
+			assert.NotContains(t, out.String(), "hunter2")
+			assert.NotContains(t, out.String(), "0ld-s3cr3t")
+		})
+	}
+	assert.Equal(t, "hunter2", azureConfig(vc).ClientSecret)
+}
+
+func TestNormalizeYAML(t *testing.T) {
+	a, err := normalizeYAML([]byte("b: 1\na:\n    - x\n"))
+	assert.NoError(t, err)
+	b, err := normalizeYAML([]byte("a: [x]\nb: 1"))
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	empty, err := normalizeYAML([]byte("null\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", empty)
+}
@@ -0,0 +1,405 @@
+package validator
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/embed"
+)
+
+// historyRun is a single 'rules check' run recorded in the workspace
+type historyRun struct {
+	ID      string
+	Time    time.Time
+	Dir     string
+	Results []*vapi.ValidationResult
+}
+
+// historyRow is a single line summary of a run, including the change in failed rules since the previous run
+type historyRow struct {
+	Run     string `json:"run"`
+	Time    string `json:"time"`
+	Results int    `json:"results"`
+	Passed  int    `json:"passed"`
+	Failed  int    `json:"failed"`
+	Errored int    `json:"errored"`
+	Trend   string `json:"trend"`
+}
+
+// ruleChange is a change in the outcome, or failures, of a single validation rule between two runs
+type ruleChange struct {
+	Plugin          string   `json:"plugin"`
+	Result          string   `json:"result"`
+	Rule            string   `json:"rule"`
+	From            string   `json:"from,omitempty"`
+	To              string   `json:"to,omitempty"`
+	RemovedFailures []string `json:"removedFailures,omitempty"`
+	AddedFailures   []string `json:"addedFailures,omitempty"`
+}
+
+// runDiff is the set of validation rules which changed between two runs
+type runDiff struct {
+	From            string       `json:"from"`
+	To              string       `json:"to"`
+	NewlyFailing    []ruleChange `json:"newlyFailing"`
+	Fixed           []ruleChange `json:"fixed"`
+	ChangedFailures []ruleChange `json:"changedFailures"`
+	Removed         []ruleChange `json:"removed"`
+}
+
+// ruleState is the state of a single validation rule within a run
+type ruleState struct {
+	plugin    string
+	result    string
+	condition vapi.ValidationCondition
+}
+
+// HistoryListCommand prints a summary of each run recorded in the workspace, oldest first
+func HistoryListCommand(workspaceLoc string, tc *cfg.TaskConfig) error {
+	if err := initOutput(tc); err != nil {
+		return err
+	}
+	runs, err := indexHistory(workspaceLoc)
+	if err != nil {
+		return err
+	}
+	rows := historyRows(runs)
+
+	if isStructuredOutput(tc.OutputFormat) {
+		return writeStructuredOutput(os.Stdout, tc.OutputFormat, rows)
+	}
+	if len(rows) == 0 {
+		log.InfoCLI("No validation runs found in workspace %s", workspaceLoc)
+		return nil
+	}
+	sb := &strings.Builder{}
+	if err := embed.EFS.PrintTableTemplate(sb, rows, cfg.Validator, "validation-history.tmpl"); err != nil {
+		return err
+	}
+	log.InfoCLI("%s", sb.String())
+	return nil
+}
+
+// HistoryShowCommand prints the validation results recorded for a single run
+func HistoryShowCommand(workspaceLoc string, tc *cfg.TaskConfig, ref string) error {
+	if err := initOutput(tc); err != nil {
+		return err
+	}
+	filter, err := newResultFilter(tc)
+	if err != nil {
+		return err
+	}
+	runs, err := indexHistory(workspaceLoc)
+	if err != nil {
+		return err
+	}
+	run, err := findHistoryRun(runs, ref)
+	if err != nil {
+		return err
+	}
+
+	vrs := filterValidationResults(run.Results, filter)
+	sortValidationResults(vrs, tc.SortBy)
+
+	if !isStructuredOutput(tc.OutputFormat) {
+		log.InfoCLI("Run %s (%s): %s", run.ID, run.Time.Format(time.DateTime), run.Dir)
+		if len(vrs) == 0 {
+			log.InfoCLI("No matching validation results found")
+			return nil
+		}
+		if tc.Summary {
+			summary, err := buildValidationResultSummaryString(vrs)
+			if err != nil {
+				return err
+			}
+			log.InfoCLI("%s", summary)
+			return nil
+		}
+	}
+
+	vrObjs, err := toUnstructuredResults(vrs)
+	if err != nil {
+		return err
+	}
+	return outputValidationResults(tc.OutputFormat, vrObjs, nil)
+}
+
+// HistoryDiffCommand compares the validation rules evaluated in two runs. By default,
+// the latest run is compared with the run before it.
+func HistoryDiffCommand(workspaceLoc string, tc *cfg.TaskConfig, fromRef, toRef string) error {
+	if err := initOutput(tc); err != nil {
+		return err
+	}
+	if fromRef == "" {
+		fromRef = cfg.HistoryPrevious
+	}
+	if toRef == "" {
+		toRef = cfg.HistoryLatest
+	}
+	runs, err := indexHistory(workspaceLoc)
+	if err != nil {
+		return err
+	}
+	from, err := findHistoryRun(runs, fromRef)
+	if err != nil {
+		return err
+	}
+	to, err := findHistoryRun(runs, toRef)
+	if err != nil {
+		return err
+	}
+
+	d := diffRuns(from, to)
+	if isStructuredOutput(tc.OutputFormat) {
+		return writeStructuredOutput(os.Stdout, tc.OutputFormat, d)
+	}
+	log.InfoCLI("%s", d.String())
+	return nil
+}
+
+// indexHistory returns each timestamped validator run in the workspace which recorded
+// validation results, oldest first
+func indexHistory(workspaceLoc string) ([]*historyRun, error) {
+	entries, err := os.ReadDir(workspaceLoc)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no history found: workspace %s does not exist", workspaceLoc)
+		}
+		return nil, fmt.Errorf("failed to read workspace %s: %w", workspaceLoc, err)
+	}
+
+	prefix := cfg.Validator + "-"
+	runs := make([]*historyRun, 0)
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		id := strings.TrimPrefix(e.Name(), prefix)
+		t, err := time.ParseInLocation(cfg.TimeFormat, id, time.Local)
+		if err != nil {
+			log.Debug("ignoring workspace directory %s: %v", e.Name(), err)
+			continue
+		}
+		dir := filepath.Join(workspaceLoc, e.Name())
+		files, err := filepath.Glob(filepath.Join(dir, "*"+cfg.ValidationResultFileSuffix))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+		run := &historyRun{ID: id, Time: t, Dir: dir, Results: make([]*vapi.ValidationResult, 0, len(files))}
+		for _, f := range files {
+			vr, err := readValidationResultFile(f)
+			if err != nil {
+				return nil, err
+			}
+			run.Results = append(run.Results, vr)
+		}
+		runs = append(runs, run)
+	}
+
+	// timestamps sort lexically in chronological order
+	slices.SortFunc(runs, func(a, b *historyRun) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return runs, nil
+}
+
+// readValidationResultFile reads a validation result written by 'rules check'
+func readValidationResultFile(path string) (*vapi.ValidationResult, error) {
+	bs, err := os.ReadFile(path) //#nosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validation result %s: %w", path, err)
+	}
+	vr := &vapi.ValidationResult{}
+	if err := yaml.Unmarshal(bs, vr); err != nil {
+		return nil, fmt.Errorf("failed to parse validation result %s: %w", path, err)
+	}
+	return vr, nil
+}
+
+// findHistoryRun returns the run with the specified ID. The ID may be a timestamp, a
+// workspace directory name, or one of the aliases 'latest' and 'previous'.
+func findHistoryRun(runs []*historyRun, ref string) (*historyRun, error) {
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no validation runs found; run 'validatorctl rules check' first")
+	}
+	switch ref {
+	case cfg.HistoryLatest:
+		return runs[len(runs)-1], nil
+	case cfg.HistoryPrevious:
+		if len(runs) < 2 {
+			return nil, fmt.Errorf("no previous validation run found: only one run has been recorded")
+		}
+		return runs[len(runs)-2], nil
+	}
+	id := strings.TrimPrefix(filepath.Base(ref), cfg.Validator+"-")
+	for _, r := range runs {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("validation run %q not found; run 'validatorctl history list' to list runs", ref)
+}
+
+// historyRows summarizes each run. The trend is the change in the number of failed and errored rules since the previous run.
+func historyRows(runs []*historyRun) []historyRow {
+	rows := make([]historyRow, 0, len(runs))
+	prevFailures := -1
+	for _, r := range runs {
+		row := historyRow{
+			Run:     r.ID,
+			Time:    r.Time.Format(time.DateTime),
+			Results: len(r.Results),
+			Trend:   "-",
+		}
+		for _, vr := range r.Results {
+			passed, failed, errored := countValidationConditions(vr)
+			row.Passed += passed
+			row.Failed += failed
+			row.Errored += errored
+		}
+		failures := row.Failed + row.Errored
+		if prevFailures >= 0 {
+			row.Trend = fmt.Sprintf("%+d", failures-prevFailures)
+		}
+		prevFailures = failures
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ruleStates indexes the validation rules evaluated in a run by result and rule name
+func ruleStates(run *historyRun) map[string]ruleState {
+	states := make(map[string]ruleState)
+	for _, vr := range run.Results {
+		for _, c := range vr.Status.ValidationConditions {
+			states[vr.Name+"/"+c.ValidationRule] = ruleState{plugin: vr.Spec.Plugin, result: vr.Name, condition: c}
+		}
+	}
+	return states
+}
+
+// diffRuns compares the outcome of each validation rule in two runs. Rules which fail in the second run,
+// but not the first, are newly failing; rules which fail in the first run and pass in the second are fixed.
+// Rules which fail in both runs, but with a different outcome or failures, are reported as changed.
+func diffRuns(from, to *historyRun) runDiff {
+	d := runDiff{
+		From:            from.ID,
+		To:              to.ID,
+		NewlyFailing:    make([]ruleChange, 0),
+		Fixed:           make([]ruleChange, 0),
+		ChangedFailures: make([]ruleChange, 0),
+		Removed:         make([]ruleChange, 0),
+	}
+	fromStates, toStates := ruleStates(from), ruleStates(to)
+
+	keys := make([]string, 0, len(fromStates)+len(toStates))
+	for k := range fromStates {
+		keys = append(keys, k)
+	}
+	for k := range toStates {
+		if _, ok := fromStates[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		before, inFrom := fromStates[k]
+		after, inTo := toStates[k]
+
+		rc := ruleChange{}
+		if inFrom {
+			rc.Plugin, rc.Result, rc.Rule = before.plugin, before.result, before.condition.ValidationRule
+			rc.From = conditionOutcome(before.condition)
+		}
+		if inTo {
+			rc.Plugin, rc.Result, rc.Rule = after.plugin, after.result, after.condition.ValidationRule
+			rc.To = conditionOutcome(after.condition)
+		}
+		wasFailing := inFrom && rc.From != rulePassed
+		isFailing := inTo && rc.To != rulePassed
+
+		switch {
+		case !inTo:
+			d.Removed = append(d.Removed, rc)
+		case isFailing && !wasFailing:
+			rc.AddedFailures = after.condition.Failures
+			d.NewlyFailing = append(d.NewlyFailing, rc)
+		case wasFailing && !isFailing:
+			rc.RemovedFailures = before.condition.Failures
+			d.Fixed = append(d.Fixed, rc)
+		case wasFailing && isFailing:
+			rc.RemovedFailures = missing(before.condition.Failures, after.condition.Failures)
+			rc.AddedFailures = missing(after.condition.Failures, before.condition.Failures)
+			if rc.From != rc.To || len(rc.RemovedFailures) > 0 || len(rc.AddedFailures) > 0 {
+				d.ChangedFailures = append(d.ChangedFailures, rc)
+			}
+		}
+	}
+	return d
+}
+
+// missing returns the elements of a which are not in b
+func missing(a, b []string) []string {
+	var diff []string
+	for _, s := range a {
+		if !slices.Contains(b, s) {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
+
+// String formats a run diff for display on the console
+func (d runDiff) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Comparing validation run %s with %s\n", d.From, d.To))
+	sb.WriteString(fmt.Sprintf("%d newly failing, %d fixed, %d changed, %d removed\n",
+		len(d.NewlyFailing), len(d.Fixed), len(d.ChangedFailures), len(d.Removed),
+	))
+
+	section := func(title string, changes []ruleChange) {
+		if len(changes) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("\n%s:\n", title))
+		for _, c := range changes {
+			sb.WriteString(fmt.Sprintf("  [%s] %s/%s: %s -> %s\n",
+				c.Plugin, c.Result, c.Rule, outcomeOrNone(c.From), outcomeOrNone(c.To),
+			))
+			for _, f := range c.RemovedFailures {
+				sb.WriteString(fmt.Sprintf("    - %s\n", f))
+			}
+			for _, f := range c.AddedFailures {
+				sb.WriteString(fmt.Sprintf("    + %s\n", f))
+			}
+		}
+	}
+	section("Newly failing rules", d.NewlyFailing)
+	section("Fixed rules", d.Fixed)
+	section("Changed failures", d.ChangedFailures)
+	section("Rules no longer evaluated", d.Removed)
+
+	return sb.String()
+}
+
+func outcomeOrNone(o string) string {
+	if o == "" {
+		return "-"
+	}
+	return o
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+func writeTestRun(t *testing.T, workspace, id string, vrs ...*vapi.ValidationResult) {
+	t.Helper()
+	dir := filepath.Join(workspace, cfg.Validator+"-"+id)
+	assert.NoError(t, os.MkdirAll(dir, 0700))
+	for _, vr := range vrs {
+		bs, err := yaml.Marshal(vr)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, vr.Name+cfg.ValidationResultFileSuffix), bs, 0600))
+	}
+}
+
+func testCondition(rule string, status corev1.ConditionStatus, failures ...string) vapi.ValidationCondition {
+	return vapi.ValidationCondition{ValidationRule: rule, Status: status, Failures: failures}
+}
+
+func TestIndexHistory(t *testing.T) {
+	workspace := t.TempDir()
+
+	_, err := indexHistory(filepath.Join(workspace, "missing"))
+	assert.ErrorContains(t, err, "no history found")
+	assert.NoDirExists(t, filepath.Join(workspace, "missing"))
+
+	writeTestRun(t, workspace, "20240602120000",
+		newTestValidationResult("oci", "OCI", vapi.ValidationFailed, testCondition("rule-a", corev1.ConditionFalse, "a")),
+	)
+	writeTestRun(t, workspace, "20240601120000",
+		newTestValidationResult("oci", "OCI", vapi.ValidationSucceeded, testCondition("rule-a", corev1.ConditionTrue)),
+		newTestValidationResult("aws", "AWS", vapi.ValidationSucceeded, testCondition("rule-b", corev1.ConditionTrue)),
+	)
+	// runs without validation results and unrelated directories are ignored
+	writeTestRun(t, workspace, "20240603120000")
+	assert.NoError(t, os.MkdirAll(filepath.Join(workspace, cfg.Validator+"-latest"), 0700))
+
+	runs, err := indexHistory(workspace)
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "20240601120000", runs[0].ID)
+	assert.Len(t, runs[0].Results, 2)
+	assert.Equal(t, "20240602120000", runs[1].ID)
+	assert.Equal(t, []string{"a"}, runs[1].Results[0].Status.ValidationConditions[0].Failures)
+
+	rows := historyRows(runs)
+	assert.Equal(t, historyRow{Run: "20240601120000", Time: "2024-06-01 12:00:00", Results: 2, Passed: 2, Trend: "-"}, rows[0])
+	assert.Equal(t, historyRow{Run: "20240602120000", Time: "2024-06-02 12:00:00", Results: 1, Failed: 1, Trend: "+1"}, rows[1])
+}
+
+func TestFindHistoryRun(t *testing.T) {
+	_, err := findHistoryRun(nil, cfg.HistoryLatest)
+	assert.ErrorContains(t, err, "no validation runs found")
+
+	runs := []*historyRun{{ID: "20240601120000"}}
+	_, err = findHistoryRun(runs, cfg.HistoryPrevious)
+	assert.ErrorContains(t, err, "no previous validation run found")
+
+	runs = append(runs, &historyRun{ID: "20240602120000"})
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{ref: cfg.HistoryLatest, expected: "20240602120000"},
+		{ref: cfg.HistoryPrevious, expected: "20240601120000"},
+		{ref: "20240601120000", expected: "20240601120000"},
+		{ref: "validator-20240602120000", expected: "20240602120000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			r, err := findHistoryRun(runs, tt.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, r.ID)
+		})
+	}
+
+	_, err = findHistoryRun(runs, "20240603120000")
+	assert.ErrorContains(t, err, `validation run "20240603120000" not found`)
+}
+
+func TestDiffRuns(t *testing.T) {
+	errored := testCondition("rule-e", corev1.ConditionFalse, "connection refused")
+	errored.Message = types.ErrValidationFailed
+
+	from := &historyRun{ID: "1", Results: []*vapi.ValidationResult{
+		newTestValidationResult("oci", "OCI", vapi.ValidationFailed,
+			testCondition("rule-a", corev1.ConditionTrue),
+			testCondition("rule-b", corev1.ConditionFalse, "b1"),
+			testCondition("rule-c", corev1.ConditionFalse, "c1", "c2"),
+			testCondition("rule-d", corev1.ConditionFalse, "d1"),
+			testCondition("rule-f", corev1.ConditionTrue),
+		),
+		newTestValidationResult("aws", "AWS", vapi.ValidationFailed, errored),
+	}}
+	to := &historyRun{ID: "2", Results: []*vapi.ValidationResult{
+		newTestValidationResult("oci", "OCI", vapi.ValidationFailed,
+			testCondition("rule-a", corev1.ConditionFalse, "a1"),
+			testCondition("rule-b", corev1.ConditionTrue),
+			testCondition("rule-c", corev1.ConditionFalse, "c2", "c3"),
+			testCondition("rule-d", corev1.ConditionFalse, "d1"),
+			testCondition("rule-g", corev1.ConditionFalse, "g1"),
+		),
+		newTestValidationResult("aws", "AWS", vapi.ValidationFailed, testCondition("rule-e", corev1.ConditionFalse, "e1")),
+	}}
+
+	d := diffRuns(from, to)
+	assert.Equal(t, []ruleChange{
+		{Plugin: "OCI", Result: "oci", Rule: "rule-a", From: rulePassed, To: ruleFailed, AddedFailures: []string{"a1"}},
+		{Plugin: "OCI", Result: "oci", Rule: "rule-g", To: ruleFailed, AddedFailures: []string{"g1"}},
+	}, d.NewlyFailing)
+	assert.Equal(t, []ruleChange{
+		{Plugin: "OCI", Result: "oci", Rule: "rule-b", From: ruleFailed, To: rulePassed, RemovedFailures: []string{"b1"}},
+	}, d.Fixed)
+	assert.Equal(t, []ruleChange{
+		{Plugin: "AWS", Result: "aws", Rule: "rule-e", From: ruleErrored, To: ruleFailed, RemovedFailures: []string{"connection refused"}, AddedFailures: []string{"e1"}},
+		{Plugin: "OCI", Result: "oci", Rule: "rule-c", From: ruleFailed, To: ruleFailed, RemovedFailures: []string{"c1"}, AddedFailures: []string{"c3"}},
+	}, d.ChangedFailures)
+	assert.Equal(t, []ruleChange{
+		{Plugin: "OCI", Result: "oci", Rule: "rule-f", From: rulePassed},
+	}, d.Removed)
+
+	s := d.String()
+	assert.Contains(t, s, "2 newly failing, 1 fixed, 2 changed, 1 removed")
+	assert.Contains(t, s, "  [OCI] oci/rule-g: - -> Failed\n    + g1\n")
+	assert.Contains(t, s, "  [OCI] oci/rule-c: Failed -> Failed\n    - c1\n    + c3\n")
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite contains the test cases for a single validator plugin
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr,omitempty"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase represents a single validation rule
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitMessage is the body of a failure or error element
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitSkipped marks a test case as skipped, i.e., a validation rule failure suppressed by a baseline
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnitReport writes a JUnit XML report for the provided validation results to path.
+// Failures suppressed by the baseline are reported as skipped.
+func writeJUnitReport(path string, results []*vapi.ValidationResult, bl *baseline) error {
+	bs, err := xml.MarshalIndent(newJUnitReport(results, bl), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+	bs = append([]byte(xml.Header), bs...)
+	bs = append(bs, '\n')
+
+	if err := os.WriteFile(path, bs, 0600); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+func newJUnitReport(results []*vapi.ValidationResult, bl *baseline) junitTestSuites {
+	report := junitTestSuites{
+		Name:   "validator",
+		Suites: make([]junitTestSuite, 0, len(results)),
+	}
+
+	for _, vr := range results {
+		suite := junitTestSuite{
+			Name:  vr.Spec.Plugin,
+			Cases: make([]junitTestCase, 0, len(vr.Status.ValidationConditions)),
+		}
+		for _, c := range vr.Status.ValidationConditions {
+			tc := junitTestCase{
+				Name:      c.ValidationRule,
+				Classname: fmt.Sprintf("%s.%s", vr.Spec.Plugin, c.ValidationType),
+				SystemOut: strings.Join(c.Details, "\n"),
+			}
+			s := bl.match(vr.Spec.Plugin, c)
+			switch {
+			case c.Status == corev1.ConditionTrue:
+				// passed
+			case s != nil:
+				tc.Skipped = &junitSkipped{
+					Message: fmt.Sprintf("suppressed: %s (expires %s)", strings.TrimSpace(s.Justification), s.Expires),
+				}
+				suite.Skipped++
+			case c.Message == types.ErrValidationFailed:
+				// the condition was finalized with an unexpected validation rule error
+				tc.Error = &junitMessage{
+					Message: c.Message,
+					Type:    c.ValidationType,
+					Body:    strings.Join(c.Failures, "\n"),
+				}
+				suite.Errors++
+			default:
+				tc.Failure = &junitMessage{
+					Message: strings.TrimSpace(c.Message),
+					Type:    c.ValidationType,
+					Body:    strings.Join(c.Failures, "\n"),
+				}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	return report
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	results := []*vapi.ValidationResult{
+		{
+			Spec: vapi.ValidationResultSpec{Plugin: "OCI"},
+			Status: vapi.ValidationResultStatus{
+				ValidationConditions: []vapi.ValidationCondition{
+					{
+						ValidationRule: "success-rule",
+						ValidationType: "oci-registry",
+						Message:        "All oci-registry checks passed",
+						Details:        []string{"detail-a"},
+						Status:         corev1.ConditionTrue,
+					},
+					{
+						ValidationRule: "failure-rule",
+						ValidationType: "oci-registry",
+						Message:        "Some oci-registry checks failed",
+						Failures:       []string{"failure-a", "failure-b"},
+						Status:         corev1.ConditionFalse,
+					},
+					{
+						ValidationRule: "error-rule",
+						ValidationType: "oci-registry",
+						Message:        types.ErrValidationFailed,
+						Failures:       []string{"unexpected error"},
+						Status:         corev1.ConditionFalse,
+					},
+				},
+			},
+		},
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="validator" tests="3" failures="1" errors="1">
+  <testsuite name="OCI" tests="3" failures="1" errors="1">
+    <testcase name="success-rule" classname="OCI.oci-registry">
+      <system-out>detail-a</system-out>
+    </testcase>
+    <testcase name="failure-rule" classname="OCI.oci-registry">
+      <failure message="Some oci-registry checks failed" type="oci-registry">failure-a&#xA;failure-b</failure>
+    </testcase>
+    <testcase name="error-rule" classname="OCI.oci-registry">
+      <error message="Validation failed with an unexpected error" type="oci-registry">unexpected error</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	assert.NoError(t, writeJUnitReport(path, results, nil))
+
+	bs, err := os.ReadFile(path) // #nosec
+	assert.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(bs)))
+}
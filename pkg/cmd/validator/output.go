@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+)
+
+// validationReport is the machine-readable representation of one or more validation results
+type validationReport struct {
+	Summary    []pluginSummary         `json:"summary"`
+	Results    []vapi.ValidationResult `json:"results"`
+	Suppressed []suppressedFailure     `json:"suppressed,omitempty"`
+}
+
+// pluginSummary tallies the validation rule outcomes for a single plugin.
+// Failures suppressed by a baseline are not counted as failed or errored.
+type pluginSummary struct {
+	Plugin     string `json:"plugin"`
+	Passed     int    `json:"passed"`
+	Failed     int    `json:"failed"`
+	Errored    int    `json:"errored"`
+	Suppressed int    `json:"suppressed,omitempty"`
+}
+
+// initOutput validates the requested output format. For structured output formats,
+// all console output is redirected to stderr so that stdout only contains the report.
+func initOutput(tc *cfg.TaskConfig) error {
+	if tc.OutputFormat == "" {
+		tc.OutputFormat = cfg.OutputFormatTable
+	}
+	if !slices.Contains(cfg.OutputFormats, tc.OutputFormat) {
+		return fmt.Errorf("invalid output format %q: must be one of %v", tc.OutputFormat, cfg.OutputFormats)
+	}
+	if isStructuredOutput(tc.OutputFormat) {
+		log.SetConsoleOutput(os.Stderr)
+	}
+	return nil
+}
+
+func isStructuredOutput(format string) bool {
+	return format == cfg.OutputFormatJSON || format == cfg.OutputFormatYAML
+}
+
+// outputValidationResults prints validation results as tables, or writes a
+// validation report to stdout if a structured output format was requested
+func outputValidationResults(format string, validationResults []unstructured.Unstructured, bl *baseline) error {
+	if !isStructuredOutput(format) {
+		return printValidationResults(validationResults, bl)
+	}
+	vrs := make([]vapi.ValidationResult, 0, len(validationResults))
+	for _, vrObj := range validationResults {
+		vr, err := toValidationResult(vrObj)
+		if err != nil {
+			return err
+		}
+		vrs = append(vrs, *vr)
+	}
+	return writeValidationReport(os.Stdout, format, newValidationReport(vrs, bl))
+}
+
+func newValidationReport(vrs []vapi.ValidationResult, bl *baseline) validationReport {
+	report := validationReport{
+		Summary: make([]pluginSummary, 0),
+		Results: vrs,
+	}
+	idx := make(map[string]int)
+
+	for _, vr := range vrs {
+		i, ok := idx[vr.Spec.Plugin]
+		if !ok {
+			i = len(report.Summary)
+			idx[vr.Spec.Plugin] = i
+			report.Summary = append(report.Summary, pluginSummary{Plugin: vr.Spec.Plugin})
+		}
+		s := &report.Summary[i]
+		for _, c := range vr.Status.ValidationConditions {
+			if sup := bl.match(vr.Spec.Plugin, c); sup != nil {
+				s.Suppressed++
+				report.Suppressed = append(report.Suppressed, suppressedFailure{
+					Plugin:           vr.Spec.Plugin,
+					ValidationResult: vr.Name,
+					Rule:             c.ValidationRule,
+					Justification:    sup.Justification,
+					Expires:          sup.Expires,
+				})
+				continue
+			}
+			switch conditionOutcome(c) {
+			case rulePassed:
+				s.Passed++
+			case ruleErrored:
+				s.Errored++
+			default:
+				s.Failed++
+			}
+		}
+	}
+
+	return report
+}
+
+func writeValidationReport(w io.Writer, format string, report validationReport) error {
+	if err := writeStructuredOutput(w, format, report); err != nil {
+		return fmt.Errorf("failed to write validation report: %w", err)
+	}
+	return nil
+}
+
+// writeStructuredOutput marshals a value to w using a structured output format
+func writeStructuredOutput(w io.Writer, format string, v any) error {
+	var bs []byte
+	var err error
+
+	switch format {
+	case cfg.OutputFormatJSON:
+		bs, err = json.MarshalIndent(v, "", "  ")
+		bs = append(bs, '\n')
+	case cfg.OutputFormatYAML:
+		bs, err = yaml.Marshal(v)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	_, err = w.Write(bs)
+	return err
+}
+
+func toValidationResult(vrObj unstructured.Unstructured) (*vapi.ValidationResult, error) {
+	vr := &vapi.ValidationResult{}
+	bytes, err := vrObj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, vr); err != nil {
+		return nil, err
+	}
+	return vr, nil
+}
+
+func toValidationResults(vrObjs []unstructured.Unstructured) ([]*vapi.ValidationResult, error) {
+	vrs := make([]*vapi.ValidationResult, 0, len(vrObjs))
+	for _, vrObj := range vrObjs {
+		vr, err := toValidationResult(vrObj)
+		if err != nil {
+			return nil, err
+		}
+		vrs = append(vrs, vr)
+	}
+	return vrs, nil
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+func TestNewValidationReport(t *testing.T) {
+	vrs := []vapi.ValidationResult{
+		{
+			Spec: vapi.ValidationResultSpec{Plugin: "OCI"},
+			Status: vapi.ValidationResultStatus{
+				ValidationConditions: []vapi.ValidationCondition{
+					{ValidationRule: "rule-a", Status: corev1.ConditionTrue},
+					{ValidationRule: "rule-b", Status: corev1.ConditionFalse},
+				},
+			},
+		},
+		{
+			Spec: vapi.ValidationResultSpec{Plugin: "Network"},
+			Status: vapi.ValidationResultStatus{
+				ValidationConditions: []vapi.ValidationCondition{
+					{ValidationRule: "rule-c", Status: corev1.ConditionFalse, Message: types.ErrValidationFailed},
+				},
+			},
+		},
+		{
+			Spec: vapi.ValidationResultSpec{Plugin: "OCI"},
+			Status: vapi.ValidationResultStatus{
+				ValidationConditions: []vapi.ValidationCondition{
+					{ValidationRule: "rule-d", Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+
+	report := newValidationReport(vrs, nil)
+
+	assert.Equal(t, []pluginSummary{
+		{Plugin: "OCI", Passed: 2, Failed: 1},
+		{Plugin: "Network", Errored: 1},
+	}, report.Summary)
+	assert.Len(t, report.Results, 3)
+}
+
+func TestWriteValidationReport(t *testing.T) {
+	report := validationReport{
+		Summary: []pluginSummary{{Plugin: "OCI", Passed: 1}},
+		Results: []vapi.ValidationResult{},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:   "json",
+			format: cfg.OutputFormatJSON,
+			expected: `{
+  "summary": [
+    {
+      "plugin": "OCI",
+      "passed": 1,
+      "failed": 0,
+      "errored": 0
+    }
+  ],
+  "results": []
+}
+`,
+		},
+		{
+			name:   "yaml",
+			format: cfg.OutputFormatYAML,
+			expected: `results: []
+summary:
+- errored: 0
+  failed: 0
+  passed: 1
+  plugin: OCI
+`,
+		},
+		{
+			name:    "unsupported",
+			format:  cfg.OutputFormatTable,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := writeValidationReport(buf, tt.format, report)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	vplugins "github.com/validator-labs/validator/pkg/plugins"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+	"github.com/validator-labs/validatorctl/pkg/utils/embed"
+)
+
+// profile is a named preset of validation rules, which can be merged into a validator configuration
+type profile struct {
+	Name        string           `yaml:"name"`
+	Title       string           `yaml:"title"`
+	Description string           `yaml:"description"`
+	Rules       []basePluginSpec `yaml:"rules"`
+
+	// source is either the file the profile was read from, or 'embedded'
+	source string
+	data   []byte
+	specs  []profileSpec
+}
+
+// profileSpec is a profile's rules for a single plugin
+type profileSpec struct {
+	plugin plugins.Plugin
+	spec   vplugins.PluginSpec
+}
+
+// profileRow is a single row of the profile list
+type profileRow struct {
+	Name    string `json:"name"`
+	Title   string `json:"title"`
+	Plugins string `json:"plugins"`
+	Rules   int    `json:"rules"`
+	Source  string `json:"source"`
+}
+
+// ProfilesListCommand lists the available rule profiles
+func ProfilesListCommand(tc *cfg.TaskConfig) error {
+	if err := initOutput(tc); err != nil {
+		return err
+	}
+	profiles, err := loadProfiles(tc.ProfileDir)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]profileRow, 0, len(profiles))
+	for _, p := range profiles {
+		codes := make([]string, 0, len(p.specs))
+		rules := 0
+		for _, ps := range p.specs {
+			codes = append(codes, ps.plugin.Code())
+			rules += ps.spec.ResultCount()
+		}
+		rows = append(rows, profileRow{
+			Name:    p.Name,
+			Title:   p.Title,
+			Plugins: strings.Join(slices.Compact(codes), ","),
+			Rules:   rules,
+			Source:  p.source,
+		})
+	}
+
+	if isStructuredOutput(tc.OutputFormat) {
+		return writeStructuredOutput(os.Stdout, tc.OutputFormat, rows)
+	}
+	sb := &strings.Builder{}
+	if err := embed.EFS.PrintTableTemplate(sb, rows, cfg.Validator, "profiles.tmpl"); err != nil {
+		return err
+	}
+	log.InfoCLI("%s", sb.String())
+	return nil
+}
+
+// ProfilesShowCommand prints a rule profile, as it is defined, to stdout
+func ProfilesShowCommand(tc *cfg.TaskConfig) error {
+	profiles, err := loadProfiles(tc.ProfileDir)
+	if err != nil {
+		return err
+	}
+	p, err := findProfile(profiles, tc.Profile)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(p.data)
+	return err
+}
+
+// ProfilesApplyCommand merges the rules in a rule profile into a validator configuration file
+func ProfilesApplyCommand(tc *cfg.TaskConfig) error {
+	profiles, err := loadProfiles(tc.ProfileDir)
+	if err != nil {
+		return err
+	}
+	p, err := findProfile(profiles, tc.Profile)
+	if err != nil {
+		return err
+	}
+	vc, err := components.NewValidatorFromConfig(tc)
+	if err != nil {
+		return err
+	}
+	if err := applyProfile(vc, p); err != nil {
+		return err
+	}
+	return components.SaveValidatorConfig(vc, tc)
+}
+
+// applyProfile merges a profile's rules into the validator configuration, enabling plugins as required.
+// Rules in the configuration with the same name as a rule in the profile are replaced.
+func applyProfile(vc *components.ValidatorConfig, p *profile) error {
+	vc.InitPlugins()
+
+	for _, ps := range p.specs {
+		code := ps.plugin.Code()
+		if !ps.plugin.Enabled(vc) {
+			ps.plugin.SetEnabled(vc, true)
+			log.InfoCLI("Enabled the %s plugin. Configure its installation and credentials prior to installing it, e.g., via 'validatorctl install --reconfigure'.", code)
+		}
+		added, replaced, err := plugins.MergeSpec(ps.plugin.Spec(vc), ps.spec)
+		if err != nil {
+			return fmt.Errorf("failed to apply profile %s to %s plugin: %w", p.Name, code, err)
+		}
+		log.InfoCLI("Applied profile %s to %s plugin: %d rule(s) added, %d rule(s) replaced", p.Name, code, len(added), len(replaced))
+		for _, name := range replaced {
+			log.InfoCLI("  replaced existing rule: %s", name)
+		}
+	}
+	return nil
+}
+
+// loadProfiles loads the embedded profiles, and any profiles in a local profile directory.
+// Local profiles take precedence over embedded profiles with the same name.
+func loadProfiles(dir string) ([]*profile, error) {
+	profiles := make(map[string]*profile)
+
+	entries, err := embed.ReadDir(cfg.ValidatorProfiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded profiles: %w", err)
+	}
+	for _, e := range entries {
+		data, err := embed.EFS.ReadFile(cfg.ValidatorProfiles, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		p, err := parseProfile(data, cfg.ProfileSourceEmbedded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid embedded profile %s: %w", e.Name(), err)
+		}
+		profiles[p.Name] = p
+	}
+
+	if dir != "" {
+		local, err := loadLocalProfiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range local {
+			if ep, ok := profiles[p.Name]; ok && ep.source == cfg.ProfileSourceEmbedded {
+				log.InfoCLI("Profile %s from %s overrides the embedded profile with the same name", p.Name, p.source)
+			}
+			profiles[p.Name] = p
+		}
+	}
+
+	ps := make([]*profile, 0, len(profiles))
+	for _, p := range profiles {
+		ps = append(ps, p)
+	}
+	slices.SortFunc(ps, func(a, b *profile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return ps, nil
+}
+
+// loadLocalProfiles loads the profiles defined in the YAML files within a directory
+func loadLocalProfiles(dir string) ([]*profile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile directory: %w", err)
+	}
+
+	seen := make(map[string]string)
+	ps := make([]*profile, 0)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		file := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(file) //#nosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profile: %w", err)
+		}
+		p, err := parseProfile(data, file)
+		if err != nil {
+			return nil, fmt.Errorf("invalid profile %s: %w", file, err)
+		}
+		if other, ok := seen[p.Name]; ok {
+			return nil, fmt.Errorf("profile %s is defined in both %s and %s", p.Name, other, file)
+		}
+		seen[p.Name] = file
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// parseProfile parses and validates a profile. Every rule in a profile must be named,
+// as rules are matched by name when the profile is applied.
+func parseProfile(data []byte, source string) (*profile, error) {
+	p := &profile{source: source, data: data}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, err
+	}
+	if p.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if len(p.Rules) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+
+	for i, r := range p.Rules {
+		plugin, spec, err := r.pluginSpec()
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		added, replaced, err := plugins.MergeSpec(plugin.NewSpec(), spec)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if len(replaced) > 0 {
+			return nil, fmt.Errorf("rules[%d]: duplicate rule name(s) %v", i, replaced)
+		}
+		if len(added) == 0 {
+			return nil, fmt.Errorf("rules[%d]: %s spec contains no rules", i, plugin.Kind())
+		}
+		p.specs = append(p.specs, profileSpec{plugin: plugin, spec: spec})
+	}
+	return p, nil
+}
+
+// findProfile returns the profile with the provided name
+func findProfile(profiles []*profile, name string) (*profile, error) {
+	names := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		if p.Name == name {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return nil, fmt.Errorf("profile %q not found: must be one of %v", name, names)
+}
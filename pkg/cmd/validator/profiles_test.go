@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	network "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+const testProfile = `name: registry
+title: Registry
+rules:
+- kind: NetworkValidator
+  spec:
+    dnsRules:
+    - name: resolve registry
+      host: registry.example.com
+    - name: resolve mirror
+      host: mirror.example.com
+`
+
+func TestLoadProfiles(t *testing.T) {
+	profiles, err := loadProfiles("")
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		names = append(names, p.Name)
+		assert.Equal(t, cfg.ProfileSourceEmbedded, p.source)
+		assert.NotEmpty(t, p.Title)
+		assert.NotEmpty(t, p.Description)
+		assert.NotEmpty(t, p.specs)
+	}
+	assert.Equal(t, []string{"airgapped-registry-reachability", "aks-cluster-creator", "eks-node-role", "vsphere-capi-provisioning"}, names)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "registry.yaml"), []byte(testProfile), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "eks.yml"), []byte("name: eks-node-role\ntitle: Custom\nrules:\n- kind: NetworkValidator\n  spec:\n    dnsRules:\n    - name: a\n      host: a\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0600))
+
+	profiles, err = loadProfiles(dir)
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 5)
+
+	p, err := findProfile(profiles, "eks-node-role")
+	assert.NoError(t, err)
+	assert.Equal(t, "Custom", p.Title)
+	assert.Equal(t, filepath.Join(dir, "eks.yml"), p.source)
+
+	_, err = findProfile(profiles, "gke")
+	assert.ErrorContains(t, err, `profile "gke" not found`)
+
+	_, err = loadProfiles(filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "failed to read profile directory")
+}
+
+func TestParseProfileInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "unknown field", content: "name: a\nrulez: []\n", err: "field rulez not found"},
+		{name: "missing name", content: "rules:\n- kind: NetworkValidator\n", err: "name is required"},
+		{name: "missing rules", content: "name: a\n", err: "at least one rule is required"},
+		{name: "unknown kind", content: "name: a\nrules:\n- kind: GcpValidator\n", err: "rules[0]: unknown plugin kind: GcpValidator"},
+		{name: "no rules", content: "name: a\nrules:\n- kind: NetworkValidator\n  spec: {}\n", err: "NetworkValidator spec contains no rules"},
+		{name: "unnamed rule", content: "name: a\nrules:\n- kind: NetworkValidator\n  spec:\n    dnsRules:\n    - host: a\n", err: "rule 0 in DNSRules has no name"},
+		{name: "duplicate rule", content: "name: a\nrules:\n- kind: NetworkValidator\n  spec:\n    dnsRules:\n    - name: a\n    - name: a\n", err: "duplicate rule name(s) [a]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseProfile([]byte(tt.content), "test")
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	p, err := parseProfile([]byte(testProfile), "test")
+	assert.NoError(t, err)
+
+	// plugin sections missing from the configuration are initialized and enabled
+	vc := &components.ValidatorConfig{}
+	assert.NoError(t, applyProfile(vc, p))
+	assert.True(t, networkConfig(vc).Enabled)
+	assert.False(t, awsConfig(vc).Enabled)
+	assert.Len(t, networkConfig(vc).Validator.DNSRules, 2)
+
+	// rules are replaced by name, and other rules and fields are retained
+	vc = components.NewValidatorConfig()
+	networkConfig(vc).Validator.DNSRules = []network.DNSRule{
+		{RuleName: "resolve internal", Host: "internal.example.com"},
+		{RuleName: "resolve registry", Host: "old.example.com", Server: "10.0.0.1"},
+	}
+	networkConfig(vc).Validator.TCPConnRules = []network.TCPConnRule{{RuleName: "connect", Host: "internal.example.com", Ports: []int{443}}}
+	assert.NoError(t, applyProfile(vc, p))
+	assert.Equal(t, []network.DNSRule{
+		{RuleName: "resolve internal", Host: "internal.example.com"},
+		{RuleName: "resolve registry", Host: "registry.example.com"},
+		{RuleName: "resolve mirror", Host: "mirror.example.com"},
+	}, networkConfig(vc).Validator.DNSRules)
+	assert.Len(t, networkConfig(vc).Validator.TCPConnRules, 1)
+}
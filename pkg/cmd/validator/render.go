@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+)
+
+// Files written by RenderCommand, relative to the output directory
+const (
+	renderChartFile     = "chart.yaml"
+	renderValuesFile    = "values.yaml"
+	renderSpecFile      = "validator-config-spec.yaml"
+	renderNamespaceFile = "namespace.yaml"
+	renderSecretsDir    = "secrets"
+	renderManifestsDir  = "manifests"
+)
+
+// RenderCommand renders the Helm values and manifests required to install validator & validator plugin(s)
+// to an output directory, without connecting to a cluster
+func RenderCommand(tc *cfg.TaskConfig) error {
+	vc, err := components.NewValidatorFromConfig(tc)
+	if err != nil {
+		return err
+	}
+	if tc.Redact {
+		vc, err = vc.Redacted()
+		if err != nil {
+			return err
+		}
+	}
+	if len(plugins.Enabled(vc)) == 0 {
+		return errors.New("invalid validator config: at least one plugin must be enabled")
+	}
+	for _, dir := range []string{tc.OutputDir, filepath.Join(tc.OutputDir, renderManifestsDir)} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	files := make([]string, 0)
+	write := func(name string, data []byte) error {
+		path := filepath.Join(tc.OutputDir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+		return nil
+	}
+	writeObject := func(name string, obj any) error {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		return write(name, data)
+	}
+
+	// validator Helm chart & values
+	if err := writeObject(renderChartFile, vc.Release.Chart); err != nil {
+		return err
+	}
+	values, spec, err := renderValidatorValues(vc)
+	if err != nil {
+		return err
+	}
+	if err := write(renderValuesFile, []byte(values)); err != nil {
+		return err
+	}
+	if err := writeObject(renderSpecFile, spec); err != nil {
+		return err
+	}
+
+	// namespace & release secret
+	if err := writeObject(renderNamespaceFile, newNamespace(cfg.Validator)); err != nil {
+		return err
+	}
+	if vc.ReleaseSecret != nil && vc.ReleaseSecret.ShouldCreate() {
+		secret, err := newReleaseSecret(vc.ReleaseSecret)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(tc.OutputDir, renderSecretsDir), 0750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := writeObject(filepath.Join(renderSecretsDir, secret.Name+".yaml"), secret); err != nil {
+			return err
+		}
+	}
+
+	// plugin custom resources
+	for _, p := range plugins.Enabled(vc) {
+		path := filepath.Join(tc.OutputDir, renderManifestsDir, fmt.Sprintf("%s.yaml", p.Chart()))
+		if err := renderValidator(path, p.Chart(), p.RulesTemplate(), p.Spec(vc)); err != nil {
+			return err
+		}
+		files = append(files, path)
+	}
+
+	log.InfoCLI("Rendered validator manifests and Helm values:")
+	for _, f := range files {
+		log.InfoCLI("  %s", f)
+	}
+	if !tc.Redact {
+		log.InfoCLI("\nWARNING: rendered files contain credentials. Use --redact to omit them.")
+	}
+	return nil
+}
+
+// newNamespace returns the namespace which validator is installed into
+func newNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+// newReleaseSecret returns the secret containing the credentials used to pull validator's Helm charts
+func newReleaseSecret(secret *components.Secret) (*corev1.Secret, error) {
+	s := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name,
+			Namespace: cfg.Validator,
+		},
+		Type: corev1.SecretTypeOpaque,
+		// include empty username/password, even if unset, to avoid error in validator
+		StringData: map[string]string{
+			"username": "",
+			"password": "",
+		},
+	}
+	if secret.BasicAuth != nil {
+		s.StringData["username"] = secret.BasicAuth.Username
+		s.StringData["password"] = secret.BasicAuth.Password
+	}
+	if secret.CaCertFile != "" {
+		caCert, err := os.ReadFile(secret.CaCertFile) // #nosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate for secret %s: %w", secret.Name, err)
+		}
+		s.StringData["caCert"] = string(caCert)
+	}
+	return s, nil
+}
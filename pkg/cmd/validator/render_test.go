@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+func TestRenderCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	vc := components.NewValidatorConfig()
+	vc.Release.Chart = vapi.HelmChart{Name: "validator", Repository: "validator", Version: "v0.0.1"}
+	vc.ReleaseSecret.Name = "validator-helm-release"
+	vc.ReleaseSecret.BasicAuth.Username = "bob"
+	vc.ReleaseSecret.BasicAuth.Password = "hunter2"
+	networkConfig(vc).Enabled = true
+	networkConfig(vc).Release.Chart = vapi.HelmChart{Name: cfg.ValidatorPluginNetwork, Repository: cfg.ValidatorPluginNetwork, Version: "v0.0.1"}
+	networkConfig(vc).Validator.DNSRules = []netapi.DNSRule{{RuleName: "dns", Host: "example.com"}}
+
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile)}
+	assert.NoError(t, components.SaveValidatorConfig(vc, tc))
+
+	tests := []struct {
+		name     string
+		redact   bool
+		password string
+	}{
+		{name: "plain", password: "hunter2"},
+		{name: "redacted", redact: true, password: components.RedactedValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc.OutputDir = filepath.Join(dir, tt.name)
+			tc.Redact = tt.redact
+			assert.NoError(t, RenderCommand(tc))
+
+			for _, f := range []string{
+				renderChartFile, renderValuesFile, renderSpecFile, renderNamespaceFile,
+				filepath.Join(renderManifestsDir, cfg.ValidatorPluginNetwork+".yaml"),
+			} {
+				assert.FileExists(t, filepath.Join(tc.OutputDir, f))
+			}
+
+			secret, err := os.ReadFile(filepath.Join(tc.OutputDir, renderSecretsDir, "validator-helm-release.yaml"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(secret), "password: "+tt.password)
+
+			manifest, err := os.ReadFile(filepath.Join(tc.OutputDir, renderManifestsDir, cfg.ValidatorPluginNetwork+".yaml"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(manifest), "host: example.com")
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// sarifLog is the root object of a SARIF 2.1.0 log file
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun contains the results reported by a single validator plugin
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// sarifTool describes the validator plugin that produced a run
+type sarifTool struct {
+	Driver sarifToolComponent `json:"driver"`
+}
+
+// sarifToolComponent identifies a validator plugin and the validation rules it evaluated
+type sarifToolComponent struct {
+	Name           string      `json:"name"`
+	FullName       string      `json:"fullName,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule is a single validation rule
+type sarifRule struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name,omitempty"`
+	ShortDescription *sarifMessage   `json:"shortDescription,omitempty"`
+	Properties       *sarifRuleProps `json:"properties,omitempty"`
+}
+
+// sarifRuleProps contains validator-specific rule metadata
+type sarifRuleProps struct {
+	ValidationType string `json:"validationType"`
+}
+
+// sarifResult is a single failed validation condition
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   *sarifResultProps  `json:"properties,omitempty"`
+}
+
+// sarifLocation is the location of a result. Code scanning requires each result to have a physical location.
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// sarifPhysicalLocation is the file, and region within it, which a result applies to
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+// sarifArtifactLocation is the URI of a file
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion is a region within a file
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifSuppression records that a failed validation condition was suppressed by a baseline
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification"`
+}
+
+// sarifResultProps contains validator-specific result metadata
+type sarifResultProps struct {
+	ValidationResult string   `json:"validationResult"`
+	Failures         []string `json:"failures,omitempty"`
+}
+
+// sarifMessage is a plain text SARIF message
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// writeSARIFReport writes a SARIF 2.1.0 log for the provided validation results to path.
+// Each result is located in artifactURI, the file the validation rules were read from.
+// Failures suppressed by the baseline are reported with an external suppression.
+func writeSARIFReport(path string, results []*vapi.ValidationResult, bl *baseline, artifactURI string) error {
+	bs, err := json.MarshalIndent(newSARIFLog(results, bl, artifactURI), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF report: %w", err)
+	}
+	bs = append(bs, '\n')
+
+	if err := os.WriteFile(path, bs, 0600); err != nil {
+		return fmt.Errorf("failed to write SARIF report: %w", err)
+	}
+	return nil
+}
+
+// sarifArtifactURI returns the URI of the file which validation rules were read from, i.e., the custom
+// resources or the validator configuration file, falling back to the default configuration file name
+func sarifArtifactURI(tc *cfg.TaskConfig) string {
+	src := tc.ConfigFile
+	if tc.CustomResources != "" && tc.CustomResources != cfg.CustomResourcesStdin {
+		src = tc.CustomResources
+	}
+	switch {
+	case src == "":
+		return cfg.ValidatorConfigFile
+	case strings.Contains(src, "://"):
+		return redactSource(src)
+	case filepath.IsAbs(src):
+		return (&url.URL{Scheme: "file", Path: filepath.ToSlash(src)}).String()
+	}
+	return filepath.ToSlash(src)
+}
+
+func newSARIFLog(results []*vapi.ValidationResult, bl *baseline, artifactURI string) sarifLog {
+	sl := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    make([]sarifRun, 0),
+	}
+
+	// results are grouped into one run per plugin
+	runIdx := make(map[string]int)
+	ruleIdx := make(map[string]map[string]int)
+
+	for _, vr := range results {
+		i, ok := runIdx[vr.Spec.Plugin]
+		if !ok {
+			i = len(sl.Runs)
+			runIdx[vr.Spec.Plugin] = i
+			ruleIdx[vr.Spec.Plugin] = make(map[string]int)
+			sl.Runs = append(sl.Runs, sarifRun{
+				Tool: sarifTool{
+					Driver: sarifToolComponent{
+						Name:           vr.Spec.Plugin,
+						FullName:       fmt.Sprintf("validator-plugin-%s", strings.ToLower(vr.Spec.Plugin)),
+						InformationURI: "https://github.com/validator-labs/validator",
+						Rules:          make([]sarifRule, 0),
+					},
+				},
+				Results: make([]sarifResult, 0),
+			})
+		}
+		run := &sl.Runs[i]
+		rules := ruleIdx[vr.Spec.Plugin]
+
+		for _, c := range vr.Status.ValidationConditions {
+			j, ok := rules[c.ValidationRule]
+			if !ok {
+				j = len(run.Tool.Driver.Rules)
+				rules[c.ValidationRule] = j
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               c.ValidationRule,
+					Name:             c.ValidationType,
+					ShortDescription: &sarifMessage{Text: fmt.Sprintf("%s validation rule %s", c.ValidationType, c.ValidationRule)},
+					Properties:       &sarifRuleProps{ValidationType: c.ValidationType},
+				})
+			}
+			if c.Status == corev1.ConditionTrue {
+				continue
+			}
+			result := sarifResult{
+				RuleID:    c.ValidationRule,
+				RuleIndex: j,
+				Level:     "error",
+				Message:   sarifMessage{Text: sarifMessageText(c)},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: artifactURI},
+						Region:           sarifRegion{StartLine: 1},
+					},
+				}},
+				Properties: &sarifResultProps{
+					ValidationResult: vr.Name,
+					Failures:         c.Failures,
+				},
+			}
+			if s := bl.match(vr.Spec.Plugin, c); s != nil {
+				result.Suppressions = []sarifSuppression{{
+					Kind:          "external",
+					Status:        "accepted",
+					Justification: fmt.Sprintf("%s (expires %s)", strings.TrimSpace(s.Justification), s.Expires),
+				}}
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	return sl
+}
+
+func sarifMessageText(c vapi.ValidationCondition) string {
+	sb := &strings.Builder{}
+	sb.WriteString(strings.TrimSpace(c.Message))
+	for _, f := range c.Failures {
+		sb.WriteString(fmt.Sprintf("\n- %s", f))
+	}
+	return sb.String()
+}
@@ -6,61 +6,53 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
-	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	toolsWatch "k8s.io/client-go/tools/watch"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	sigsyaml "sigs.k8s.io/yaml"
 
-	awsapi "github.com/validator-labs/validator-plugin-aws/api/v1alpha1"
-	awsconst "github.com/validator-labs/validator-plugin-aws/pkg/constants"
-	awsval "github.com/validator-labs/validator-plugin-aws/pkg/validate"
-	azureapi "github.com/validator-labs/validator-plugin-azure/api/v1alpha1"
-	azureconst "github.com/validator-labs/validator-plugin-azure/pkg/constants"
-	azureval "github.com/validator-labs/validator-plugin-azure/pkg/validate"
-	maasapi "github.com/validator-labs/validator-plugin-maas/api/v1alpha1"
-	maasconst "github.com/validator-labs/validator-plugin-maas/pkg/constants"
-	maasval "github.com/validator-labs/validator-plugin-maas/pkg/validate"
-	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
-	netconst "github.com/validator-labs/validator-plugin-network/pkg/constants"
-	netval "github.com/validator-labs/validator-plugin-network/pkg/validate"
-	ociapi "github.com/validator-labs/validator-plugin-oci/api/v1alpha1"
 	ociauth "github.com/validator-labs/validator-plugin-oci/pkg/auth"
-	ociconst "github.com/validator-labs/validator-plugin-oci/pkg/constants"
 	ocic "github.com/validator-labs/validator-plugin-oci/pkg/ociclient"
-	ocival "github.com/validator-labs/validator-plugin-oci/pkg/validate"
-	vsphereapi "github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
-	vsphereconst "github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
-	vsphereval "github.com/validator-labs/validator-plugin-vsphere/pkg/validate"
 	vapi "github.com/validator-labs/validator/api/v1alpha1"
 	"github.com/validator-labs/validator/pkg/helm"
-	"github.com/validator-labs/validator/pkg/plugins"
+	vplugins "github.com/validator-labs/validator/pkg/plugins"
 	"github.com/validator-labs/validator/pkg/sinks"
 	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
 	vres "github.com/validator-labs/validator/pkg/validationresult"
 
 	"github.com/validator-labs/validatorctl/pkg/components"
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
 	"github.com/validator-labs/validatorctl/pkg/services/validator"
 	"github.com/validator-labs/validatorctl/pkg/utils/embed"
 	"github.com/validator-labs/validatorctl/pkg/utils/exec"
-	"github.com/validator-labs/validatorctl/pkg/utils/file"
+	helmsdk "github.com/validator-labs/validatorctl/pkg/utils/helm"
 	"github.com/validator-labs/validatorctl/pkg/utils/kind"
 	"github.com/validator-labs/validatorctl/pkg/utils/kube"
 	string_utils "github.com/validator-labs/validatorctl/pkg/utils/string"
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
 )
 
 // ErrValidationFailed is returned when one or more validation checks failed
@@ -94,6 +86,9 @@ func InstallValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 	if tc.ConfigFile == "" && tc.Reconfigure {
 		log.FatalCLI("invalid arguments", "error", "cannot reconfigure validator without providing a configuration file")
 	}
+	if err := initAnswers(tc); err != nil {
+		return err
+	}
 
 	configProvided := tc.ConfigFile != ""
 
@@ -110,6 +105,9 @@ func InstallValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 		}
 		if tc.UpdatePasswords {
 			log.Header("Updating credentials in validator configuration file")
+			if err := applyAnswers(vc); err != nil {
+				return err
+			}
 			if err := validator.UpdateValidatorCredentials(vc); err != nil {
 				return err
 			}
@@ -139,6 +137,9 @@ func InstallValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 		// for all other builds, we set a fixed version for the validator and plugins
 		vc.UseFixedVersions = !string_utils.IsDevVersion(tc.CliVersion)
 
+		if err := applyAnswers(vc); err != nil {
+			return err
+		}
 		if err := validator.ReadValidatorConfig(c, tc, vc); err != nil {
 			return errors.Wrap(err, "failed to configure validator")
 		}
@@ -168,7 +169,7 @@ func InstallValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 		return nil
 	}
 
-	if vc.KindConfig.UseKindCluster {
+	if vc.KindConfig.UseKindCluster && !tc.DryRun {
 		if err := createKindCluster(c, vc); err != nil {
 			return err
 		}
@@ -181,11 +182,18 @@ func InstallValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 		if err := ConfigureCommand(c, tc); err != nil {
 			return err
 		}
+		if tc.DryRun {
+			// ConfigureCommand has already compared validator and its plugins with the cluster
+			return nil
+		}
 	}
 
-	if err := deployValidatorAndPlugins(c, vc); err != nil {
+	if err := deployValidatorAndPlugins(c, vc, tc); err != nil {
 		return err
 	}
+	if tc.DryRun {
+		return nil
+	}
 
 	log.InfoCLI(`
 	Configure plugin rules and apply them to a cluster via the following command:
@@ -197,6 +205,9 @@ func InstallValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 
 // ConfigureCommand configures and applies validator plugin rules
 func ConfigureCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
+	if err := initAnswers(tc); err != nil {
+		return err
+	}
 	vc, err := configureValidatorConfig(c, tc)
 	if err != nil {
 		return err
@@ -208,9 +219,19 @@ func ConfigureCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 
 	ensurePluginsHaveRules(vc)
 
+	if tc.DryRun || tc.Diff {
+		drift, err := diffValidator(vc, tc.HelmBackend, true)
+		if err != nil {
+			return err
+		}
+		if tc.DryRun {
+			return dryRunResult(drift)
+		}
+	}
+
 	// upgrade the validator helm release so that plugin rule secrets
 	// are created, e.g., OCI registry secrets, Network basic auth secrets, etc.
-	if err := applyValidator(c, vc); err != nil {
+	if err := applyValidator(c, vc, tc); err != nil {
 		return err
 	}
 	if err := configurePlugins(c, vc, tc); err != nil {
@@ -218,26 +239,46 @@ func ConfigureCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 	}
 	if tc.Wait {
 		log.Header("Waiting for validation to complete")
-		_, err := WatchValidationResults(tc)
-		return err
+		ok, err := WatchValidationResults(tc)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrValidationFailed{}
+		}
 	}
 	return nil
 }
 
 // CheckCommand configures and executes validator plugin rules
 func CheckCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
+	if err := initOutput(tc); err != nil {
+		return err
+	}
+	if err := initAnswers(tc); err != nil {
+		return err
+	}
+	bl, err := initBaseline(tc)
+	if err != nil {
+		return err
+	}
+
 	if tc.CustomResources != "" {
-		pluginSpecs, err := readPluginSpecs(tc.CustomResources)
+		v, err := vars.Load(tc.VarFiles, tc.Vars)
+		if err != nil {
+			return err
+		}
+		pluginSpecs, err := readPluginSpecs(tc, v)
 		if err != nil {
 			return err
 		}
 
 		if len(pluginSpecs) == 0 {
-			log.InfoCLI("No plugin rule custom resources found in %s", tc.CustomResources)
+			log.InfoCLI("No plugin rule custom resources found in %s", redactSource(tc.CustomResources))
 			return nil
 		}
 
-		return executePlugins(c, pluginSpecs, nil)
+		return executePlugins(c, tc, pluginSpecs, nil, bl)
 	}
 
 	vc, err := configureValidatorConfig(c, tc)
@@ -251,7 +292,7 @@ func CheckCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 
 	ensurePluginsHaveRules(vc)
 
-	return executePlugins(c, toPluginSpecs(vc), vc.SinkConfig)
+	return executePlugins(c, tc, plugins.EnabledSpecs(vc), vc.SinkConfig, bl)
 }
 
 func configureValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig) (*components.ValidatorConfig, error) {
@@ -267,6 +308,9 @@ func configureValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig) (*components.Va
 		}
 		if tc.UpdatePasswords {
 			log.Header("Updating plugin credentials in validator configuration file")
+			if err := applyAnswers(vc); err != nil {
+				return nil, err
+			}
 			if err := validator.UpdateValidatorPluginCredentials(vc, tc); err != nil {
 				return nil, err
 			}
@@ -283,6 +327,9 @@ func configureValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig) (*components.Va
 				return nil, errors.Wrap(err, "failed to load validator configuration file")
 			}
 		}
+		if err := applyAnswers(vc); err != nil {
+			return nil, err
+		}
 		if err := validator.ReadValidatorPluginConfig(c, tc, vc); err != nil {
 			return nil, errors.Wrap(err, "failed to configure validator plugin(s)")
 		}
@@ -304,7 +351,7 @@ func configureValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig) (*components.Va
 // ensurePluginsHaveRules checks if enabled plugins have rules configured.
 // If no rules are configured for an enabled plugin, the function exits with an error.
 func ensurePluginsHaveRules(vc *components.ValidatorConfig) {
-	ok, invalidPlugins := vc.EnabledPluginsHaveRules()
+	ok, invalidPlugins := plugins.EnabledPluginsHaveRules(vc)
 	if !ok {
 		log.FatalCLI("invalid validator configuration", "error",
 			fmt.Sprintf("the following plugins are enabled, but have no rules configured: %v", invalidPlugins),
@@ -312,29 +359,16 @@ func ensurePluginsHaveRules(vc *components.ValidatorConfig) {
 	}
 }
 
-func readPluginSpecs(path string) ([]plugins.PluginSpec, error) {
-	fi, err := os.Stat(path)
+// readPluginSpecs reads the plugin specs in the custom resource files provided via --custom-resources
+func readPluginSpecs(tc *cfg.TaskConfig, v vars.Vars) ([]vplugins.PluginSpec, error) {
+	files, err := readCustomResources(tc)
 	if err != nil {
 		return nil, err
 	}
 
-	files := make([]string, 0)
-	if !fi.IsDir() {
-		files = append(files, path)
-	} else {
-		files, err = file.GetFilesInDir(path)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if len(files) == 0 {
-		return nil, fmt.Errorf("no files found in %s", path)
-	}
-
-	ps := make([]plugins.PluginSpec, 0)
+	ps := make([]vplugins.PluginSpec, 0)
 	for _, f := range files {
-		pSpecs, err := readPluginSpecsInFile(f)
+		pSpecs, err := readPluginSpecsInFile(f, v)
 		if err != nil {
 			return nil, err
 		}
@@ -344,91 +378,110 @@ func readPluginSpecs(path string) ([]plugins.PluginSpec, error) {
 	return ps, nil
 }
 
-func readPluginSpecsInFile(file string) ([]plugins.PluginSpec, error) {
-	log.InfoCLI("Reading plugin specs from file: %s", file)
+// readPluginSpecsInFile reads the plugin specs in a file of YAML or JSON documents
+func readPluginSpecsInFile(f customResourceFile, v vars.Vars) ([]vplugins.PluginSpec, error) {
+	log.InfoCLI("Reading plugin specs from file: %s", f.name)
 
-	data, err := os.ReadFile(file) // #nosec
+	data, err := v.Render(f.name, f.data)
 	if err != nil {
 		return nil, err
 	}
 
-	ps := make([]plugins.PluginSpec, 0)
-	parts := bytes.Split(data, []byte("---"))
-	for _, p := range parts {
-		if len(p) == 0 {
-			continue
-		}
+	return decodePluginSpecs(bytes.NewReader(data), f.name)
+}
+
+// pluginDocument is a plugin custom resource, or a List of plugin custom resources.
+// Any other fields of a Kubernetes object, e.g., apiVersion and metadata, are ignored.
+type pluginDocument struct {
+	Kind  string            `json:"kind"`
+	Spec  json.RawMessage   `json:"spec"`
+	Items []json.RawMessage `json:"items"`
+}
 
-		spec, err := unmarshalPluginSpec(p)
+// decodePluginSpecs decodes the plugin specs in a stream of YAML or JSON documents.
+// Errors identify the source and the index of the document which failed to decode.
+func decodePluginSpecs(r io.Reader, source string) ([]vplugins.PluginSpec, error) {
+	ps := make([]vplugins.PluginSpec, 0)
+	dec := k8syaml.NewYAMLOrJSONDecoder(r, 4096)
+	for i := 0; ; i++ {
+		var doc json.RawMessage
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrapf(err, "failed to parse %s, document %d", source, i)
+		}
+		specs, err := decodePluginDocument(doc)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to unmarshal plugin spec")
+			return nil, errors.Wrapf(err, "failed to unmarshal plugin spec in %s, document %d", source, i)
 		}
-		ps = append(ps, spec)
+		ps = append(ps, specs...)
 	}
-
 	return ps, nil
 }
 
-func unmarshalPluginSpec(data []byte) (plugins.PluginSpec, error) {
-	var bps basePluginSpec
-	if err := yaml.Unmarshal(data, &bps); err != nil {
-		return nil, err
+// decodePluginDocument decodes the plugin spec(s) in a single JSON document. Empty documents contain no specs.
+func decodePluginDocument(doc json.RawMessage) ([]vplugins.PluginSpec, error) {
+	if len(doc) == 0 || string(doc) == "null" {
+		return nil, nil
 	}
-
-	specBytes, err := yaml.Marshal(bps.Spec)
-	if err != nil {
+	var pd pluginDocument
+	if err := json.Unmarshal(doc, &pd); err != nil {
 		return nil, err
 	}
 
-	var spec plugins.PluginSpec
-	switch bps.Kind {
-	case cfg.ValidatorPluginAwsKind:
-		spec = &awsapi.AwsValidatorSpec{}
-	case cfg.ValidatorPluginAzureKind:
-		spec = &azureapi.AzureValidatorSpec{}
-	case cfg.ValidatorPluginMaasKind:
-		spec = &maasapi.MaasValidatorSpec{}
-	case cfg.ValidatorPluginNetworkKind:
-		spec = &netapi.NetworkValidatorSpec{}
-	case cfg.ValidatorPluginOciKind:
-		spec = &ociapi.OciValidatorSpec{}
-	case cfg.ValidatorPluginVsphereKind:
-		spec = &vsphereapi.VsphereValidatorSpec{}
-	default:
-		if bps.Kind == "" {
-			return nil, errors.New("plugin kind is not set")
+	if strings.HasSuffix(pd.Kind, "List") {
+		ps := make([]vplugins.PluginSpec, 0, len(pd.Items))
+		for i, item := range pd.Items {
+			specs, err := decodePluginDocument(item)
+			if err != nil {
+				return nil, errors.Wrapf(err, "items[%d]", i)
+			}
+			ps = append(ps, specs...)
 		}
-		return nil, fmt.Errorf("unknown plugin kind: %s", bps.Kind)
+		return ps, nil
 	}
 
-	if err := yaml.Unmarshal(specBytes, spec); err != nil {
+	specBytes := []byte("{}")
+	if len(pd.Spec) > 0 {
+		var err error
+		if specBytes, err = sigsyaml.JSONToYAML(pd.Spec); err != nil {
+			return nil, err
+		}
+	}
+	_, spec, err := newPluginSpec(pd.Kind, specBytes)
+	if err != nil {
 		return nil, err
 	}
-
-	return spec, nil
+	return []vplugins.PluginSpec{spec}, nil
 }
 
-func toPluginSpecs(vc *components.ValidatorConfig) []plugins.PluginSpec {
-	pluginSpecs := make([]plugins.PluginSpec, 0)
-	if vc.AWSPlugin != nil && vc.AWSPlugin.Enabled {
-		pluginSpecs = append(pluginSpecs, vc.AWSPlugin.Validator)
-	}
-	if vc.AzurePlugin != nil && vc.AzurePlugin.Enabled {
-		pluginSpecs = append(pluginSpecs, vc.AzurePlugin.Validator)
-	}
-	if vc.MaasPlugin != nil && vc.MaasPlugin.Enabled {
-		pluginSpecs = append(pluginSpecs, vc.MaasPlugin.Validator)
+// pluginSpec converts a basePluginSpec into the spec of the plugin for its kind
+func (bps basePluginSpec) pluginSpec() (plugins.Plugin, vplugins.PluginSpec, error) {
+	specBytes, err := yaml.Marshal(bps.Spec)
+	if err != nil {
+		return nil, nil, err
 	}
-	if vc.NetworkPlugin != nil && vc.NetworkPlugin.Enabled {
-		pluginSpecs = append(pluginSpecs, vc.NetworkPlugin.Validator)
+	return newPluginSpec(bps.Kind, specBytes)
+}
+
+// newPluginSpec unmarshals a YAML spec into the spec of the plugin for the provided kind
+func newPluginSpec(kind string, specBytes []byte) (plugins.Plugin, vplugins.PluginSpec, error) {
+	if kind == "" {
+		return nil, nil, errors.New("plugin kind is not set")
 	}
-	if vc.OCIPlugin != nil && vc.OCIPlugin.Enabled {
-		pluginSpecs = append(pluginSpecs, vc.OCIPlugin.Validator)
+	p, ok := plugins.GetByKind(kind)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown plugin kind: %s", kind)
 	}
-	if vc.VspherePlugin != nil && vc.VspherePlugin.Enabled {
-		pluginSpecs = append(pluginSpecs, vc.VspherePlugin.Validator)
+
+	spec := p.NewSpec()
+
+	if err := yaml.Unmarshal(specBytes, spec); err != nil {
+		return nil, nil, err
 	}
-	return pluginSpecs
+
+	return p, spec, nil
 }
 
 // UpgradeValidatorCommand upgrades validator and its plugins
@@ -440,7 +493,7 @@ func UpgradeValidatorCommand(c *cfg.Config, tc *cfg.TaskConfig) error {
 	if vc.Kubeconfig == "" {
 		return errors.New("invalid validator configuration: kubeconfig is required")
 	}
-	return deployValidatorAndPlugins(c, vc)
+	return deployValidatorAndPlugins(c, vc, tc)
 }
 
 // UndeployValidatorCommand undeploys validator and its plugins
@@ -456,7 +509,7 @@ func UndeployValidatorCommand(tc *cfg.TaskConfig) error {
 	}
 
 	log.Header("Uninstalling validator")
-	helmClient, err := getHelmClient(vc)
+	helmClient, err := getHelmClient(vc, tc.HelmBackend)
 	if err != nil {
 		return err
 	}
@@ -473,97 +526,197 @@ func UndeployValidatorCommand(tc *cfg.TaskConfig) error {
 }
 
 // DescribeValidationResultsCommand prints the validation results
-func DescribeValidationResultsCommand(tc *cfg.TaskConfig) error {
+func DescribeValidationResultsCommand(tc *cfg.TaskConfig, name string) error {
+	if err := initOutput(tc); err != nil {
+		return err
+	}
+	filter, err := newResultFilter(tc)
+	if err != nil {
+		return err
+	}
+
 	kClient, err := getValidationResultsCRDClient(tc)
 	if err != nil {
 		return errors.Wrap(err, "failed to get validation result client")
 	}
+	var client dynamic.ResourceInterface = kClient
+	if tc.Namespace != "" {
+		client = kClient.Namespace(tc.Namespace)
+	}
+	opts := metav1.ListOptions{}
+	if name != "" {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
 
-	vrs, err := kClient.List(context.Background(), metav1.ListOptions{})
+	vrList, err := client.List(context.Background(), opts)
 	if err != nil {
 		return errors.Wrap(err, "failed to list validation results")
 	}
+	if name != "" && len(vrList.Items) == 0 {
+		return errors.Errorf("validation result %q not found", name)
+	}
 
-	if err := printValidationResults(vrs.Items); err != nil {
+	vrs, err := toValidationResults(vrList.Items)
+	if err != nil {
 		return err
 	}
+	vrs = filterValidationResults(vrs, filter)
+	sortValidationResults(vrs, tc.SortBy)
 
-	return nil
+	if tc.SARIFReport != "" {
+		if err := writeSARIFReport(tc.SARIFReport, vrs, nil, sarifArtifactURI(tc)); err != nil {
+			return err
+		}
+		log.InfoCLI("Wrote SARIF report: %s", tc.SARIFReport)
+	}
+
+	if !isStructuredOutput(tc.OutputFormat) {
+		if len(vrs) == 0 {
+			log.InfoCLI("No matching validation results found")
+			return nil
+		}
+		if tc.Summary {
+			summary, err := buildValidationResultSummaryString(vrs)
+			if err != nil {
+				return err
+			}
+			log.InfoCLI("%s", summary)
+			return nil
+		}
+	}
+
+	vrObjs, err := toUnstructuredResults(vrs)
+	if err != nil {
+		return err
+	}
+	return outputValidationResults(tc.OutputFormat, vrObjs, nil)
 }
 
-// WatchValidationResults watches the validation results until all have either succeeded or failed
+// WatchValidationResults watches the validation results until all have either succeeded or failed.
+// Only results matching the task's label selector and plugin filter are considered. If fail-fast is
+// enabled, it returns as soon as any result fails. It returns false if any validation failed.
 func WatchValidationResults(tc *cfg.TaskConfig) (bool, error) {
 	log.InfoCLI("\nWatching validation results, waiting for all to succeed...")
+	pluginFilter, err := validationPluginFilter(tc.Plugins)
+	if err != nil {
+		return false, err
+	}
 	kClient, err := getValidationResultsCRDClient(tc)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get validation result client")
 	}
 
-	watchFunc := func(_ metav1.ListOptions) (watch.Interface, error) {
-		return kClient.Watch(context.Background(), metav1.ListOptions{})
+	if os.Getenv("IS_TEST") == "true" {
+		return true, nil
 	}
 
-	watcher, err := toolsWatch.NewRetryWatcher("1", &cache.ListWatch{WatchFunc: watchFunc})
-	if err != nil {
-		return false, errors.Wrap(err, "failed to create retry watcher for validation results")
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if tc.WaitTimeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), tc.WaitTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
 	}
+	defer cancel()
 
-	var hasValidationSucceeded bool
-	validationStates := make(map[string]vapi.ValidationState)
-
-	if os.Getenv("IS_TEST") == "true" {
-		return true, nil
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.LabelSelector = tc.Selector
+			return kClient.List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.LabelSelector = tc.Selector
+			return kClient.Watch(ctx, opts)
+		},
 	}
 
-	for event := range watcher.ResultChan() {
-		vrObj := event.Object.(*unstructured.Unstructured)
+	validationStates := make(map[string]vapi.ValidationState)
+	updated := false
 
-		vr := &vapi.ValidationResult{}
-		bytes, err := vrObj.MarshalJSON()
+	_, err = toolsWatch.UntilWithSync(ctx, lw, &unstructured.Unstructured{}, nil, func(event watch.Event) (bool, error) {
+		vrObj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok || event.Type == watch.Deleted {
+			return false, nil
+		}
+		vr, err := toValidationResult(*vrObj)
 		if err != nil {
 			return false, err
 		}
-		if err := json.Unmarshal(bytes, vr); err != nil {
-			return false, err
+		if !pluginFilter(vr.Spec.Plugin) {
+			return false, nil
 		}
 
 		prevValidationState := validationStates[vr.Name]
 		validationStates[vr.Name] = vr.Status.State
-		if event.Type != watch.Modified {
-			continue
-		}
 
-		hasValidationSucceeded = true
-		if prevValidationState != vr.Status.State {
-			log.InfoCLI("\nValidation result for %s updated:", vr.Name)
-			err = printValidationResults([]unstructured.Unstructured{*vrObj})
-			if err != nil {
-				return false, err
-			}
+		// results which exist prior to watching may be stale, so wait for at least one update
+		if event.Type != watch.Modified || prevValidationState == vr.Status.State {
+			return false, nil
+		}
+		updated = true
 
-			finished := true
-			vrWaiting := make([]string, 0)
-			for vName, state := range validationStates {
-				if state == vapi.ValidationFailed {
-					hasValidationSucceeded = false
-				}
-				if state != vapi.ValidationSucceeded && state != vapi.ValidationFailed {
-					vrWaiting = append(vrWaiting, vName)
-					finished = false
-					break
-				}
-			}
-			if finished {
-				break
-			}
+		log.InfoCLI("\nValidation result for %s updated:", vr.Name)
+		if err := printValidationResults([]unstructured.Unstructured{*vrObj}, nil); err != nil {
+			return false, err
+		}
 
+		if tc.FailFast && vr.Status.State == vapi.ValidationFailed {
+			log.InfoCLI("\nValidation failed for %s; not waiting for remaining validation results.", vr.Name)
+			return true, nil
+		}
+		if vrWaiting := waitingValidationResults(validationStates); len(vrWaiting) > 0 {
 			log.InfoCLI("\nWatching for updates to validation results for %s...", vrWaiting)
+			return false, nil
 		}
+		return true, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, errors.Errorf("timed out after %s waiting for validation results %v", tc.WaitTimeout, waitingValidationResults(validationStates))
+		}
+		return false, errors.Wrap(err, "failed to watch validation results")
+	}
+
+	hasValidationSucceeded := updated
+	for _, state := range validationStates {
+		if state == vapi.ValidationFailed {
+			hasValidationSucceeded = false
+		}
+	}
+	if len(waitingValidationResults(validationStates)) == 0 {
+		log.InfoCLI("\nAll validations have completed.")
 	}
-	log.InfoCLI("\nAll validations have completed.")
 	return hasValidationSucceeded, nil
 }
 
+// waitingValidationResults returns the names of all validation results which have yet to succeed or fail, sorted
+func waitingValidationResults(validationStates map[string]vapi.ValidationState) []string {
+	vrWaiting := make([]string, 0)
+	for vName, state := range validationStates {
+		if state != vapi.ValidationSucceeded && state != vapi.ValidationFailed {
+			vrWaiting = append(vrWaiting, vName)
+		}
+	}
+	slices.Sort(vrWaiting)
+	return vrWaiting
+}
+
+// validationPluginFilter returns a function reporting whether validation results for a plugin should be considered.
+// If no plugin codes are provided, all plugins are considered. Codes are matched case-insensitively.
+func validationPluginFilter(codes []string) (func(plugin string) bool, error) {
+	if len(codes) == 0 {
+		return func(string) bool { return true }, nil
+	}
+	for _, code := range codes {
+		if !slices.ContainsFunc(plugins.Codes(), func(c string) bool { return strings.EqualFold(c, code) }) {
+			return nil, errors.Errorf("invalid plugin %q: must be one of %v", code, plugins.Codes())
+		}
+	}
+	return func(plugin string) bool {
+		return slices.ContainsFunc(codes, func(c string) bool { return strings.EqualFold(c, plugin) })
+	}, nil
+}
+
 func getValidationResultsCRDClient(tc *cfg.TaskConfig) (dynamic.NamespaceableResourceInterface, error) {
 	if tc.ConfigFile != "" {
 		vc, err := components.NewValidatorFromConfig(tc)
@@ -585,9 +738,9 @@ func getValidationResultsCRDClient(tc *cfg.TaskConfig) (dynamic.NamespaceableRes
 	return kClient, nil
 }
 
-func printValidationResults(validationResults []unstructured.Unstructured) error {
+func printValidationResults(validationResults []unstructured.Unstructured, bl *baseline) error {
 	for _, vrObj := range validationResults {
-		vrStr, err := buildValidationResultString(vrObj)
+		vrStr, err := buildValidationResultString(vrObj, bl)
 		if err != nil {
 			return err
 		}
@@ -596,15 +749,11 @@ func printValidationResults(validationResults []unstructured.Unstructured) error
 	return nil
 }
 
-func buildValidationResultString(vrObj unstructured.Unstructured) (string, error) {
-	vr := &vapi.ValidationResult{}
-	bytes, err := vrObj.MarshalJSON()
+func buildValidationResultString(vrObj unstructured.Unstructured, bl *baseline) (string, error) {
+	vr, err := toValidationResult(vrObj)
 	if err != nil {
 		return "", err
 	}
-	if err := json.Unmarshal(bytes, vr); err != nil {
-		return "", err
-	}
 
 	sb := &strings.Builder{}
 	sb.WriteString("\n=================\nValidation Result\n=================\n")
@@ -630,9 +779,15 @@ func buildValidationResultString(vrObj unstructured.Unstructured) (string, error
 
 	sb.WriteString("\n------------\nRule Results\n------------\n")
 	for _, c := range vr.Status.ValidationConditions {
+		keys := []string{"Validation Rule", "Validation Type", "Status", "Last Validated", "Message"}
+		vals := []string{c.ValidationRule, c.ValidationType, string(c.Status), c.LastValidationTime.Format(time.RFC3339), strings.TrimSpace(c.Message)}
+		if s := bl.match(vr.Spec.Plugin, c); s != nil {
+			keys = append(keys, "Suppressed")
+			vals = append(vals, fmt.Sprintf("%s (expires %s)", strings.TrimSpace(s.Justification), s.Expires))
+		}
 		args := map[string]interface{}{
-			"Keys":   []string{"Validation Rule", "Validation Type", "Status", "Last Validated", "Message"},
-			"Values": []string{c.ValidationRule, c.ValidationType, string(c.Status), c.LastValidationTime.Format(time.RFC3339), strings.TrimSpace(c.Message)},
+			"Keys":   keys,
+			"Values": vals,
 		}
 
 		if err := embed.EFS.PrintTableTemplate(sb, args, cfg.Validator, "validation-result.tmpl"); err != nil {
@@ -656,10 +811,20 @@ func buildValidationResultString(vrObj unstructured.Unstructured) (string, error
 }
 
 // deployValidatorAndPlugins installs/upgrades validator + plugin(s)
-func deployValidatorAndPlugins(c *cfg.Config, vc *components.ValidatorConfig) error {
+func deployValidatorAndPlugins(c *cfg.Config, vc *components.ValidatorConfig, tc *cfg.TaskConfig) error {
+	if tc.DryRun || tc.Diff {
+		drift, err := diffValidator(vc, tc.HelmBackend, false)
+		if err != nil {
+			return err
+		}
+		if tc.DryRun {
+			return dryRunResult(drift)
+		}
+	}
+
 	log.Header("Installing/Upgrading validator and validator plugin(s)")
 
-	if err := applyValidator(c, vc); err != nil {
+	if err := applyValidator(c, vc, tc); err != nil {
 		return err
 	}
 
@@ -686,150 +851,46 @@ func configurePlugins(c *cfg.Config, vc *components.ValidatorConfig, tc *cfg.Tas
 	return nil
 }
 
-// nolint:gocyclo
-func executePlugins(c *cfg.Config, pluginSpecs []plugins.PluginSpec, sc *components.SinkConfig) error {
+func executePlugins(c *cfg.Config, tc *cfg.TaskConfig, pluginSpecs []vplugins.PluginSpec, sc *components.SinkConfig, bl *baseline) error {
 	log.Header("Executing validator plugin(s)")
 
 	// Initialize a new logr.Logger that writes to the same
 	// debug log file as the global logrus.Logger
 	l := zap.New(zap.WriteTo(log.Out()))
 
-	ok := true
-	results := make([]*vapi.ValidationResult, 0)
-
-	for _, ps := range pluginSpecs {
-
-		switch ps.PluginCode() {
-		case awsconst.PluginCode:
-			s := ps.(*awsapi.AwsValidatorSpec)
-
-			v := &awsapi.AwsValidator{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "aws-validator",
-					Namespace: "N/A",
-				},
-				Spec: *s,
-			}
-
-			vr := vres.Build(v)
-			vrr := awsval.Validate(*s, l)
-			if err := vres.Finalize(vr, vrr, l); err != nil {
-				return err
-			}
-			if vrOk := validationResponseOk(s.ResultCount(), vrr, l); !vrOk {
-				ok = false
-			}
-			results = append(results, vr)
-
-		case azureconst.PluginCode:
-			s := ps.(*azureapi.AzureValidatorSpec)
-
-			v := &azureapi.AzureValidator{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "azure-validator",
-					Namespace: "N/A",
-				},
-				Spec: *s,
-			}
-			vr := vres.Build(v)
-			vrr := azureval.Validate(context.Background(), *s, l)
-			if err := vres.Finalize(vr, vrr, l); err != nil {
-				return err
-			}
-			if vrOk := validationResponseOk(s.ResultCount(), vrr, l); !vrOk {
-				ok = false
-			}
-			results = append(results, vr)
-
-		case maasconst.PluginCode:
-			s := ps.(*maasapi.MaasValidatorSpec)
-
-			v := &maasapi.MaasValidator{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "maas-validator",
-					Namespace: "N/A",
-				},
-				Spec: *s,
-			}
-			vr := vres.Build(v)
-			vrr := maasval.Validate(*s, l)
-			if err := vres.Finalize(vr, vrr, l); err != nil {
-				return err
-			}
-			if vrOk := validationResponseOk(s.ResultCount(), vrr, l); !vrOk {
-				ok = false
-			}
-			results = append(results, vr)
-
-		case netconst.PluginCode:
-			s := ps.(*netapi.NetworkValidatorSpec)
-
-			v := &netapi.NetworkValidator{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "network-validator",
-					Namespace: "N/A",
-				},
-				Spec: *s,
-			}
-			vr := vres.Build(v)
-			vrr := netval.Validate(*s,
-				s.CACerts.RawCerts(),
-				s.HTTPFileAuthBytesDirect(),
-				l,
-			)
-			if err := vres.Finalize(vr, vrr, l); err != nil {
-				return err
-			}
-			if vrOk := validationResponseOk(s.ResultCount(), vrr, l); !vrOk {
-				ok = false
-			}
-			results = append(results, vr)
-
-		case ociconst.PluginCode:
-			s := ps.(*ociapi.OciValidatorSpec)
+	ctx := context.Background()
+	if tc.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, tc.Timeout)
+		defer cancel()
+	}
 
-			v := &ociapi.OciValidator{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "oci-validator",
-					Namespace: "N/A",
-				},
-				Spec: *s,
-			}
-			vr := vres.Build(v)
-			vrr := ocival.Validate(*s,
-				s.DeepCopy().BasicAuthsDirect(),
-				s.DeepCopy().AllPubKeysDirect(),
-				l,
-			)
-			if err := vres.Finalize(vr, vrr, l); err != nil {
-				return err
-			}
-			if vrOk := validationResponseOk(s.ResultCount(), vrr, l); !vrOk {
-				ok = false
-			}
-			results = append(results, vr)
+	parallelism := tc.Parallelism
+	if parallelism < 1 {
+		parallelism = cfg.DefaultPluginParallelism
+	}
 
-		case vsphereconst.PluginCode:
-			s := ps.(*vsphereapi.VsphereValidatorSpec)
+	results := make([]*vapi.ValidationResult, len(pluginSpecs))
+	oks := make([]bool, len(pluginSpecs))
 
-			v := &vsphereapi.VsphereValidator{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "vsphere-validator",
-					Namespace: "N/A",
-				},
-				Spec: *s,
-			}
-			vr := vres.Build(v)
-			vrr := vsphereval.Validate(context.Background(), *s, l)
-			if err := vres.Finalize(vr, vrr, l); err != nil {
+	eg := &errgroup.Group{}
+	eg.SetLimit(parallelism)
+	for i, ps := range pluginSpecs {
+		eg.Go(func() error {
+			vr, vrOk, err := runPlugin(ctx, ps, tc.PluginTimeout, bl, l)
+			if err != nil {
 				return err
 			}
-			if vrOk := validationResponseOk(s.ResultCount(), vrr, l); !vrOk {
-				ok = false
-			}
-			results = append(results, vr)
-		}
+			results[i] = vr
+			oks[i] = vrOk
+			return nil
+		})
 	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	ok := !slices.Contains(oks, false)
 
 	// Optionally emit results to a sink
 	if sc != nil && sc.Enabled {
@@ -852,15 +913,31 @@ func executePlugins(c *cfg.Config, pluginSpecs []plugins.PluginSpec, sc *compone
 		if err != nil {
 			return err
 		}
-		out := filepath.Join(c.RunLoc, fmt.Sprintf("%s-validation-result.yaml", vr.Name))
+		out := filepath.Join(c.RunLoc, vr.Name+cfg.ValidationResultFileSuffix)
 		if err := os.WriteFile(out, bs, 0600); err != nil {
 			return err
 		}
 	}
 
-	if err := printValidationResults(us); err != nil {
+	if tc.JUnitReport != "" {
+		if err := writeJUnitReport(tc.JUnitReport, results, bl); err != nil {
+			return err
+		}
+		log.InfoCLI("\nWrote JUnit report: %s", tc.JUnitReport)
+	}
+	if tc.SARIFReport != "" {
+		if err := writeSARIFReport(tc.SARIFReport, results, bl, sarifArtifactURI(tc)); err != nil {
+			return err
+		}
+		log.InfoCLI("\nWrote SARIF report: %s", tc.SARIFReport)
+	}
+
+	if err := outputValidationResults(tc.OutputFormat, us, bl); err != nil {
 		return err
 	}
+	if n := len(bl.suppressedFailures(results)); n > 0 {
+		log.InfoCLI("\n%d validation rule failure(s) suppressed by baseline", n)
+	}
 
 	if !ok {
 		return ErrValidationFailed{}
@@ -869,13 +946,76 @@ func executePlugins(c *cfg.Config, pluginSpecs []plugins.PluginSpec, sc *compone
 	return nil
 }
 
-func validationResponseOk(expected int, vr types.ValidationResponse, log logr.Logger) bool {
+// runPlugin executes the rules for a single plugin spec. If the plugin does not complete before
+// its deadline, a failed ValidationResult is recorded rather than waiting for it indefinitely.
+func runPlugin(ctx context.Context, ps vplugins.PluginSpec, timeout time.Duration, bl *baseline, l logr.Logger) (*vapi.ValidationResult, bool, error) {
+	p, ok := plugins.Get(ps.PluginCode())
+	if !ok {
+		return nil, false, fmt.Errorf("unknown plugin code: %s", ps.PluginCode())
+	}
+	v, validate := p.NewValidator(ps)
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// buffered so that the goroutine can exit if the plugin completes after its deadline
+	ch := make(chan types.ValidationResponse, 1)
+	go func() {
+		ch <- validate(ctx, l)
+	}()
+
+	var vrr types.ValidationResponse
+	select {
+	case vrr = <-ch:
+	case <-ctx.Done():
+		log.InfoCLI("Plugin %s did not complete: %v", ps.PluginCode(), ctx.Err())
+		vrr = timedOutResponse(ps.PluginCode(), ctx.Err())
+	}
+
+	vr := vres.Build(v)
+	if err := vres.Finalize(vr, vrr, l); err != nil {
+		return nil, false, err
+	}
+	return vr, validationResponseOk(ps.ResultCount(), vrr, bl.matcher(ps.PluginCode()), l), nil
+}
+
+// timedOutResponse builds a failed ValidationResponse for a plugin that did not complete
+func timedOutResponse(pluginCode string, err error) types.ValidationResponse {
+	c := vapi.DefaultValidationCondition()
+	c.ValidationRule = fmt.Sprintf("validation-%s-timeout", strings.ToLower(pluginCode))
+	c.ValidationType = fmt.Sprintf("%s-timeout", strings.ToLower(pluginCode))
+
+	vrr := types.ValidationResponse{}
+	vrr.AddResult(
+		&types.ValidationRuleResult{Condition: &c, State: util.Ptr(vapi.ValidationFailed)},
+		fmt.Errorf("plugin %s did not complete: %w", pluginCode, err),
+	)
+	return vrr
+}
+
+// validationResponseOk reports whether all of a plugin's validation rules were evaluated and passed.
+// Failed rules for which suppressed returns true are ignored.
+func validationResponseOk(expected int, vr types.ValidationResponse, suppressed func(vapi.ValidationCondition) bool, log logr.Logger) bool {
 	var hasRuleError, hasResultCountError, hasValidationError bool
 
-	for _, err := range vr.ValidationRuleErrors {
+	isSuppressed := func(vrr *types.ValidationRuleResult) bool {
+		if suppressed == nil || vrr == nil || vrr.Condition == nil || !suppressed(*vrr.Condition) {
+			return false
+		}
+		log.V(0).Info("validation rule failure suppressed by baseline", "rule", vrr.Condition.ValidationRule)
+		return true
+	}
+
+	for i, err := range vr.ValidationRuleErrors {
 		if err == nil {
 			continue
 		}
+		if i < len(vr.ValidationRuleResults) && isSuppressed(vr.ValidationRuleResults[i]) {
+			continue
+		}
 		log.V(0).Info("validation rule failed: unexpected error",
 			"error", err,
 		)
@@ -892,6 +1032,9 @@ func validationResponseOk(expected int, vr types.ValidationResponse, log logr.Lo
 
 	for _, vrr := range vr.ValidationRuleResults {
 		if vrr.State != nil && *vrr.State == vapi.ValidationFailed {
+			if isSuppressed(vrr) {
+				continue
+			}
 			log.V(0).Info("validation rule failed",
 				"rule", vrr.Condition.ValidationRule,
 				"type", vrr.Condition.ValidationType,
@@ -928,137 +1071,28 @@ func emitToSink(sc *components.SinkConfig, results []*vapi.ValidationResult, log
 	return nil
 }
 
-func createReleaseSecretCmd(secret *components.Secret) []string {
-	args := []string{
-		"create", "secret", "generic", secret.Name, "-n", "validator",
-		// include empty username/password, even if unset, to avoid error in validator
-		fmt.Sprintf("--from-literal=username=%s", secret.BasicAuth.Username),
-		fmt.Sprintf("--from-literal=password=%s", secret.BasicAuth.Password),
-	}
-	if secret.CaCertFile != "" {
-		args = append(args, fmt.Sprintf("--from-file=caCert=%s", secret.CaCertFile))
-	}
-	return args
-}
-
-// nolint:gocyclo
-func applyValidator(c *cfg.Config, vc *components.ValidatorConfig) error {
-	pluginCount := 0
-	kubecommandsPre := [][]string{}
-
-	kClient, err := kube.GetKubeClientset(vc.Kubeconfig)
-	if err != nil {
-		return err
-	}
-
+// renderValidatorValues renders the final Helm values for the validator chart, which embed
+// a ValidatorConfigSpec containing the Helm release and values for each enabled plugin
+func renderValidatorValues(vc *components.ValidatorConfig) (string, *vapi.ValidatorConfigSpec, error) {
 	// build validator plugin spec
-	validatorSpec := vapi.ValidatorConfigSpec{
+	validatorSpec := &vapi.ValidatorConfigSpec{
 		HelmConfig: *vc.HelmConfig,
 		Plugins:    make([]vapi.HelmRelease, 0),
 	}
 
-	if vc.ReleaseSecret != nil && vc.ReleaseSecret.ShouldCreate() {
-		kubecommandsPre = append(kubecommandsPre, createReleaseSecretCmd(vc.ReleaseSecret))
-	}
-
-	if vc.AWSPlugin.Enabled {
-		args := map[string]interface{}{
-			"Config":        vc.AWSPlugin,
-			"ImageRegistry": vc.ImageRegistry,
-		}
-		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-plugin-aws-values.tmpl")
-		if err != nil {
-			return errors.Wrap(err, "failed to render validator plugin aws values.yaml")
-		}
-		validatorSpec.Plugins = append(validatorSpec.Plugins, vapi.HelmRelease{
-			Chart:  vc.AWSPlugin.Release.Chart,
-			Values: string(values),
-		})
-		pluginCount++
-	}
-
-	if vc.AzurePlugin.Enabled {
-		args := map[string]interface{}{
-			"Config":        vc.AzurePlugin,
-			"ImageRegistry": vc.ImageRegistry,
-		}
-		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-plugin-azure-values.tmpl")
-		if err != nil {
-			return errors.Wrap(err, "failed to render validator plugin azure values.yaml")
-		}
-		validatorSpec.Plugins = append(validatorSpec.Plugins, vapi.HelmRelease{
-			Chart:  vc.AzurePlugin.Release.Chart,
-			Values: string(values),
-		})
-		pluginCount++
-	}
-
-	if vc.MaasPlugin.Enabled {
-		args := map[string]interface{}{
-			"Config":        vc.MaasPlugin,
-			"ImageRegistry": vc.ImageRegistry,
-		}
-		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-plugin-maas-values.tmpl")
-		if err != nil {
-			return errors.Wrap(err, "failed to render validator plugin maas values.yaml")
-		}
-		validatorSpec.Plugins = append(validatorSpec.Plugins, vapi.HelmRelease{
-			Chart:  vc.MaasPlugin.Release.Chart,
-			Values: string(values),
-		})
-		pluginCount++
-	}
-
-	if vc.NetworkPlugin.Enabled {
-		args := map[string]interface{}{
-			"Config":        vc.NetworkPlugin,
-			"ImageRegistry": vc.ImageRegistry,
-		}
-		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-plugin-network-values.tmpl")
-		if err != nil {
-			return errors.Wrap(err, "failed to render validator plugin network values.yaml")
-		}
-		validatorSpec.Plugins = append(validatorSpec.Plugins, vapi.HelmRelease{
-			Chart:  vc.NetworkPlugin.Release.Chart,
-			Values: string(values),
-		})
-		pluginCount++
-	}
-
-	if vc.OCIPlugin.Enabled {
+	for _, p := range plugins.Enabled(vc) {
 		args := map[string]interface{}{
-			"Config":        vc.OCIPlugin,
+			"Config":        p.Config(vc),
 			"ImageRegistry": vc.ImageRegistry,
 		}
-		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-plugin-oci-values.tmpl")
+		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, p.ValuesTemplate())
 		if err != nil {
-			return errors.Wrap(err, "failed to render validator plugin oci values.yaml")
+			return "", nil, errors.Wrapf(err, "failed to render validator plugin %s values.yaml", strings.ToLower(p.Code()))
 		}
 		validatorSpec.Plugins = append(validatorSpec.Plugins, vapi.HelmRelease{
-			Chart:  vc.OCIPlugin.Release.Chart,
+			Chart:  p.Release(vc).Chart,
 			Values: string(values),
 		})
-		pluginCount++
-	}
-
-	if vc.VspherePlugin.Enabled {
-		args := map[string]interface{}{
-			"Config":        vc.VspherePlugin,
-			"ImageRegistry": vc.ImageRegistry,
-		}
-		values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-plugin-vsphere-values.tmpl")
-		if err != nil {
-			return errors.Wrap(err, "failed to render validator plugin vsphere values.yaml")
-		}
-		validatorSpec.Plugins = append(validatorSpec.Plugins, vapi.HelmRelease{
-			Chart:  vc.VspherePlugin.Release.Chart,
-			Values: string(values),
-		})
-		pluginCount++
-	}
-
-	if !vc.AnyPluginEnabled() {
-		log.FatalCLI("invalid validator config", "error", "at least one plugin must be enabled")
 	}
 
 	// concatenate base validator values w/ plugin values
@@ -1067,12 +1101,9 @@ func applyValidator(c *cfg.Config, vc *components.ValidatorConfig) error {
 		"Tag":           vc.Release.Chart.Version,
 		"ProxyConfig":   vc.ProxyConfig,
 		"SinkConfig":    vc.SinkConfig,
-		"AWSPlugin":     vc.AWSPlugin,
-		"AzurePlugin":   vc.AzurePlugin,
-		"MAASPlugin":    vc.MaasPlugin,
-		"NetworkPlugin": vc.NetworkPlugin,
-		"OCIPlugin":     vc.OCIPlugin,
-		"VspherePlugin": vc.VspherePlugin,
+	}
+	for _, p := range plugins.All() {
+		args[p.TemplateKey()] = p.Config(vc)
 	}
 	if vc.ProxyConfig.Enabled {
 		args["ProxyCaCertData"] = strings.Split(vc.ProxyConfig.Env.ProxyCACert.Data, "\n")
@@ -1080,37 +1111,56 @@ func applyValidator(c *cfg.Config, vc *components.ValidatorConfig) error {
 
 	values, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, "validator-base-values.tmpl")
 	if err != nil {
-		return errors.Wrap(err, "failed to render validator base values.yaml")
+		return "", nil, errors.Wrap(err, "failed to render validator base values.yaml")
 	}
 	pluginValues, err := yaml.Marshal(validatorSpec)
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal validator plugin YAML")
+		return "", nil, errors.Wrap(err, "failed to marshal validator plugin YAML")
 	}
 	pluginValues = bytes.ReplaceAll(pluginValues, []byte("sink: null"), nil)
 	values = append(values, pluginValues...)
-	finalValues := string(values)
+
+	return string(values), validatorSpec, nil
+}
+
+// nolint:gocyclo
+func applyValidator(c *cfg.Config, vc *components.ValidatorConfig, tc *cfg.TaskConfig) error {
+	kClient, err := kube.GetKubeClientset(vc.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if len(plugins.Enabled(vc)) == 0 {
+		log.FatalCLI("invalid validator config", "error", "at least one plugin must be enabled")
+	}
+
+	finalValues, validatorSpec, err := renderValidatorValues(vc)
+	if err != nil {
+		return err
+	}
+	pluginNames := make([]string, 0, len(validatorSpec.Plugins))
+	for _, p := range validatorSpec.Plugins {
+		pluginNames = append(pluginNames, p.Chart.Name)
+	}
 	log.Debug("applying validator helm chart with values:")
 	log.Debug("%s", finalValues)
 
-	// install validator helm chart
-
-	if len(kubecommandsPre) > 0 {
-		_, err := kClient.CoreV1().Namespaces().Get(context.Background(), cfg.Validator, metav1.GetOptions{})
-		if err != nil && apierrs.IsNotFound(err) {
-			kubecommandsPre = append([][]string{{"create", "namespace", cfg.Validator}}, kubecommandsPre...)
+	// create the release secret, if required, then install validator helm chart
+	if vc.ReleaseSecret != nil && vc.ReleaseSecret.ShouldCreate() {
+		secret, err := newReleaseSecret(vc.ReleaseSecret)
+		if err != nil {
+			return err
 		}
-		for _, c := range kubecommandsPre {
-			if _, stderr, err := kube.KubectlCommand(c, vc.Kubeconfig); err != nil {
-				// ignore already exists errors when creating release secrets
-				if !strings.HasSuffix(strings.TrimSpace(stderr), "already exists") {
-					return errors.Wrap(err, stderr)
-				}
-				log.Debug("%s", stderr)
-			}
+		log.InfoCLI("\n==== Creating validator Helm release secret %s ====", secret.Name)
+		if err := kube.CreateNamespace(kClient, newNamespace(cfg.Validator)); err != nil {
+			return err
+		}
+		if err := kube.CreateSecret(kClient, secret); err != nil {
+			return err
 		}
 	}
 
-	helmClient, err := getHelmClient(vc)
+	helmClient, err := getHelmClient(vc, tc.HelmBackend)
 	if err != nil {
 		return err
 	}
@@ -1129,8 +1179,9 @@ func applyValidator(c *cfg.Config, vc *components.ValidatorConfig) error {
 		opts.Password = vc.ReleaseSecret.BasicAuth.Password
 	}
 
+	// the Helm SDK pulls charts from OCI registries itself
 	var cleanupLocalChart bool
-	if strings.HasPrefix(opts.Registry, ocic.Scheme) {
+	if tc.HelmBackend == cfg.HelmBackendBinary && strings.HasPrefix(opts.Registry, ocic.Scheme) {
 		log.InfoCLI("\n==== Pulling validator Helm chart from OCI registry %s ====", opts.Registry)
 
 		opts.Path = fmt.Sprintf("%s/%s", c.RunLoc, opts.Chart)
@@ -1170,12 +1221,28 @@ func applyValidator(c *cfg.Config, vc *components.ValidatorConfig) error {
 		log.InfoCLI("Cleaned up local chart directory: %s", opts.Path)
 	}
 
-	// wait for validator to be ready
-	if _, stderr, err := kube.KubectlCommand(cfg.ValidatorWaitCmd, vc.Kubeconfig); err != nil {
-		return errors.Wrap(err, stderr)
+	// wait for validator to be ready, then for its plugins to be installed
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if tc.InstallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), tc.InstallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	log.InfoCLI("\nWaiting for deployment %s/%s to become available", cfg.Validator, cfg.ValidatorDeployment)
+	if err := kube.WaitForDeployment(ctx, kClient, cfg.Validator, cfg.ValidatorDeployment); err != nil {
+		if ctx.Err() != nil {
+			return installTimedOut(c, kClient, tc.InstallTimeout, err)
+		}
+		return err
 	}
-	pluginsOk, err := watchValidatorConfig(pluginCount)
+	pluginsOk, err := watchValidatorConfig(ctx, pluginNames)
 	if err != nil {
+		if ctx.Err() != nil {
+			return installTimedOut(c, kClient, tc.InstallTimeout, err)
+		}
 		return err
 	}
 	if !pluginsOk {
@@ -1185,8 +1252,23 @@ func applyValidator(c *cfg.Config, vc *components.ValidatorConfig) error {
 	return nil
 }
 
-// watchValidatorConfig watches the validator config until all plugins have been installed
-func watchValidatorConfig(numPlugins int) (bool, error) {
+// Plugin phases displayed while waiting for validator plugin(s) to be installed
+const (
+	pluginPhasePending   = "Pending"
+	pluginPhaseInstalled = "Installed"
+	pluginPhaseFailed    = "Failed"
+)
+
+// pluginStatus is the install status of a single validator plugin
+type pluginStatus struct {
+	Plugin  string
+	Phase   string
+	Message string
+}
+
+// watchValidatorConfig watches the validator config until all plugins have been installed or failed,
+// or the context is done. The install status of each plugin is printed whenever it changes.
+func watchValidatorConfig(ctx context.Context, pluginNames []string) (bool, error) {
 	log.InfoCLI("\nWatching validator config, waiting for plugins to be installed or failed")
 
 	gv := kube.GetGroupVersion(vapi.GroupVersion.Group, vapi.GroupVersion.Version)
@@ -1194,130 +1276,171 @@ func watchValidatorConfig(numPlugins int) (bool, error) {
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get validator config client")
 	}
-
-	watchFunc := func(_ metav1.ListOptions) (watch.Interface, error) {
-		return kClient.Watch(context.Background(), metav1.ListOptions{})
-	}
-	watcher, err := toolsWatch.NewRetryWatcher("1", &cache.ListWatch{WatchFunc: watchFunc})
-	if err != nil {
-		return false, errors.Wrap(err, "failed to create retry watcher for validator config")
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return kClient.List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return kClient.Watch(ctx, opts)
+		},
 	}
 
 	pluginsOk := true
+	lastStatus := ""
 
-	for event := range watcher.ResultChan() {
-		vrObj := event.Object.(*unstructured.Unstructured)
-		bytes, err := vrObj.MarshalJSON()
-		if err != nil {
+	_, err = toolsWatch.UntilWithSync(ctx, lw, &unstructured.Unstructured{}, nil, func(event watch.Event) (bool, error) {
+		vcObj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok || event.Type == watch.Deleted {
+			return false, nil
+		}
+		vc := &vapi.ValidatorConfig{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(vcObj.Object, vc); err != nil {
 			return false, err
 		}
 
-		vc := &vapi.ValidatorConfig{}
-		if err := json.Unmarshal(bytes, vc); err != nil {
+		statuses := pluginStatuses(pluginNames, vc.Status.Conditions)
+		status, err := buildPluginStatusString(statuses)
+		if err != nil {
 			return false, err
 		}
+		if status != lastStatus {
+			log.InfoCLI("%s", status)
+			lastStatus = status
+		}
 
-		if len(vc.Status.Conditions) == numPlugins {
-			for _, c := range vc.Status.Conditions {
-				if c.Status == v1.ConditionFalse {
-					pluginsOk = false
-					log.ErrorCLI("Plugin failed to install", c.PluginName, c.Message)
-				}
+		if len(vc.Status.Conditions) < len(pluginNames) {
+			return false, nil
+		}
+		for _, s := range statuses {
+			if s.Phase == pluginPhaseFailed {
+				pluginsOk = false
 			}
-			break
 		}
-
-		log.InfoCLI("\nFound %d/%d plugin conditions in validator config status. Waiting...", len(vc.Status.Conditions), numPlugins)
+		return true, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, errors.Wrap(err, "failed to watch validator config")
 	}
 
 	log.InfoCLI("\nPlugin conditions found. All ok: %t.", pluginsOk)
 	return pluginsOk, nil
 }
 
-// getHelmClient gets a helm client w/ a monkey-patched path to the embedded kind binary
-func getHelmClient(vc *components.ValidatorConfig) (helm.Client, error) {
-	apiCfg, err := kube.GetAPIConfig(vc.Kubeconfig)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get API config from kubeconfig")
+// pluginStatuses returns the install status of each plugin, based on the validator config's status conditions
+func pluginStatuses(pluginNames []string, conditions []vapi.ValidatorPluginCondition) []pluginStatus {
+	statuses := make([]pluginStatus, 0, len(pluginNames))
+	for _, name := range pluginNames {
+		s := pluginStatus{Plugin: name, Phase: pluginPhasePending, Message: "waiting for plugin to be installed"}
+		for _, c := range conditions {
+			if c.PluginName != name {
+				continue
+			}
+			switch c.Status {
+			case v1.ConditionTrue:
+				s.Phase = pluginPhaseInstalled
+			case v1.ConditionFalse:
+				s.Phase = pluginPhaseFailed
+			}
+			s.Message = strings.TrimSpace(c.Message)
+		}
+		statuses = append(statuses, s)
 	}
-	helm.CommandPath = exec.Helm
-	helmClient := helm.NewHelmClient(apiCfg)
-	return helmClient, nil
+	return statuses
 }
 
-func applyPlugins(c *cfg.Config, vc *components.ValidatorConfig) error {
-	if vc.AWSPlugin.Enabled {
-		log.InfoCLI("\n==== Applying AWS plugin validator(s) ====")
-		if err := createValidator(
-			vc.Kubeconfig, c.RunLoc, cfg.ValidatorPluginAws, cfg.ValidatorPluginAwsTemplate, *vc.AWSPlugin.Validator,
-		); err != nil {
-			return err
-		}
+func buildPluginStatusString(statuses []pluginStatus) (string, error) {
+	sb := &strings.Builder{}
+	sb.WriteString("\n")
+	if err := embed.EFS.PrintTableTemplate(sb, statuses, cfg.Validator, "validator-plugin-status.tmpl"); err != nil {
+		return "", err
 	}
+	return sb.String(), nil
+}
 
-	if vc.AzurePlugin.Enabled {
-		log.InfoCLI("\n==== Applying Azure plugin validator(s) ====")
-		if err := createValidator(
-			vc.Kubeconfig, c.RunLoc, cfg.ValidatorPluginAzure, cfg.ValidatorPluginAzureTemplate, *vc.AzurePlugin.Validator,
-		); err != nil {
-			return err
-		}
+// installTimedOut writes diagnostics for the validator namespace to the run directory
+// and returns an error explaining that validator and/or its plugin(s) failed to install in time
+func installTimedOut(c *cfg.Config, kClient kubernetes.Interface, timeout time.Duration, cause error) error {
+	dir := filepath.Join(c.RunLoc, "diagnostics")
+	log.InfoCLI("\nTimed out after %s. Collecting diagnostics from namespace %s", timeout, cfg.Validator)
+	files, err := kube.CollectDiagnostics(kClient, cfg.Validator, dir)
+	if err != nil {
+		log.ErrorCLI("failed to collect diagnostics", "error", err)
 	}
-
-	if vc.MaasPlugin.Enabled {
-		log.InfoCLI("\n==== Applying MAAS plugin validator(s) ====")
-		if err := createValidator(
-			vc.Kubeconfig, c.RunLoc, cfg.ValidatorPluginMaas, cfg.ValidatorPluginMaasTemplate, *vc.MaasPlugin.Validator,
-		); err != nil {
-			return err
-		}
+	for _, f := range files {
+		log.InfoCLI("  %s", f)
 	}
+	return errors.Wrapf(cause, "timed out after %s waiting for validator and validator plugin(s) to install; see diagnostics in %s", timeout, dir)
+}
 
-	if vc.NetworkPlugin.Enabled {
-		log.InfoCLI("\n==== Applying Network plugin validator(s) ====")
-		if err := createValidator(
-			vc.Kubeconfig, c.RunLoc, cfg.ValidatorPluginNetwork, cfg.ValidatorPluginNetworkTemplate, *vc.NetworkPlugin.Validator,
-		); err != nil {
-			return err
+// getHelmClient gets a helm client for the specified backend. The Helm SDK is used by default.
+// The binary backend uses a helm client w/ a monkey-patched path to the helm binary.
+func getHelmClient(vc *components.ValidatorConfig, backend string) (helmsdk.Client, error) {
+	switch backend {
+	case cfg.HelmBackendSDK, "":
+		return helmsdk.NewSDKClient(vc.Kubeconfig), nil
+	case cfg.HelmBackendBinary:
+		apiCfg, err := kube.GetAPIConfig(vc.Kubeconfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get API config from kubeconfig")
 		}
+		helm.CommandPath = exec.Helm
+		return helm.NewHelmClient(apiCfg), nil
 	}
+	return nil, fmt.Errorf("invalid Helm backend %s. One of: %v", backend, cfg.HelmBackends)
+}
 
-	if vc.OCIPlugin.Enabled {
-		log.InfoCLI("\n==== Applying OCI plugin validator(s) ====")
+func applyPlugins(c *cfg.Config, vc *components.ValidatorConfig) error {
+	for _, p := range plugins.Enabled(vc) {
+		log.InfoCLI("\n==== Applying %s plugin validator(s) ====", p.Code())
 		if err := createValidator(
-			vc.Kubeconfig, c.RunLoc, cfg.ValidatorPluginOci, cfg.ValidatorPluginOciTemplate, *vc.OCIPlugin.Validator,
+			vc.Kubeconfig, c.RunLoc, p.Chart(), p.RulesTemplate(), p.Spec(vc),
 		); err != nil {
 			return err
 		}
 	}
 
-	if vc.VspherePlugin.Enabled {
-		log.InfoCLI("\n==== Applying vSphere plugin validator(s) ====")
-		if err := createValidator(
-			vc.Kubeconfig, c.RunLoc, cfg.ValidatorPluginVsphere, cfg.ValidatorPluginVsphereTemplate, *vc.VspherePlugin.Validator,
-		); err != nil {
-			return err
-		}
+	return nil
+}
+
+func createValidator(kubeconfig, runLoc, name, template string, validator interface{}) error {
+	path := filepath.Join(runLoc, "manifests", fmt.Sprintf("%s.yaml", name))
+	if err := renderValidator(path, name, template, validator); err != nil {
+		return err
 	}
+	return applyValidatorManifest(kubeconfig, name, path)
+}
 
+// renderValidator renders a plugin's custom resource to a file
+func renderValidator(path, name, template string, validator interface{}) error {
+	manifest, err := renderValidatorManifest(name, template, validator)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, manifest, 0600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s validator manifest", name))
+	}
 	return nil
 }
 
-func createValidator(kubeconfig, runLoc, name, template string, validator interface{}) error {
+// renderValidatorManifest renders a plugin's custom resource
+func renderValidatorManifest(name, template string, validator interface{}) ([]byte, error) {
 	spec, err := yaml.Marshal(validator)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to marshal %s validator", name))
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to marshal %s validator", name))
 	}
 	args := map[string]interface{}{
 		"Name":      name,
 		"Namespace": cfg.Validator,
 		"Spec":      indent(spec, 2),
 	}
-	path := filepath.Join(runLoc, "manifests", fmt.Sprintf("%s.yaml", name))
-	if err := embed.EFS.RenderTemplate(args, cfg.Validator, template, path); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to render %s validator manifest", name))
+	manifest, err := embed.EFS.RenderTemplateBytes(args, cfg.Validator, template)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to render %s validator manifest", name))
 	}
-	return applyValidatorManifest(kubeconfig, name, path)
+	return manifest, nil
 }
 
 func indent(bs []byte, indent int) string {
@@ -1333,10 +1456,15 @@ func indent(bs []byte, indent int) string {
 }
 
 func applyValidatorManifest(kubeconfig, name, path string) error {
-	cmd := []string{"apply", "-f", path}
-	if _, stderr, err := kube.KubectlCommand(cmd, kubeconfig); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to apply %s validator: %s", name, stderr))
+	manifest, err := os.ReadFile(path) // #nosec
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read %s validator manifest", name))
+	}
+	obj, err := kube.ApplyManifest(kubeconfig, manifest)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to apply %s validator", name))
 	}
+	log.InfoCLI("%s %s/%s applied", obj.GetKind(), obj.GetNamespace(), obj.GetName())
 	return nil
 }
 
@@ -1,16 +1,27 @@
 package validator
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+	ociapi "github.com/validator-labs/validator-plugin-oci/api/v1alpha1"
 	vapi "github.com/validator-labs/validator/api/v1alpha1"
 	"github.com/validator-labs/validator/pkg/plugins"
+	vres "github.com/validator-labs/validator/pkg/validationresult"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
 )
 
 func TestBuildValidationResultString(t *testing.T) {
@@ -109,7 +120,7 @@ Failures
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			vrStr, _ := buildValidationResultString(tc.vrObj)
+			vrStr, _ := buildValidationResultString(tc.vrObj, nil)
 
 			if vrStr != tc.expectedVrStr {
 				t.Errorf("\nexpected vrStr:\n%s\nactual vrStr:\n%s", tc.expectedVrStr, vrStr)
@@ -118,12 +129,19 @@ Failures
 	}
 }
 
-func TestUnmarshalPluginSpec(t *testing.T) {
+func TestDecodePluginSpecs(t *testing.T) {
+	resolveGoogle := &netapi.NetworkValidatorSpec{
+		DNSRules: []netapi.DNSRule{
+			{RuleName: "Resolve Google", Host: "google.com"},
+		},
+	}
+	caCert := "-----BEGIN CERTIFICATE-----\nMIIB\n---\n-----END CERTIFICATE-----\n"
+
 	tests := []struct {
-		name         string
-		input        []byte
-		expectedSpec plugins.PluginSpec
-		expectedErr  error
+		name          string
+		input         []byte
+		expectedSpecs []plugins.PluginSpec
+		expectedErr   error
 	}{
 		{
 			name: "Valid NetworkValidator spec",
@@ -137,18 +155,79 @@ spec:
   - name: Resolve Google
     host: google.com
 `),
-			expectedSpec: &netapi.NetworkValidatorSpec{
-				DNSRules: []netapi.DNSRule{
-					{RuleName: "Resolve Google", Host: "google.com"},
-				},
+			expectedSpecs: []plugins.PluginSpec{resolveGoogle},
+		},
+		{
+			name: "Multiple documents with leading document marker and comments",
+			input: []byte(
+				`# network rules
+---
+kind: NetworkValidator
+spec:
+  dnsRules:
+  - name: Resolve Google
+    host: google.com
+--- # OCI rules
+kind: OciValidator
+spec:
+  ociRegistryRules:
+  - name: registry
+    host: registry.example.com
+    validationType: none
+    caCert: |
+      -----BEGIN CERTIFICATE-----
+      MIIB
+      ---
+      -----END CERTIFICATE-----
+---
+# empty document
+`),
+			expectedSpecs: []plugins.PluginSpec{
+				resolveGoogle,
+				&ociapi.OciValidatorSpec{OciRegistryRules: []ociapi.OciRegistryRule{
+					{RuleName: "registry", Host: "registry.example.com", ValidationType: ociapi.ValidationTypeNone, CaCert: caCert},
+				}},
 			},
-			expectedErr: nil,
 		},
 		{
-			name:         "Unknown plugin kind",
-			input:        []byte(`kind: SomeRandomKind`),
-			expectedSpec: nil,
-			expectedErr:  errors.New("unknown plugin kind"),
+			name: "List",
+			input: []byte(
+				`apiVersion: v1
+kind: List
+items:
+- kind: NetworkValidator
+  spec:
+    dnsRules:
+    - name: Resolve Google
+      host: google.com
+- kind: NetworkValidator
+  spec:
+    tcpConnRules:
+    - name: Connect to Google
+      host: google.com
+      ports:
+      - 443
+`),
+			expectedSpecs: []plugins.PluginSpec{
+				resolveGoogle,
+				&netapi.NetworkValidatorSpec{TCPConnRules: []netapi.TCPConnRule{{RuleName: "Connect to Google", Host: "google.com", Ports: []int{443}}}},
+			},
+		},
+		{
+			name: "JSON",
+			input: []byte(`{
+	"apiVersion": "validation.spectrocloud.labs/v1alpha1",
+	"kind": "NetworkValidator",
+	"spec": {"dnsRules": [{"name": "Resolve Google", "host": "google.com"}]}
+}
+{"kind": "NetworkValidatorList", "items": [{"kind": "NetworkValidator", "spec": {"dnsRules": [{"name": "Resolve Google", "host": "google.com"}]}}]}
+`),
+			expectedSpecs: []plugins.PluginSpec{resolveGoogle, resolveGoogle},
+		},
+		{
+			name:        "Unknown plugin kind",
+			input:       []byte(`kind: SomeRandomKind`),
+			expectedErr: errors.New("failed to unmarshal plugin spec in test.yaml, document 0: unknown plugin kind"),
 		},
 		{
 			name: "Kind not set",
@@ -158,20 +237,28 @@ spec:
   - name: Resolve Google
     host: google.com
 `),
-			expectedSpec: nil,
-			expectedErr:  errors.New("plugin kind is not set"),
+			expectedErr: errors.New("plugin kind is not set"),
 		},
 		{
-			name:         "Invalid YAML format",
-			input:        []byte("hello"),
-			expectedSpec: nil,
-			expectedErr:  errors.New("cannot unmarshal"),
+			name:        "Invalid YAML format",
+			input:       []byte("hello"),
+			expectedErr: errors.New("cannot unmarshal"),
+		},
+		{
+			name:        "Invalid list item",
+			input:       []byte("kind: NetworkValidator\n---\nkind: List\nitems:\n- kind: NetworkValidator\n- kind: Foo\n"),
+			expectedErr: errors.New("failed to unmarshal plugin spec in test.yaml, document 1: items[1]: unknown plugin kind: Foo"),
+		},
+		{
+			name:        "Malformed document",
+			input:       []byte("kind: NetworkValidator\n---\nkind: [\n"),
+			expectedErr: errors.New("failed to parse test.yaml, document 1"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			spec, err := unmarshalPluginSpec(tt.input)
+			specs, err := decodePluginSpecs(bytes.NewReader(tt.input), "test.yaml")
 
 			// If an error is expected
 			if tt.expectedErr != nil {
@@ -179,8 +266,121 @@ spec:
 				assert.Contains(t, err.Error(), tt.expectedErr.Error())
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedSpec, spec)
+				assert.Equal(t, tt.expectedSpecs, specs)
 			}
 		})
 	}
 }
+
+func TestReadPluginSpecsWithVars(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(`kind: NetworkValidator
+spec:
+  dnsRules:
+  - name: Resolve ${HOST}
+    host: ${HOST}
+---
+kind: NetworkValidator
+spec:
+  tcpConnRules:
+  - name: Connect to {{ .HOST }}
+    host: {{ .HOST }}
+    ports:
+    - ${PORT}
+`), 0600))
+
+	tc := &cfg.TaskConfig{CustomResources: file}
+	specs, err := readPluginSpecs(tc, vars.Vars{"HOST": "registry.example.com", "PORT": "443"})
+	assert.NoError(t, err)
+	assert.Equal(t, []plugins.PluginSpec{
+		&netapi.NetworkValidatorSpec{DNSRules: []netapi.DNSRule{{RuleName: "Resolve registry.example.com", Host: "registry.example.com"}}},
+		&netapi.NetworkValidatorSpec{TCPConnRules: []netapi.TCPConnRule{{RuleName: "Connect to registry.example.com", Host: "registry.example.com", Ports: []int{443}}}},
+	}, specs)
+
+	_, err = readPluginSpecs(tc, vars.Vars{"HOST": "registry.example.com"})
+	assert.ErrorContains(t, err, "undefined variable(s) in "+file+": PORT (line 13)")
+}
+
+func TestTimedOutResponse(t *testing.T) {
+	vrr := timedOutResponse("vSphere", context.DeadlineExceeded)
+
+	vr := &vapi.ValidationResult{}
+	assert.NoError(t, vres.Finalize(vr, vrr, logr.Discard()))
+
+	assert.Equal(t, vapi.ValidationFailed, vr.Status.State)
+	assert.Len(t, vr.Status.ValidationConditions, 1)
+
+	c := vr.Status.ValidationConditions[0]
+	assert.Equal(t, "validation-vsphere-timeout", c.ValidationRule)
+	assert.Equal(t, corev1.ConditionFalse, c.Status)
+	assert.Equal(t, []string{"plugin vSphere did not complete: context deadline exceeded"}, c.Failures)
+	assert.False(t, validationResponseOk(1, vrr, nil, logr.Discard()))
+}
+
+func TestPluginStatuses(t *testing.T) {
+	conditions := []vapi.ValidatorPluginCondition{
+		{PluginName: "validator-plugin-aws", Status: corev1.ConditionTrue, Message: "Plugin installed successfully"},
+		{PluginName: "validator-plugin-oci", Status: corev1.ConditionFalse, Message: "chart not found\n"},
+	}
+	statuses := pluginStatuses([]string{"validator-plugin-aws", "validator-plugin-network", "validator-plugin-oci"}, conditions)
+
+	assert.Equal(t, []pluginStatus{
+		{Plugin: "validator-plugin-aws", Phase: pluginPhaseInstalled, Message: "Plugin installed successfully"},
+		{Plugin: "validator-plugin-network", Phase: pluginPhasePending, Message: "waiting for plugin to be installed"},
+		{Plugin: "validator-plugin-oci", Phase: pluginPhaseFailed, Message: "chart not found"},
+	}, statuses)
+
+	table, err := buildPluginStatusString(statuses)
+	assert.NoError(t, err)
+	assert.Contains(t, table, "PLUGIN")
+	assert.Regexp(t, `validator-plugin-oci\s+Failed\s+chart not found`, table)
+}
+
+func TestValidationPluginFilter(t *testing.T) {
+	all, err := validationPluginFilter(nil)
+	assert.NoError(t, err)
+	assert.True(t, all("AWS"))
+
+	filter, err := validationPluginFilter([]string{"aws", "OCI"})
+	assert.NoError(t, err)
+	assert.True(t, filter("AWS"))
+	assert.True(t, filter("OCI"))
+	assert.False(t, filter("Network"))
+
+	_, err = validationPluginFilter([]string{"gcp"})
+	assert.ErrorContains(t, err, `invalid plugin "gcp"`)
+}
+
+func TestWaitingValidationResults(t *testing.T) {
+	states := map[string]vapi.ValidationState{
+		"validator-plugin-oci": vapi.ValidationInProgress,
+		"validator-plugin-aws": vapi.ValidationSucceeded,
+		"validator-plugin-net": vapi.ValidationFailed,
+		"validator-plugin-azu": "",
+	}
+	assert.Equal(t, []string{"validator-plugin-azu", "validator-plugin-oci"}, waitingValidationResults(states))
+}
+
+func awsConfig(vc *components.ValidatorConfig) *components.AWSPluginConfig {
+	return components.PluginConfig[*components.AWSPluginConfig](vc, "aws")
+}
+
+func azureConfig(vc *components.ValidatorConfig) *components.AzurePluginConfig {
+	return components.PluginConfig[*components.AzurePluginConfig](vc, "azure")
+}
+
+func maasConfig(vc *components.ValidatorConfig) *components.MaasPluginConfig {
+	return components.PluginConfig[*components.MaasPluginConfig](vc, "maas")
+}
+
+func networkConfig(vc *components.ValidatorConfig) *components.NetworkPluginConfig {
+	return components.PluginConfig[*components.NetworkPluginConfig](vc, "network")
+}
+
+func ociConfig(vc *components.ValidatorConfig) *components.OCIPluginConfig {
+	return components.PluginConfig[*components.OCIPluginConfig](vc, "oci")
+}
+
+func vsphereConfig(vc *components.ValidatorConfig) *components.VspherePluginConfig {
+	return components.PluginConfig[*components.VspherePluginConfig](vc, "vsphere")
+}
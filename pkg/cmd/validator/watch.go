@@ -0,0 +1,288 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	toolsWatch "k8s.io/client-go/tools/watch"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/embed"
+)
+
+// maxTransitions is the number of recent state transitions displayed by the watch dashboard
+const maxTransitions = 10
+
+// watchRow is the live status of a single validation result
+type watchRow struct {
+	Namespace      string
+	Name           string
+	Plugin         string
+	State          string
+	Sink           string
+	LastValidated  string
+	LastTransition string
+}
+
+// stateTransition is a change in the state of a validation result
+type stateTransition struct {
+	Time      time.Time
+	Namespace string
+	Name      string
+	Plugin    string
+	From      vapi.ValidationState
+	To        vapi.ValidationState
+}
+
+// String formats a state transition as a single tab-separated line
+func (t stateTransition) String() string {
+	return strings.Join([]string{
+		t.Time.Format(time.RFC3339), t.Namespace, t.Name, t.Plugin, stateOrNone(t.From), stateOrNone(t.To),
+	}, "\t")
+}
+
+// resultWatcher tracks the status of validation results as they change
+type resultWatcher struct {
+	rows        map[string]*watchRow
+	states      map[string]vapi.ValidationState
+	transitions []stateTransition
+	filter      func(plugin string) bool
+}
+
+func newResultWatcher(filter func(plugin string) bool) *resultWatcher {
+	return &resultWatcher{
+		rows:        make(map[string]*watchRow),
+		states:      make(map[string]vapi.ValidationState),
+		transitions: make([]stateTransition, 0),
+		filter:      filter,
+	}
+}
+
+// update records the latest version of a validation result. If record is true and the
+// result's state changed, the state transition is recorded and returned.
+func (w *resultWatcher) update(vr *vapi.ValidationResult, now time.Time, record bool) *stateTransition {
+	if !w.filter(vr.Spec.Plugin) {
+		return nil
+	}
+	key := vr.Namespace + "/" + vr.Name
+	row, ok := w.rows[key]
+	if !ok {
+		row = &watchRow{Namespace: vr.Namespace, Name: vr.Name, LastTransition: "-"}
+		w.rows[key] = row
+	}
+	row.Plugin = vr.Spec.Plugin
+	row.State = stateOrNone(vr.Status.State)
+	row.Sink = sinkState(vr)
+	row.LastValidated = lastValidated(vr)
+
+	prev, seen := w.states[key]
+	w.states[key] = vr.Status.State
+	if !record || (seen && prev == vr.Status.State) {
+		return nil
+	}
+
+	t := stateTransition{
+		Time:      now,
+		Namespace: vr.Namespace,
+		Name:      vr.Name,
+		Plugin:    vr.Spec.Plugin,
+		From:      prev,
+		To:        vr.Status.State,
+	}
+	row.LastTransition = fmt.Sprintf("%s -> %s (%s)", stateOrNone(t.From), stateOrNone(t.To), now.Format(time.TimeOnly))
+	w.transitions = append(w.transitions, t)
+	if len(w.transitions) > maxTransitions {
+		w.transitions = w.transitions[len(w.transitions)-maxTransitions:]
+	}
+	return &t
+}
+
+// remove stops tracking a deleted validation result
+func (w *resultWatcher) remove(vr *vapi.ValidationResult) {
+	key := vr.Namespace + "/" + vr.Name
+	delete(w.rows, key)
+	delete(w.states, key)
+}
+
+// render builds the watch dashboard: a table with the status of each validation result, followed by recent state transitions
+func (w *resultWatcher) render(now time.Time) (string, error) {
+	keys := make([]string, 0, len(w.rows))
+	for k := range w.rows {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	rows := make([]watchRow, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, *w.rows[k])
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Watching validation results. Last updated: %s. Press Ctrl+C to exit.\n\n", now.Format(time.RFC3339)))
+	if len(rows) == 0 {
+		sb.WriteString("No validation results found\n")
+	} else if err := embed.EFS.PrintTableTemplate(sb, rows, cfg.Validator, "validation-result-watch.tmpl"); err != nil {
+		return "", err
+	}
+
+	if len(w.transitions) > 0 {
+		sb.WriteString("\n\nRecent state transitions:\n")
+		for _, t := range w.transitions {
+			sb.WriteString(fmt.Sprintf("  %s  %s/%s: %s -> %s\n",
+				t.Time.Format(time.TimeOnly), t.Namespace, t.Name, stateOrNone(t.From), stateOrNone(t.To),
+			))
+		}
+	}
+	return sb.String(), nil
+}
+
+// WatchCommand continuously watches validation results, displaying a live dashboard, or
+// printing a line for each state transition if events mode is enabled, until interrupted
+func WatchCommand(tc *cfg.TaskConfig) error {
+	pluginFilter, err := validationPluginFilter(tc.Plugins)
+	if err != nil {
+		return err
+	}
+	if tc.Events {
+		// ensure stdout only contains state transitions
+		log.SetConsoleOutput(os.Stderr)
+	}
+
+	kClient, err := getValidationResultsCRDClient(tc)
+	if err != nil {
+		return errors.Wrap(err, "failed to get validation result client")
+	}
+	var client dynamic.ResourceInterface = kClient
+	if tc.Namespace != "" {
+		client = kClient.Namespace(tc.Namespace)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	vrList, err := client.List(ctx, metav1.ListOptions{LabelSelector: tc.Selector})
+	if err != nil {
+		return errors.Wrap(err, "failed to list validation results")
+	}
+	w := newResultWatcher(pluginFilter)
+	for _, vrObj := range vrList.Items {
+		vr, err := toValidationResult(vrObj)
+		if err != nil {
+			return err
+		}
+		w.update(vr, time.Now(), false)
+	}
+
+	watchFunc := func(opts metav1.ListOptions) (watch.Interface, error) {
+		opts.LabelSelector = tc.Selector
+		return client.Watch(ctx, opts)
+	}
+	watcher, err := toolsWatch.NewRetryWatcher(vrList.GetResourceVersion(), &cache.ListWatch{WatchFunc: watchFunc})
+	if err != nil {
+		return errors.Wrap(err, "failed to create retry watcher for validation results")
+	}
+	defer watcher.Stop()
+
+	var area *pterm.AreaPrinter
+	draw := func() error {
+		dashboard, err := w.render(time.Now())
+		if err != nil {
+			return err
+		}
+		area.Update(dashboard)
+		return nil
+	}
+	if !tc.Events {
+		area, err = pterm.DefaultArea.Start()
+		if err != nil {
+			return errors.Wrap(err, "failed to start watch dashboard")
+		}
+		defer func() { _ = area.Stop() }()
+		if err := draw(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return errors.New("validation result watch closed unexpectedly")
+			}
+			vrObj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				log.Debug("ignoring %s event for %T", event.Type, event.Object)
+				continue
+			}
+			vr, err := toValidationResult(*vrObj)
+			if err != nil {
+				return err
+			}
+
+			var t *stateTransition
+			if event.Type == watch.Deleted {
+				w.remove(vr)
+			} else {
+				t = w.update(vr, time.Now(), true)
+			}
+
+			if tc.Events {
+				if t != nil {
+					fmt.Println(t.String())
+				}
+				continue
+			}
+			if err := draw(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sinkState returns the reason of a validation result's sink emission condition, if any
+func sinkState(vr *vapi.ValidationResult) string {
+	for _, c := range vr.Status.Conditions {
+		if c.Type == vapi.SinkEmission {
+			return c.Reason
+		}
+	}
+	return "-"
+}
+
+// lastValidated returns the most recent time at which any of a validation result's rules were evaluated
+func lastValidated(vr *vapi.ValidationResult) string {
+	var last time.Time
+	for _, c := range vr.Status.ValidationConditions {
+		if c.LastValidationTime.After(last) {
+			last = c.LastValidationTime.Time
+		}
+	}
+	if last.IsZero() {
+		return "-"
+	}
+	return last.Format(time.RFC3339)
+}
+
+func stateOrNone(s vapi.ValidationState) string {
+	if s == "" {
+		return "-"
+	}
+	return string(s)
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+)
+
+func TestResultWatcher(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	filter, err := validationPluginFilter([]string{"aws", "oci"})
+	assert.NoError(t, err)
+	w := newResultWatcher(filter)
+
+	oci := newTestValidationResult("oci", "OCI", vapi.ValidationInProgress)
+	aws := newTestValidationResult("aws", "AWS", vapi.ValidationSucceeded)
+	network := newTestValidationResult("network", "Network", vapi.ValidationFailed)
+
+	// initial state is not recorded as a transition
+	assert.Nil(t, w.update(oci, now, false))
+	assert.Nil(t, w.update(aws, now, false))
+	assert.Nil(t, w.update(network, now, true))
+	assert.Len(t, w.rows, 2)
+
+	// unchanged state
+	assert.Nil(t, w.update(aws, now, true))
+
+	// changed state
+	oci = oci.DeepCopy()
+	oci.Status.State = vapi.ValidationFailed
+	oci.Status.Conditions = []clusterv1beta1.Condition{{Type: vapi.SinkEmission, Reason: "SinkEmitSucceeded"}}
+	oci.Status.ValidationConditions = []vapi.ValidationCondition{
+		{ValidationRule: "rule-a", LastValidationTime: metav1.NewTime(now.Add(-time.Minute))},
+		{ValidationRule: "rule-b", LastValidationTime: metav1.NewTime(now)},
+	}
+	tr := w.update(oci, now, true)
+	assert.NotNil(t, tr)
+	assert.Equal(t, "2024-06-01T12:00:00Z\tvalidator\toci\tOCI\tInProgress\tFailed", tr.String())
+
+	row := w.rows["validator/oci"]
+	assert.Equal(t, "Failed", row.State)
+	assert.Equal(t, "SinkEmitSucceeded", row.Sink)
+	assert.Equal(t, "2024-06-01T12:00:00Z", row.LastValidated)
+	assert.Equal(t, "InProgress -> Failed (12:00:00)", row.LastTransition)
+
+	// new results are recorded as transitions from no state
+	aws2 := newTestValidationResult("aws-2", "AWS", vapi.ValidationInProgress)
+	tr = w.update(aws2, now, true)
+	assert.NotNil(t, tr)
+	assert.Equal(t, "-", stateOrNone(tr.From))
+
+	dashboard, err := w.render(now)
+	assert.NoError(t, err)
+	assert.Regexp(t, `validator\s+aws\s+AWS\s+Succeeded\s+-\s+-\s+-`, dashboard)
+	assert.Regexp(t, `validator\s+oci\s+OCI\s+Failed\s+SinkEmitSucceeded`, dashboard)
+	assert.Contains(t, dashboard, "validator/oci: InProgress -> Failed")
+	assert.NotContains(t, dashboard, "network")
+
+	w.remove(oci)
+	assert.NotContains(t, w.rows, "validator/oci")
+}
+
+func TestResultWatcherTransitionLimit(t *testing.T) {
+	w := newResultWatcher(func(string) bool { return true })
+	vr := newTestValidationResult("oci", "OCI", vapi.ValidationInProgress)
+	for i := 0; i < maxTransitions*2; i++ {
+		vr = vr.DeepCopy()
+		if i%2 == 0 {
+			vr.Status.State = vapi.ValidationFailed
+		} else {
+			vr.Status.State = vapi.ValidationSucceeded
+		}
+		w.update(vr, time.Now(), true)
+	}
+	assert.Len(t, w.transitions, maxTransitions)
+}
@@ -0,0 +1,206 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigVersion is the current version of the validator configuration file format.
+// It must be incremented, and a Migration added, whenever the format changes in a backwards incompatible way.
+const ConfigVersion = 3
+
+// encryptionVersion is the first version in which sensitive values may be encrypted. Sensitive values within
+// older configuration files are only base64-encoded, so they are encrypted using the default keyring on save.
+const encryptionVersion = 2
+
+// configVersionKey is the key of the version field within a validator configuration file
+const configVersionKey = "version"
+
+// Migration upgrades a validator configuration file from one version to the next
+type Migration struct {
+	// From is the version which the migration upgrades from
+	From int
+	// Description describes the changes made by the migration
+	Description string
+	// Migrate modifies the root mapping node of a validator configuration file in place
+	Migrate func(root *yaml.Node) error
+}
+
+// migrations upgrade validator configuration files to the current version, in order
+var migrations = []Migration{
+	{
+		From:        0,
+		Description: "add version field",
+		Migrate:     func(*yaml.Node) error { return nil },
+	},
+	{
+		From:        1,
+		Description: "encrypt base64-encoded sensitive values using the default keyring when the file is next saved",
+		Migrate:     func(*yaml.Node) error { return nil },
+	},
+	{
+		From:        2,
+		Description: "move plugin sections, e.g., awsPlugin, into the plugins section, e.g., plugins.aws",
+		Migrate:     migratePluginSections,
+	},
+}
+
+// legacyPluginKeys maps the top-level key of each plugin's section prior to version 3 to its key within the plugins section
+var legacyPluginKeys = map[string]string{
+	"awsPlugin":     "aws",
+	"azurePlugin":   "azure",
+	"maasPlugin":    "maas",
+	"networkPlugin": "network",
+	"ociPlugin":     "oci",
+	"vspherePlugin": "vsphere",
+}
+
+// migratePluginSections moves each top-level plugin section into the plugins section. The nodes are moved
+// rather than copied, so that their positions within the original file are preserved.
+func migratePluginSections(root *yaml.Node) error {
+	var plugins *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "plugins" {
+			plugins = root.Content[i+1]
+		}
+	}
+	if plugins != nil && plugins.Kind == yaml.ScalarNode && plugins.Tag == "!!null" {
+		*plugins = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: plugins.Line, Column: plugins.Column}
+	}
+	if plugins != nil && plugins.Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid plugins section at line %d: expected a mapping, got %s", plugins.Line, plugins.ShortTag())
+	}
+
+	content := make([]*yaml.Node, 0, len(root.Content))
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k, v := root.Content[i], root.Content[i+1]
+		key, ok := legacyPluginKeys[k.Value]
+		if !ok {
+			content = append(content, k, v)
+			continue
+		}
+		if plugins == nil {
+			// the plugins section takes the place of the first plugin section
+			plugins = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: k.Line, Column: k.Column}
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "plugins", Line: k.Line, Column: k.Column}, plugins)
+		}
+		for j := 0; j+1 < len(plugins.Content); j += 2 {
+			if plugins.Content[j].Value == key {
+				return fmt.Errorf("both %s (line %d) and plugins.%s (line %d) are set", k.Value, k.Line, key, plugins.Content[j].Line)
+			}
+		}
+		k.Value = key
+		plugins.Content = append(plugins.Content, k, v)
+	}
+	root.Content = content
+	return nil
+}
+
+// migratesEncryption returns true if the applied migrations include the migration to encryptionVersion
+func migratesEncryption(applied []Migration) bool {
+	for _, m := range applied {
+		if m.From+1 == encryptionVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateConfig upgrades a validator configuration file to the current version.
+// The upgraded file is returned, along with the migrations which were applied, if any.
+func MigrateConfig(data []byte) ([]byte, []Migration, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse validator config: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{nil}}
+	}
+	if root := doc.Content[0]; root == nil || (root.Kind == yaml.ScalarNode && root.Tag == "!!null") {
+		doc.Content[0] = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	applied, err := MigrateNode(doc.Content[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(applied) == 0 {
+		return data, nil, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal validator config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal validator config: %w", err)
+	}
+	return buf.Bytes(), applied, nil
+}
+
+// MigrateNode upgrades the root mapping node of a validator configuration file to the current version, in place.
+// The migrations which were applied are returned, if any.
+func MigrateNode(root *yaml.Node) ([]Migration, error) {
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid validator config: expected a mapping, got %s", root.ShortTag())
+	}
+	version, err := configVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > ConfigVersion {
+		return nil, fmt.Errorf("validator config version %d is newer than the latest supported version, %d. Upgrade validatorctl to use it", version, ConfigVersion)
+	}
+
+	applied := make([]Migration, 0)
+	for _, m := range migrations {
+		if m.From < version {
+			continue
+		}
+		if err := m.Migrate(root); err != nil {
+			return nil, fmt.Errorf("failed to migrate validator config from version %d to %d: %w", m.From, m.From+1, err)
+		}
+		applied = append(applied, m)
+	}
+	if len(applied) > 0 {
+		setConfigVersion(root, ConfigVersion)
+	}
+	return applied, nil
+}
+
+// configVersion returns the version of a validator configuration file. Files without a version are version 0.
+func configVersion(root *yaml.Node) (int, error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != configVersionKey {
+			continue
+		}
+		v := root.Content[i+1]
+		version, err := strconv.Atoi(v.Value)
+		if err != nil || version < 0 {
+			return 0, fmt.Errorf("invalid validator config version %q at line %d", v.Value, v.Line)
+		}
+		return version, nil
+	}
+	return 0, nil
+}
+
+// setConfigVersion sets the version of a validator configuration file, adding the version field if necessary
+func setConfigVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == configVersionKey {
+			root.Content[i+1].Value = value
+			root.Content[i+1].Tag = "!!int"
+			return
+		}
+	}
+	root.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: configVersionKey},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: value},
+	}, root.Content...)
+}
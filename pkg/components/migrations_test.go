@@ -0,0 +1,143 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/encryption"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+		applied  int
+		err      string
+	}{
+		{
+			name:     "unversioned",
+			data:     "# comment\nkubeconfig: /tmp/kubeconfig\n",
+			expected: "version: 3\n# comment\nkubeconfig: /tmp/kubeconfig\n",
+			applied:  3,
+		},
+		{
+			name:     "empty",
+			data:     "",
+			expected: "version: 3\n",
+			applied:  3,
+		},
+		{
+			name:     "unencrypted",
+			data:     "version: 1\nkubeconfig: /tmp/kubeconfig\n",
+			expected: "version: 3\nkubeconfig: /tmp/kubeconfig\n",
+			applied:  2,
+		},
+		{
+			name:     "plugin sections",
+			data:     "version: 2\nawsPlugin:\n  enabled: true\nkubeconfig: /tmp/kubeconfig\nociPlugin:\n  enabled: false\n",
+			expected: "version: 3\nplugins:\n  aws:\n    enabled: true\n  oci:\n    enabled: false\nkubeconfig: /tmp/kubeconfig\n",
+			applied:  1,
+		},
+		{
+			name:     "plugin sections, existing plugins section",
+			data:     "version: 2\nplugins:\n  custom:\n    enabled: true\nnetworkPlugin:\n  enabled: true\n",
+			expected: "version: 3\nplugins:\n  custom:\n    enabled: true\n  network:\n    enabled: true\n",
+			applied:  1,
+		},
+		{
+			name: "plugin sections, conflict",
+			data: "version: 2\nplugins:\n  aws:\n    enabled: true\nawsPlugin:\n  enabled: false\n",
+			err:  "both awsPlugin (line 5) and plugins.aws (line 3) are set",
+		},
+		{
+			name:     "current",
+			data:     "version: 3\nkubeconfig: /tmp/kubeconfig\n",
+			expected: "version: 3\nkubeconfig: /tmp/kubeconfig\n",
+		},
+		{
+			name: "newer",
+			data: "version: 4\n",
+			err:  "validator config version 4 is newer than the latest supported version, 3",
+		},
+		{
+			name: "invalid",
+			data: "kubeconfig: /tmp/kubeconfig\nversion: latest\n",
+			err:  `invalid validator config version "latest" at line 2`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, applied, err := MigrateConfig([]byte(tt.data))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(migrated))
+			assert.Len(t, applied, tt.applied)
+		})
+	}
+}
+
+func TestMigrationChain(t *testing.T) {
+	defaultMigrations := migrations
+	migrations = []Migration{{
+		From:        0,
+		Description: "rename kubeConfig to kubeconfig",
+		Migrate: func(root *yaml.Node) error {
+			for i := 0; i < len(root.Content); i += 2 {
+				if root.Content[i].Value == "kubeConfig" {
+					root.Content[i].Value = "kubeconfig"
+				}
+			}
+			return nil
+		},
+	}}
+	defer func() { migrations = defaultMigrations }()
+
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(t.TempDir(), cfg.ValidatorConfigFile)}
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, []byte("kubeConfig: /tmp/kubeconfig\n"), 0600))
+
+	vc, err := LoadValidatorConfig(tc)
+	assert.NoError(t, err)
+	assert.Equal(t, ConfigVersion, vc.Version)
+	assert.Equal(t, "/tmp/kubeconfig", vc.Kubeconfig)
+}
+
+func TestLegacyConfigEncryptedOnSave(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, cfg.KeyringFile)
+
+	defaultKeyringFile := encryption.DefaultKeyringFile
+	encryption.DefaultKeyringFile = func() (string, error) {
+		return keyFile, nil
+	}
+	defer func() { encryption.DefaultKeyringFile = defaultKeyringFile }()
+
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile)}
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, []byte("version: 1\nsinkConfig:\n  values:\n    token: aHVudGVyMg==\n"), 0600))
+
+	vc, err := NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.True(t, vc.Legacy())
+	assert.Equal(t, "hunter2", vc.SinkConfig.Values["token"])
+	assert.NoError(t, SaveValidatorConfig(vc, tc))
+
+	data, err := os.ReadFile(tc.ConfigFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "token: enc:v1:")
+	assert.NotContains(t, string(data), "aHVudGVyMg==")
+	assert.FileExists(t, keyFile)
+
+	vc, err = NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.False(t, vc.Legacy())
+	assert.True(t, vc.Encrypted())
+	assert.Equal(t, "hunter2", vc.SinkConfig.Values["token"])
+}
@@ -0,0 +1,70 @@
+package components
+
+import (
+	"fmt"
+)
+
+// PluginConfigs contains the section of each plugin within a ValidatorConfig, keyed by plugin, e.g., aws.
+// The section of each registered plugin is unmarshaled into the plugin's configuration type. Sections
+// of plugins which aren't registered are retained as is, so that they are preserved when saved.
+type PluginConfigs map[string]any
+
+// pluginSection defers unmarshaling a plugin's section until its configuration type is known
+type pluginSection struct {
+	unmarshal func(any) error
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (s *pluginSection) UnmarshalYAML(unmarshal func(any) error) error {
+	s.unmarshal = unmarshal
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Sections are unmarshaled into any existing
+// configuration for the plugin, so that a partial section overrides individual fields.
+func (pc *PluginConfigs) UnmarshalYAML(unmarshal func(any) error) error {
+	sections := make(map[string]*pluginSection)
+	if err := unmarshal(&sections); err != nil {
+		return err
+	}
+	if *pc == nil {
+		*pc = make(PluginConfigs, len(sections))
+	}
+	for key, s := range sections {
+		p := registeredPlugin(key)
+		if p == nil {
+			var v any
+			if err := s.unmarshal(&v); err != nil {
+				return err
+			}
+			(*pc)[key] = v
+			continue
+		}
+		v := (*pc)[key]
+		if v == nil {
+			v = p.NewConfig()
+		}
+		if err := s.unmarshal(v); err != nil {
+			return fmt.Errorf("failed to unmarshal %s plugin configuration: %w", key, err)
+		}
+		(*pc)[key] = v
+	}
+	return nil
+}
+
+// PluginConfig returns the section of the plugin with the provided key, e.g., aws, or the zero value of T
+// if the section is unset or isn't of type T
+func PluginConfig[T any](c *ValidatorConfig, key string) T {
+	v, _ := c.Plugins[key].(T)
+	return v
+}
+
+// registeredPlugin returns the registered plugin with the provided key, if any
+func registeredPlugin(key string) Plugin {
+	for _, p := range registeredPlugins {
+		if p.Key() == key {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// testPlugin is a plugin whose section is a NetworkPluginConfig
+type testPlugin struct{}
+
+func (testPlugin) Key() string                               { return "test" }
+func (testPlugin) NewConfig() any                            { return &NetworkPluginConfig{} }
+func (testPlugin) InitConfig(*ValidatorConfig)               {}
+func (testPlugin) Encode(*ValidatorConfig, FieldCodec) error { return nil }
+func (testPlugin) Decode(*ValidatorConfig, FieldCodec) error { return nil }
+
+func withTestPlugin(t *testing.T) {
+	plugins := registeredPlugins
+	t.Cleanup(func() { registeredPlugins = plugins })
+	RegisterPlugin(testPlugin{})
+}
+
+func TestPluginConfigs(t *testing.T) {
+	withTestPlugin(t)
+
+	data := "plugins:\n  test:\n    enabled: true\n  unknown:\n    enabled: true\n    rules:\n    - name: rule\n"
+	c := &ValidatorConfig{}
+	require.NoError(t, yaml.Unmarshal([]byte(data), c))
+
+	pc := PluginConfig[*NetworkPluginConfig](c, "test")
+	require.NotNil(t, pc)
+	assert.True(t, pc.Enabled)
+	assert.Nil(t, PluginConfig[*NetworkPluginConfig](c, "unknown"))
+	assert.Nil(t, PluginConfig[*NetworkPluginConfig](c, "missing"))
+
+	// sections of unregistered plugins are preserved
+	b, err := yaml.Marshal(c.Plugins)
+	require.NoError(t, err)
+	var out map[string]map[string]any
+	require.NoError(t, yaml.Unmarshal(b, &out))
+	assert.Equal(t, true, out["test"]["enabled"])
+	assert.Equal(t, true, out["unknown"]["enabled"])
+	assert.Len(t, out["unknown"]["rules"], 1)
+
+	// partial sections override individual fields of existing sections
+	pc.HTTPFileAuths = [][]string{{"user", "pass"}}
+	require.NoError(t, yaml.Unmarshal([]byte("plugins:\n  test:\n    enabled: false\n"), c))
+	assert.Same(t, pc, PluginConfig[*NetworkPluginConfig](c, "test"))
+	assert.False(t, pc.Enabled)
+	assert.Equal(t, [][]string{{"user", "pass"}}, pc.HTTPFileAuths)
+
+	err = yaml.Unmarshal([]byte("plugins:\n  test:\n    enabled: [true]\n"), c)
+	assert.ErrorContains(t, err, "failed to unmarshal test plugin configuration")
+}
@@ -0,0 +1,159 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"emperror.dev/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/validator-labs/validatorctl/pkg/encryption"
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/kube"
+)
+
+const (
+	// SecretRefEnv references an environment variable, e.g., env:AWS_SECRET_ACCESS_KEY
+	SecretRefEnv = "env:"
+	// SecretRefFile references the contents of a file, e.g., file:/run/secrets/aws-secret-access-key
+	SecretRefFile = "file:"
+	// SecretRefK8s references a key within a Kubernetes secret, e.g., k8s:my-namespace/my-secret#password
+	SecretRefK8s = "k8s:"
+)
+
+// IsSecretRef returns true if the value is a reference to a secret stored outside of the validator configuration file
+func IsSecretRef(s string) bool {
+	return strings.HasPrefix(s, SecretRefEnv) || strings.HasPrefix(s, SecretRefFile) || strings.HasPrefix(s, SecretRefK8s)
+}
+
+// FieldCodec encodes and decodes the sensitive fields of a ValidatorConfig.
+// Each field is identified by its path within the validator configuration file, e.g., plugins.aws.secretAccessKey.
+type FieldCodec interface {
+	Encode(field, value string) (string, error)
+	Decode(field, value string) (string, error)
+}
+
+// fieldCodec encrypts and decrypts sensitive fields. Secret references are resolved on decode
+// and written back, unresolved, on encode.
+type fieldCodec struct {
+	cipher *encryption.Cipher
+	refs   *secretRefs
+}
+
+// Encode implements FieldCodec
+func (fc fieldCodec) Encode(field, value string) (string, error) {
+	if ref, ok := fc.refs.unresolve(field, value); ok {
+		return ref, nil
+	}
+	return fc.cipher.Encrypt(value)
+}
+
+// Decode implements FieldCodec
+func (fc fieldCodec) Decode(field, value string) (string, error) {
+	if IsSecretRef(value) {
+		return fc.refs.resolve(field, value)
+	}
+	return fc.cipher.Decrypt(value)
+}
+
+// secretRef is a secret reference and the value it resolved to
+type secretRef struct {
+	ref   string
+	value string
+}
+
+// secretRefs tracks the secret references within a ValidatorConfig, keyed by field path
+type secretRefs struct {
+	kubeconfig string
+	kClient    kubernetes.Interface
+	refs       map[string]secretRef
+}
+
+func newSecretRefs(kubeconfig string) *secretRefs {
+	return &secretRefs{
+		kubeconfig: kubeconfig,
+		refs:       make(map[string]secretRef),
+	}
+}
+
+// resolve resolves a secret reference. References which were already resolved for the field are not resolved again.
+func (r *secretRefs) resolve(field, ref string) (string, error) {
+	if sr, ok := r.refs[field]; ok && sr.ref == ref {
+		return sr.value, nil
+	}
+	value, err := r.lookup(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve secret reference %s for %s", ref, field)
+	}
+	r.refs[field] = secretRef{ref: ref, value: value}
+	return value, nil
+}
+
+// unresolve returns the secret reference for a field, provided that the field's value has not been modified since it was resolved
+func (r *secretRefs) unresolve(field, value string) (string, bool) {
+	sr, ok := r.refs[field]
+	if !ok {
+		return "", false
+	}
+	if sr.value != value {
+		log.Warn("%s was modified and no longer matches secret reference %s; it will be saved inline", field, sr.ref)
+		delete(r.refs, field)
+		return "", false
+	}
+	return sr.ref, true
+}
+
+func (r *secretRefs) lookup(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, SecretRefEnv):
+		name := strings.TrimPrefix(ref, SecretRefEnv)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+
+	case strings.HasPrefix(ref, SecretRefFile):
+		bytes, err := os.ReadFile(strings.TrimPrefix(ref, SecretRefFile)) // #nosec
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(bytes), "\r\n"), nil
+
+	case strings.HasPrefix(ref, SecretRefK8s):
+		return r.lookupK8s(strings.TrimPrefix(ref, SecretRefK8s))
+	}
+	return "", fmt.Errorf("unsupported secret reference: %s", ref)
+}
+
+// lookupK8s resolves a reference of the form namespace/secret#key
+func (r *secretRefs) lookupK8s(ref string) (string, error) {
+	nsName, key, ok := strings.Cut(ref, "#")
+	if !ok || key == "" {
+		return "", fmt.Errorf("invalid Kubernetes secret reference %s, expected namespace/secret#key", ref)
+	}
+	namespace, name, ok := strings.Cut(nsName, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", fmt.Errorf("invalid Kubernetes secret reference %s, expected namespace/secret#key", ref)
+	}
+
+	if r.kClient == nil {
+		kClient, err := kube.GetKubeClientset(r.kubeconfig)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create Kubernetes client")
+		}
+		r.kClient = kClient
+	}
+	secret, err := r.kClient.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", key, namespace, name)
+	}
+	return string(value), nil
+}
@@ -0,0 +1,145 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/encryption"
+)
+
+func TestSecretRefsLookup(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	assert.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0600))
+	t.Setenv("VALIDATOR_TEST_SECRET", "from-env")
+
+	r := newSecretRefs("")
+	r.kClient = fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "validator"},
+		Data:       map[string][]byte{"password": []byte("from-k8s")},
+	})
+
+	tests := []struct {
+		ref      string
+		expected string
+		err      string
+	}{
+		{ref: "env:VALIDATOR_TEST_SECRET", expected: "from-env"},
+		{ref: "env:VALIDATOR_TEST_UNSET", err: "environment variable VALIDATOR_TEST_UNSET is not set"},
+		{ref: "file:" + secretFile, expected: "from-file"},
+		{ref: "file:" + filepath.Join(dir, "missing"), err: "no such file or directory"},
+		{ref: "k8s:validator/creds#password", expected: "from-k8s"},
+		{ref: "k8s:validator/creds#username", err: "key username not found in secret validator/creds"},
+		{ref: "k8s:validator/creds", err: "expected namespace/secret#key"},
+		{ref: "k8s:creds#password", err: "expected namespace/secret#key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			value, err := r.resolve("field", tt.ref)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+
+			ref, ok := r.unresolve("field", value)
+			assert.True(t, ok)
+			assert.Equal(t, tt.ref, ref)
+		})
+	}
+}
+
+func TestSaveValidatorConfigPreservesSecretRefs(t *testing.T) {
+	dir := t.TempDir()
+
+	// the legacy configuration file is encrypted on save
+	defaultKeyringFile := encryption.DefaultKeyringFile
+	encryption.DefaultKeyringFile = func() (string, error) {
+		return filepath.Join(dir, cfg.KeyringFile), nil
+	}
+	defer func() { encryption.DefaultKeyringFile = defaultKeyringFile }()
+
+	t.Setenv("VALIDATOR_TEST_PASSWORD", "hunter2")
+	t.Setenv("VALIDATOR_TEST_TOKEN", "s3cr3t")
+
+	tc := &cfg.TaskConfig{ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile)}
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, []byte(`helmReleaseSecret:
+  name: chart-secret
+  basicAuth:
+    username: bob
+    password: env:VALIDATOR_TEST_PASSWORD
+sinkConfig:
+  values:
+    token: env:VALIDATOR_TEST_TOKEN
+    webhook: aHR0cHM6Ly9leGFtcGxlLmNvbQ==
+`), 0600))
+
+	vc, err := NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", vc.ReleaseSecret.BasicAuth.Password)
+	assert.Equal(t, "s3cr3t", vc.SinkConfig.Values["token"])
+	assert.Equal(t, "https://example.com", vc.SinkConfig.Values["webhook"])
+
+	// modified fields are no longer written as references
+	vc.SinkConfig.Values["token"] = "rotated"
+	assert.NoError(t, SaveValidatorConfig(vc, tc))
+
+	data, err := os.ReadFile(tc.ConfigFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "password: env:VALIDATOR_TEST_PASSWORD")
+	assert.NotContains(t, string(data), "VALIDATOR_TEST_TOKEN")
+	assert.NotContains(t, string(data), "rotated")
+	assert.NotContains(t, string(data), "hunter2")
+
+	vc, err = NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", vc.ReleaseSecret.BasicAuth.Password)
+	assert.Equal(t, "rotated", vc.SinkConfig.Values["token"])
+	assert.Equal(t, "https://example.com", vc.SinkConfig.Values["webhook"])
+}
+
+func TestTemplatedValidatorConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv("VALIDATOR_TEST_KUBECONFIG", "/tmp/kubeconfig")
+
+	tc := &cfg.TaskConfig{
+		ConfigFile: filepath.Join(dir, cfg.ValidatorConfigFile),
+		Vars:       []string{"REGISTRY=registry.example.com"},
+	}
+	data := []byte(`kubeconfig: ${VALIDATOR_TEST_KUBECONFIG}
+imageRegistry: '{{ .REGISTRY }}'
+`)
+	assert.NoError(t, os.WriteFile(tc.ConfigFile, data, 0600))
+
+	vc, err := NewValidatorFromConfig(tc)
+	assert.NoError(t, err)
+	assert.True(t, vc.Templated())
+	assert.Equal(t, "/tmp/kubeconfig", vc.Kubeconfig)
+	assert.Equal(t, "registry.example.com", vc.ImageRegistry)
+
+	// unchanged templated configuration files are left as is
+	assert.NoError(t, SaveValidatorConfig(vc, tc))
+	saved, err := os.ReadFile(tc.ConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(saved))
+
+	// changes to configuration files containing Go templates aren't saved, but don't fail
+	vc.KindConfig.UseKindCluster = true
+	assert.NoError(t, SaveValidatorConfig(vc, tc))
+	saved, err = os.ReadFile(tc.ConfigFile)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(saved))
+
+	tc.Vars = nil
+	_, err = NewValidatorFromConfig(tc)
+	assert.ErrorContains(t, err, "undefined variable(s)")
+}
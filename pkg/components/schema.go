@@ -0,0 +1,47 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/validator-labs/validatorctl/pkg/utils/schema"
+)
+
+// Schema returns a JSON Schema describing validator configuration files
+func Schema() *schema.Schema {
+	s := schema.Generate(ValidatorConfig{}, "validatorctl configuration file")
+	plugins := &schema.Schema{Type: schema.TypeObject, Properties: make(map[string]*schema.Schema), AdditionalProperties: false}
+	for _, p := range registeredPlugins {
+		plugins.Properties[p.Key()] = schema.Of(p.NewConfig())
+	}
+	s.Properties["plugins"] = plugins
+	return s
+}
+
+// refChecker is a FieldCodec which attempts to resolve secret references, without modifying any fields
+type refChecker struct {
+	refs *secretRefs
+	errs map[string]error
+}
+
+// Encode implements FieldCodec
+func (rc refChecker) Encode(_, value string) (string, error) {
+	return value, nil
+}
+
+// Decode implements FieldCodec
+func (rc refChecker) Decode(field, value string) (string, error) {
+	if IsSecretRef(value) {
+		if _, err := rc.refs.lookup(value); err != nil {
+			rc.errs[field] = fmt.Errorf("failed to resolve secret reference %s: %w", value, err)
+		}
+	}
+	return value, nil
+}
+
+// CheckSecretRefs attempts to resolve each secret reference within a ValidatorConfig which has not yet been decoded.
+// An error is returned for each secret reference which can't be resolved, keyed by field path.
+func (c *ValidatorConfig) CheckSecretRefs() map[string]error {
+	rc := refChecker{refs: newSecretRefs(c.Kubeconfig), errs: make(map[string]error)}
+	_ = c.decodeFields(rc)
+	return rc.errs
+}
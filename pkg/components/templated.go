@@ -0,0 +1,245 @@
+package components
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
+)
+
+// changedFields returns the path of each field of a ValidatorConfig loaded from a configuration file which
+// references variables that has changed since it was loaded, e.g., [kindConfig useKindCluster], given the
+// ValidatorConfig marshaled prior to encoding
+func (c *ValidatorConfig) changedFields(marshaled []byte) ([][]string, error) {
+	loaded, err := rootNode(c.loaded)
+	if err != nil {
+		return nil, err
+	}
+	current, err := rootNode(marshaled)
+	if err != nil {
+		return nil, err
+	}
+	return diffNodes(loaded, current, nil), nil
+}
+
+// patchable returns an error if the changed fields of a ValidatorConfig can't be written back to the
+// configuration file it was loaded from
+func (c *ValidatorConfig) patchable() error {
+	if c.migrated {
+		return fmt.Errorf("it predates version %d of the configuration file format", ConfigVersion)
+	}
+	if bytes.Contains(c.source, []byte("{{")) {
+		return errors.New("it contains Go templates")
+	}
+	return nil
+}
+
+// patchSource writes the changed fields of a ValidatorConfig, marshaled and encoded, back to the configuration
+// file it was loaded from, leaving the rest of the file, including the variables it references, as is.
+// The patched file is returned, along with the fields which weren't written back, since either their existing
+// values reference variables or their new values contain Go template actions.
+func (c *ValidatorConfig) patchSource(encoded []byte, changed [][]string) ([]byte, []string, error) {
+	// the document node is patched, so that any comments at the top of the file are preserved
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(c.source, doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal validator config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, errors.New("it isn't a mapping")
+	}
+	enc, err := rootNode(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skipped := make([]string, 0)
+	for _, path := range changed {
+		ok, err := patchNode(doc.Content[0], enc, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			skipped = append(skipped, strings.Join(path, "."))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	e := yaml.NewEncoder(buf)
+	e.SetIndent(2)
+	if err := e.Encode(doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal validator config: %w", err)
+	}
+	return buf.Bytes(), skipped, nil
+}
+
+// rootNode returns the root node of a YAML document, or an empty mapping if the document is empty
+func rootNode(data []byte) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validator config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	return doc.Content[0], nil
+}
+
+// diffNodes returns the path of each node which differs between two YAML documents. Mappings are
+// compared key by key. All other nodes, including lists, are compared as a whole.
+func diffNodes(a, b *yaml.Node, path []string) [][]string {
+	if a.Kind != yaml.MappingNode || b.Kind != yaml.MappingNode {
+		if nodesEqual(a, b) {
+			return nil
+		}
+		return [][]string{path}
+	}
+	paths := make([][]string, 0)
+	for i := 0; i+1 < len(b.Content); i += 2 {
+		key := b.Content[i].Value
+		p := append(slices.Clone(path), key)
+		if v := mappingValue(a, key); v != nil {
+			paths = append(paths, diffNodes(v, b.Content[i+1], p)...)
+		} else {
+			paths = append(paths, p)
+		}
+	}
+	for i := 0; i+1 < len(a.Content); i += 2 {
+		key := a.Content[i].Value
+		if mappingValue(b, key) == nil {
+			paths = append(paths, append(slices.Clone(path), key))
+		}
+	}
+	return paths
+}
+
+func nodesEqual(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || a.Tag != b.Tag || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if !nodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// patchNode sets the node at a path within src to the node at the same path within dst, removing it if it's
+// absent from dst. Any missing mappings are created. False is returned if the existing node references variables,
+// or if the new node contains Go template actions, since the file would then be rendered as a Go template.
+func patchNode(src, dst *yaml.Node, path []string) (bool, error) {
+	v := dst
+	for _, key := range path {
+		if v = mappingValue(v, key); v == nil {
+			break
+		}
+	}
+
+	parent := src
+	for _, key := range path[:len(path)-1] {
+		child := mappingValue(parent, key)
+		if child == nil || isNullNode(child) {
+			if v == nil {
+				return true, nil
+			}
+			if child == nil {
+				child = &yaml.Node{}
+				parent.Content = append(parent.Content, keyNode(key), child)
+			}
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		if child.Kind != yaml.MappingNode && references(child) {
+			return false, nil
+		}
+		if child.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("failed to patch %s: expected a mapping", strings.Join(path, "."))
+		}
+		parent = child
+	}
+
+	key := path[len(path)-1]
+	i := mappingIndex(parent, key)
+	if i >= 0 && references(parent.Content[i+1]) {
+		return false, nil
+	}
+	if v != nil && contains(v, "{{") {
+		return false, nil
+	}
+	if v != nil && references(v) {
+		// values which look like variables would otherwise be replaced on load
+		v = escape(v)
+	}
+	switch {
+	case v == nil && i >= 0:
+		parent.Content = slices.Delete(parent.Content, i, i+2)
+	case v == nil:
+	case i >= 0:
+		existing := parent.Content[i+1]
+		v.HeadComment, v.LineComment, v.FootComment = existing.HeadComment, existing.LineComment, existing.FootComment
+		parent.Content[i+1] = v
+	default:
+		parent.Content = append(parent.Content, keyNode(key), v)
+	}
+	return true, nil
+}
+
+// references returns true if any scalar within a node references variables
+func references(n *yaml.Node) bool {
+	if n.Kind == yaml.ScalarNode {
+		return vars.References(n.Value)
+	}
+	return slices.ContainsFunc(n.Content, references)
+}
+
+// escape returns a copy of a node, with any ${NAME} placeholders within its scalars escaped
+func escape(n *yaml.Node) *yaml.Node {
+	c := *n
+	c.Value = vars.Escape(n.Value)
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = escape(child)
+	}
+	return &c
+}
+
+// contains returns true if any scalar within a node contains a substring
+func contains(n *yaml.Node, substr string) bool {
+	if n.Kind == yaml.ScalarNode {
+		return strings.Contains(n.Value, substr)
+	}
+	return slices.ContainsFunc(n.Content, func(child *yaml.Node) bool { return contains(child, substr) })
+}
+
+func keyNode(key string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+}
+
+func isNullNode(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// mappingIndex returns the index of a key within a mapping's content, or -1 if it's absent
+func mappingIndex(n *yaml.Node, key string) int {
+	if n.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue returns the value of a key within a mapping, if any
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(n, key); i >= 0 {
+		return n.Content[i+1]
+	}
+	return nil
+}
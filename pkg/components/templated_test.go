@@ -0,0 +1,97 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+func TestSaveTemplatedValidatorConfig(t *testing.T) {
+	t.Setenv("VALIDATOR_TEST_KUBECONFIG", "/tmp/kubeconfig")
+	t.Setenv("VALIDATOR_TEST_CHART_VERSION", "v0.1.0")
+
+	tests := []struct {
+		name     string
+		data     string
+		update   func(*ValidatorConfig)
+		expected string
+	}{
+		{
+			name: "changed fields are patched",
+			data: `# validator configuration
+version: 3
+kubeconfig: ${VALIDATOR_TEST_KUBECONFIG} # set per environment
+kindConfig:
+  useKindCluster: false
+`,
+			update: func(c *ValidatorConfig) {
+				c.KindConfig.UseKindCluster = true
+				c.KindConfig.KindClusterName = "kind"
+				c.ImageRegistry = "${REGISTRY}"
+			},
+			expected: `# validator configuration
+version: 3
+kubeconfig: ${VALIDATOR_TEST_KUBECONFIG} # set per environment
+kindConfig:
+  useKindCluster: true
+  kindClusterName: kind
+imageRegistry: $${REGISTRY}
+`,
+		},
+		{
+			name: "fields which reference variables are skipped",
+			data: `version: 3
+kubeconfig: ${VALIDATOR_TEST_KUBECONFIG}
+helmRelease:
+  chart:
+    version: ${VALIDATOR_TEST_CHART_VERSION}
+`,
+			update: func(c *ValidatorConfig) {
+				c.Kubeconfig = "/tmp/other"
+				c.Release.Chart.Version = "v0.2.0"
+				c.Release.Chart.Name = "validator"
+			},
+			expected: `version: 3
+kubeconfig: ${VALIDATOR_TEST_KUBECONFIG}
+helmRelease:
+  chart:
+    version: ${VALIDATOR_TEST_CHART_VERSION}
+    name: validator
+`,
+		},
+		{
+			name: "migrated files aren't saved",
+			data: `kubeconfig: ${VALIDATOR_TEST_KUBECONFIG}
+`,
+			update: func(c *ValidatorConfig) {
+				c.ImageRegistry = "registry.example.com"
+			},
+			expected: `kubeconfig: ${VALIDATOR_TEST_KUBECONFIG}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := &cfg.TaskConfig{ConfigFile: filepath.Join(t.TempDir(), cfg.ValidatorConfigFile)}
+			require.NoError(t, os.WriteFile(tc.ConfigFile, []byte(tt.data), 0600))
+
+			c, err := NewValidatorFromConfig(tc)
+			require.NoError(t, err)
+			tt.update(c)
+			assert.NoError(t, SaveValidatorConfig(c, tc))
+
+			data, err := os.ReadFile(tc.ConfigFile)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+
+			c, err = NewValidatorFromConfig(tc)
+			require.NoError(t, err)
+			assert.Equal(t, "/tmp/kubeconfig", c.Kubeconfig)
+		})
+	}
+}
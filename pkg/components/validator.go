@@ -2,11 +2,13 @@
 package components
 
 import (
-	"encoding/base64"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"emperror.dev/errors"
+	"github.com/mohae/deepcopy"
 	"gopkg.in/yaml.v2"
 
 	aws "github.com/validator-labs/validator-plugin-aws/api/v1alpha1"
@@ -18,11 +20,14 @@ import (
 	validator "github.com/validator-labs/validator/api/v1alpha1"
 
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/encryption"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/vars"
 )
 
 // ValidatorConfig represents the validator configuration.
 type ValidatorConfig struct {
+	Version          int                    `yaml:"version"`
 	HelmConfig       *validator.HelmConfig  `yaml:"helmConfig"`
 	Release          *validator.HelmRelease `yaml:"helmRelease"`
 	ReleaseSecret    *Secret                `yaml:"helmReleaseSecret"`
@@ -34,17 +39,31 @@ type ValidatorConfig struct {
 	ImageRegistry    string                 `yaml:"imageRegistry"`
 	UseFixedVersions bool                   `yaml:"useFixedVersions"`
 
-	AWSPlugin     *AWSPluginConfig     `yaml:"awsPlugin,omitempty"`
-	AzurePlugin   *AzurePluginConfig   `yaml:"azurePlugin,omitempty"`
-	MaasPlugin    *MaasPluginConfig    `yaml:"maasPlugin,omitempty"`
-	NetworkPlugin *NetworkPluginConfig `yaml:"networkPlugin,omitempty"`
-	OCIPlugin     *OCIPluginConfig     `yaml:"ociPlugin,omitempty"`
-	VspherePlugin *VspherePluginConfig `yaml:"vspherePlugin,omitempty"`
+	// Plugins contains the section of each plugin, keyed by plugin, e.g., aws
+	Plugins PluginConfigs `yaml:"plugins,omitempty"`
+
+	Encryption *encryption.Config `yaml:"encryption,omitempty"`
+
+	// cipher encrypts and decrypts sensitive values. It is initialized on decode.
+	cipher *encryption.Cipher
+	// refs tracks secret references, so that they can be written back unresolved. It is initialized on decode.
+	refs *secretRefs
+	// source is the configuration file as read from disk, if it references variables, in which case only
+	// the fields which have changed since it was loaded are written back to it on save
+	source []byte
+	// loaded is the configuration, marshaled once loaded and decoded. It is only set if source is.
+	loaded []byte
+	// migrated is true if the configuration file was migrated to the current version on load
+	migrated bool
+	// legacy is true if the configuration file predates encryption, in which case it is encrypted on save
+	legacy bool
 }
 
 // NewValidatorConfig creates a new ValidatorConfig object.
+// The section of each registered plugin is initialized with the plugin's defaults.
 func NewValidatorConfig() *ValidatorConfig {
-	return &ValidatorConfig{
+	c := &ValidatorConfig{
+		Version: ConfigVersion,
 		// Base config
 		HelmConfig: &validator.HelmConfig{},
 		Release:    &validator.HelmRelease{},
@@ -67,143 +86,180 @@ func NewValidatorConfig() *ValidatorConfig {
 				ProxyCACert: &CACert{},
 			},
 		},
-		// Plugin config
-		AWSPlugin: &AWSPluginConfig{
-			Release:   &validator.HelmRelease{},
-			Validator: &aws.AwsValidatorSpec{},
-		},
-		AzurePlugin: &AzurePluginConfig{
-			Release:   &validator.HelmRelease{},
-			Validator: &azure.AzureValidatorSpec{},
-		},
-		MaasPlugin: &MaasPluginConfig{
-			Release:   &validator.HelmRelease{},
-			Validator: &maas.MaasValidatorSpec{},
-		},
-		NetworkPlugin: &NetworkPluginConfig{
-			Release:       &validator.HelmRelease{},
-			HTTPFileAuths: make([][]string, 0),
-			Validator: &network.NetworkValidatorSpec{
-				CACerts: network.CACertificates{},
-			},
-		},
-		OCIPlugin: &OCIPluginConfig{
-			Release:     &validator.HelmRelease{},
-			Validator:   &oci.OciValidatorSpec{},
-			CaCertPaths: make(map[int]string),
-		},
-		VspherePlugin: &VspherePluginConfig{
-			Release:   &validator.HelmRelease{},
-			Validator: &vsphereapi.VsphereValidatorSpec{},
-		},
 	}
+	c.InitPlugins()
+	return c
 }
 
-// AnyPluginEnabled returns true if any plugin is enabled.
-func (c *ValidatorConfig) AnyPluginEnabled() bool {
-	return c.AWSPlugin.Enabled || c.NetworkPlugin.Enabled || c.VspherePlugin.Enabled || c.OCIPlugin.Enabled || c.AzurePlugin.Enabled || c.MaasPlugin.Enabled
-}
-
-// EnabledPluginsHaveRules returns true if all enabled plugins have at least one rule configured.
-func (c *ValidatorConfig) EnabledPluginsHaveRules() (bool, []string) {
-	var ok bool
-	invalidPlugins := []string{}
-	if c.AWSPlugin != nil && c.AWSPlugin.Enabled && c.AWSPlugin.Validator.ResultCount() == 0 {
-		invalidPlugins = append(invalidPlugins, c.AWSPlugin.Validator.PluginCode())
-	}
-	if c.AzurePlugin != nil && c.AzurePlugin.Enabled && c.AzurePlugin.Validator.ResultCount() == 0 {
-		invalidPlugins = append(invalidPlugins, c.AzurePlugin.Validator.PluginCode())
-	}
-	if c.MaasPlugin != nil && c.MaasPlugin.Enabled && c.MaasPlugin.Validator.ResultCount() == 0 {
-		invalidPlugins = append(invalidPlugins, c.MaasPlugin.Validator.PluginCode())
+// InitPlugins initializes the section of each registered plugin which is missing from the validator
+// configuration, e.g., for plugins which were never enabled, so that their rules can be modified.
+func (c *ValidatorConfig) InitPlugins() {
+	if c.Plugins == nil {
+		c.Plugins = make(PluginConfigs)
 	}
-	if c.NetworkPlugin != nil && c.NetworkPlugin.Enabled && c.NetworkPlugin.Validator.ResultCount() == 0 {
-		invalidPlugins = append(invalidPlugins, c.NetworkPlugin.Validator.PluginCode())
+	for _, p := range registeredPlugins {
+		p.InitConfig(c)
 	}
-	if c.OCIPlugin != nil && c.OCIPlugin.Enabled && c.OCIPlugin.Validator.ResultCount() == 0 {
-		invalidPlugins = append(invalidPlugins, c.OCIPlugin.Validator.PluginCode())
+}
+
+// PluginCodec encrypts and decrypts the sensitive fields within a plugin's section of a ValidatorConfig.
+type PluginCodec interface {
+	Encode(c *ValidatorConfig, fc FieldCodec) error
+	Decode(c *ValidatorConfig, fc FieldCodec) error
+}
+
+// Plugin manages a plugin's section of a ValidatorConfig
+type Plugin interface {
+	PluginCodec
+
+	// Key returns the key of the plugin's section within the plugins section of a ValidatorConfig, e.g., aws
+	Key() string
+	// NewConfig returns a pointer to an empty section for the plugin, which its section is unmarshaled into
+	NewConfig() any
+	// InitConfig initializes the plugin's section of the ValidatorConfig, and its rules, with defaults if they are unset
+	InitConfig(c *ValidatorConfig)
+}
+
+var registeredPlugins = make([]Plugin, 0)
+
+// RegisterPlugin registers a Plugin, which is applied whenever a ValidatorConfig is initialized, encoded or decoded.
+func RegisterPlugin(p Plugin) {
+	registeredPlugins = append(registeredPlugins, p)
+}
+
+// Encrypted returns true if the sensitive fields of the ValidatorConfig are encrypted when saved.
+func (c *ValidatorConfig) Encrypted() bool {
+	return c.Encryption != nil
+}
+
+// SetKeySource generates a new data key, wrapped by the provided key source, which is used to
+// encrypt the sensitive fields of the ValidatorConfig when it is next saved.
+func (c *ValidatorConfig) SetKeySource(ks encryption.KeySource) error {
+	ci, ec, err := encryption.NewCipher(ks)
+	if err != nil {
+		return err
 	}
-	if c.VspherePlugin != nil && c.VspherePlugin.Enabled && c.VspherePlugin.Validator.ResultCount() == 0 {
-		invalidPlugins = append(invalidPlugins, c.VspherePlugin.Validator.PluginCode())
+	c.cipher = ci
+	c.Encryption = ec
+	return nil
+}
+
+// RemoveEncryption ensures that the sensitive fields of the ValidatorConfig are only base64-encoded when it is next saved.
+func (c *ValidatorConfig) RemoveEncryption() {
+	c.cipher = encryption.Legacy()
+	c.Encryption = nil
+}
+
+func (c *ValidatorConfig) decode() error {
+	ci := c.cipher
+	if ci == nil {
+		if c.Encryption != nil {
+			var err error
+			ci, err = encryption.Open(c.Encryption)
+			if err != nil {
+				return errors.Wrap(err, "failed to decrypt validator configuration")
+			}
+			c.cipher = ci
+		} else {
+			// unencrypted configuration files are base64-encoded
+			ci = encryption.Legacy()
+		}
 	}
-	if len(invalidPlugins) == 0 {
-		ok = true
+	if c.refs == nil {
+		c.refs = newSecretRefs(c.Kubeconfig)
 	}
-	return ok, invalidPlugins
+	return c.decodeFields(fieldCodec{cipher: ci, refs: c.refs})
 }
 
-// nolint:dupl
-func (c *ValidatorConfig) decode() error {
+// decodeFields decodes each sensitive field of the ValidatorConfig using the provided FieldCodec
+func (c *ValidatorConfig) decodeFields(fc FieldCodec) error {
 	if c.ReleaseSecret != nil {
-		if err := c.ReleaseSecret.decode(); err != nil {
+		if err := c.ReleaseSecret.decode(fc, "helmReleaseSecret"); err != nil {
 			return errors.Wrap(err, "failed to decode release secret configuration")
 		}
 	}
-	if err := c.SinkConfig.decode(); err != nil {
-		return errors.Wrap(err, "failed to decode Sink configuration")
-	}
-
-	if c.AWSPlugin != nil {
-		if err := c.AWSPlugin.decode(); err != nil {
-			return errors.Wrap(err, "failed to decode AWS plugin configuration")
+	if c.SinkConfig != nil {
+		if err := c.SinkConfig.decode(fc); err != nil {
+			return errors.Wrap(err, "failed to decode Sink configuration")
 		}
 	}
-	if c.AzurePlugin != nil {
-		if err := c.AzurePlugin.decode(); err != nil {
-			return errors.Wrap(err, "failed to decode Azure plugin configuration")
+	for _, pc := range registeredPlugins {
+		if err := pc.Decode(c, fc); err != nil {
+			return err
 		}
 	}
-	if c.MaasPlugin != nil {
-		if err := c.MaasPlugin.decode(); err != nil {
-			return errors.Wrap(err, "failed to decode MAAS plugin configuration")
+	return nil
+}
+
+func (c *ValidatorConfig) encode() error {
+	if c.cipher == nil && c.Encryption == nil && c.legacy {
+		// legacy configuration files are migrated to encryption using the default keyring, which is stored by reference
+		ks, err := encryption.NewKeySource(encryption.ProviderKeyring, "")
+		if err != nil {
+			return err
+		}
+		if err := c.SetKeySource(ks); err != nil {
+			return errors.Wrap(err, "failed to encrypt legacy validator configuration")
 		}
+		log.InfoCLI("Encrypting sensitive values using the default keyring. Run 'validatorctl config decrypt' to store them unencrypted.")
+	}
+	ci := c.cipher
+	if ci == nil {
+		// sensitive fields of new configuration files are only encrypted once a key source is set, i.e., via 'validatorctl config encrypt'
+		ci = encryption.Legacy()
 	}
-	if c.NetworkPlugin != nil {
-		if err := c.NetworkPlugin.decode(); err != nil {
-			return errors.Wrap(err, "failed to decode Network plugin configuration")
+	if c.refs == nil {
+		c.refs = newSecretRefs(c.Kubeconfig)
+	}
+	return c.encodeFields(fieldCodec{cipher: ci, refs: c.refs})
+}
+
+// encodeFields encodes each sensitive field of the ValidatorConfig using the provided FieldCodec
+func (c *ValidatorConfig) encodeFields(fc FieldCodec) error {
+	if c.ReleaseSecret != nil {
+		if err := c.ReleaseSecret.encode(fc, "helmReleaseSecret"); err != nil {
+			return errors.Wrap(err, "failed to encode release secret configuration")
 		}
 	}
-	if c.OCIPlugin != nil {
-		if err := c.OCIPlugin.decode(); err != nil {
-			return errors.Wrap(err, "failed to decode OCI plugin configuration")
+	if c.SinkConfig != nil {
+		if err := c.SinkConfig.encode(fc); err != nil {
+			return errors.Wrap(err, "failed to encode Sink configuration")
 		}
 	}
-	if c.VspherePlugin != nil {
-		if err := c.VspherePlugin.decode(); err != nil {
-			return errors.Wrap(err, "failed to decode vSphere plugin configuration")
+	for _, pc := range registeredPlugins {
+		if err := pc.Encode(c, fc); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
-// nolint:dupl
-func (c *ValidatorConfig) encode() error {
-	if c.ReleaseSecret != nil {
-		c.ReleaseSecret.encode()
-	}
-	c.SinkConfig.encode()
+// RedactedValue replaces the value of each non-empty sensitive field within a redacted ValidatorConfig
+const RedactedValue = "<redacted>"
 
-	if c.AWSPlugin != nil {
-		c.AWSPlugin.encode()
-	}
-	if c.AzurePlugin != nil {
-		c.AzurePlugin.encode()
-	}
-	if c.MaasPlugin != nil {
-		c.MaasPlugin.encode()
-	}
-	if c.NetworkPlugin != nil {
-		c.NetworkPlugin.encode()
-	}
-	if c.OCIPlugin != nil {
-		c.OCIPlugin.encode()
-	}
-	if c.VspherePlugin != nil {
-		c.VspherePlugin.encode()
+// redactor is a FieldCodec which redacts sensitive fields
+type redactor struct{}
+
+// Encode implements FieldCodec
+func (redactor) Encode(_, value string) (string, error) {
+	if value == "" {
+		return "", nil
 	}
+	return RedactedValue, nil
+}
 
-	return nil
+// Decode implements FieldCodec
+func (redactor) Decode(_, value string) (string, error) {
+	return value, nil
+}
+
+// Redacted returns a copy of the ValidatorConfig in which the value of each sensitive field is redacted
+func (c *ValidatorConfig) Redacted() (*ValidatorConfig, error) {
+	rc := deepcopy.Copy(c).(*ValidatorConfig)
+	if err := rc.encodeFields(redactor{}); err != nil {
+		return nil, errors.Wrap(err, "failed to redact validator configuration")
+	}
+	return rc, nil
 }
 
 // RegistryConfig represents the artifact registry configuration.
@@ -257,32 +313,30 @@ type SinkConfig struct {
 	Values       map[string]string `yaml:"values"`
 }
 
-func (c *SinkConfig) encode() {
+func (c *SinkConfig) encode(fc FieldCodec) error {
 	if c.Values == nil {
-		return
+		return nil
 	}
 	for k, v := range c.Values {
-		if v == "" {
-			continue
+		value, err := fc.Encode("sinkConfig.values."+k, v)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode SinkConfig key %s", k)
 		}
-		value := base64.StdEncoding.EncodeToString([]byte(v))
 		c.Values[k] = value
 	}
+	return nil
 }
 
-func (c *SinkConfig) decode() error {
+func (c *SinkConfig) decode(fc FieldCodec) error {
 	if c.Values == nil {
 		return nil
 	}
-	for k := range c.Values {
-		if c.Values[k] == "" {
-			continue
-		}
-		bytes, err := base64.StdEncoding.DecodeString(c.Values[k])
+	for k, v := range c.Values {
+		value, err := fc.Decode("sinkConfig.values."+k, v)
 		if err != nil {
 			return errors.Wrapf(err, "failed to decode SinkConfig key %s", k)
 		}
-		c.Values[k] = string(bytes)
+		c.Values[k] = value
 	}
 	return nil
 }
@@ -298,35 +352,48 @@ type AWSPluginConfig struct {
 	Validator          *aws.AwsValidatorSpec  `yaml:"validator"`
 }
 
-func (c *AWSPluginConfig) encode() {
-	accessKey := base64.StdEncoding.EncodeToString([]byte(c.AccessKeyID))
+// Encode encodes the sensitive fields of the AWSPluginConfig.
+func (c *AWSPluginConfig) Encode(fc FieldCodec) error {
+	accessKey, err := fc.Encode("plugins.aws.accessKeyId", c.AccessKeyID)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode access key id")
+	}
 	c.AccessKeyID = accessKey
 
-	secretKey := base64.StdEncoding.EncodeToString([]byte(c.SecretAccessKey))
+	secretKey, err := fc.Encode("plugins.aws.secretAccessKey", c.SecretAccessKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode secret access key")
+	}
 	c.SecretAccessKey = secretKey
 
-	sessionToken := base64.StdEncoding.EncodeToString([]byte(c.SessionToken))
+	sessionToken, err := fc.Encode("plugins.aws.sessionToken", c.SessionToken)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode session token")
+	}
 	c.SessionToken = sessionToken
+
+	return nil
 }
 
-func (c *AWSPluginConfig) decode() error {
-	bytes, err := base64.StdEncoding.DecodeString(c.AccessKeyID)
+// Decode decodes the sensitive fields of the AWSPluginConfig.
+func (c *AWSPluginConfig) Decode(fc FieldCodec) error {
+	accessKey, err := fc.Decode("plugins.aws.accessKeyId", c.AccessKeyID)
 	if err != nil {
 		return errors.Wrap(err, "failed to decode access key id")
 	}
-	c.AccessKeyID = string(bytes)
+	c.AccessKeyID = accessKey
 
-	bytes, err = base64.StdEncoding.DecodeString(c.SecretAccessKey)
+	secretKey, err := fc.Decode("plugins.aws.secretAccessKey", c.SecretAccessKey)
 	if err != nil {
 		return errors.Wrap(err, "failed to decode secret access key")
 	}
-	c.SecretAccessKey = string(bytes)
+	c.SecretAccessKey = secretKey
 
-	bytes, err = base64.StdEncoding.DecodeString(c.SessionToken)
+	sessionToken, err := fc.Decode("plugins.aws.sessionToken", c.SessionToken)
 	if err != nil {
 		return errors.Wrap(err, "failed to decode session token")
 	}
-	c.SessionToken = string(bytes)
+	c.SessionToken = sessionToken
 
 	return nil
 }
@@ -343,17 +410,24 @@ type AzurePluginConfig struct {
 	Validator          *azure.AzureValidatorSpec `yaml:"validator"`
 }
 
-func (c *AzurePluginConfig) encode() {
-	clientSecret := base64.StdEncoding.EncodeToString([]byte(c.ClientSecret))
+// Encode encodes the sensitive fields of the AzurePluginConfig.
+func (c *AzurePluginConfig) Encode(fc FieldCodec) error {
+	clientSecret, err := fc.Encode("plugins.azure.clientSecret", c.ClientSecret)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode Client Secret")
+	}
 	c.ClientSecret = clientSecret
+
+	return nil
 }
 
-func (c *AzurePluginConfig) decode() error {
-	bytes, err := base64.StdEncoding.DecodeString(c.ClientSecret)
+// Decode decodes the sensitive fields of the AzurePluginConfig.
+func (c *AzurePluginConfig) Decode(fc FieldCodec) error {
+	clientSecret, err := fc.Decode("plugins.azure.clientSecret", c.ClientSecret)
 	if err != nil {
 		return errors.Wrap(err, "failed to decode Client Secret")
 	}
-	c.ClientSecret = string(bytes)
+	c.ClientSecret = clientSecret
 
 	return nil
 }
@@ -365,25 +439,32 @@ type MaasPluginConfig struct {
 	Validator *maas.MaasValidatorSpec `yaml:"validator"`
 }
 
-func (c *MaasPluginConfig) encode() {
+// Encode encodes the sensitive fields of the MaasPluginConfig.
+func (c *MaasPluginConfig) Encode(fc FieldCodec) error {
 	if c.Validator == nil {
-		return
+		return nil
 	}
 
-	token := base64.StdEncoding.EncodeToString([]byte(c.Validator.Auth.APIToken))
+	token, err := fc.Encode("plugins.maas.validator.auth.apiToken", c.Validator.Auth.APIToken)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode token")
+	}
 	c.Validator.Auth.APIToken = token
+
+	return nil
 }
 
-func (c *MaasPluginConfig) decode() error {
+// Decode decodes the sensitive fields of the MaasPluginConfig.
+func (c *MaasPluginConfig) Decode(fc FieldCodec) error {
 	if c.Validator == nil {
 		return nil
 	}
 
-	bytes, err := base64.StdEncoding.DecodeString(c.Validator.Auth.APIToken)
+	token, err := fc.Decode("plugins.maas.validator.auth.apiToken", c.Validator.Auth.APIToken)
 	if err != nil {
 		return errors.Wrap(err, "failed to decode token")
 	}
-	c.Validator.Auth.APIToken = string(bytes)
+	c.Validator.Auth.APIToken = token
 
 	return nil
 }
@@ -402,28 +483,35 @@ func (c *NetworkPluginConfig) AddDummyHTTPFileAuth() {
 	c.HTTPFileAuths = append(c.HTTPFileAuths, []string{"", ""})
 }
 
-func (c *NetworkPluginConfig) encode() {
+// Encode encodes the sensitive fields of the NetworkPluginConfig.
+func (c *NetworkPluginConfig) Encode(fc FieldCodec) error {
 	if c.HTTPFileAuths == nil {
-		return
+		return nil
 	}
 
 	for i, auth := range c.HTTPFileAuths {
-		password := base64.StdEncoding.EncodeToString([]byte(auth[1]))
+		password, err := fc.Encode(fmt.Sprintf("plugins.network.httpFileAuths[%d]", i), auth[1])
+		if err != nil {
+			return fmt.Errorf("failed to encode password: %w", err)
+		}
 		c.HTTPFileAuths[i][1] = password
 	}
+
+	return nil
 }
 
-func (c *NetworkPluginConfig) decode() error {
+// Decode decodes the sensitive fields of the NetworkPluginConfig.
+func (c *NetworkPluginConfig) Decode(fc FieldCodec) error {
 	if c.HTTPFileAuths == nil {
 		return nil
 	}
 
 	for i, auth := range c.HTTPFileAuths {
-		bytes, err := base64.StdEncoding.DecodeString(auth[1])
+		password, err := fc.Decode(fmt.Sprintf("plugins.network.httpFileAuths[%d]", i), auth[1])
 		if err != nil {
 			return fmt.Errorf("failed to decode password: %w", err)
 		}
-		c.HTTPFileAuths[i][1] = string(bytes)
+		c.HTTPFileAuths[i][1] = password
 	}
 
 	return nil
@@ -439,18 +527,23 @@ type OCIPluginConfig struct {
 	Validator        *oci.OciValidatorSpec  `yaml:"validator"`
 }
 
-func (c *OCIPluginConfig) encode() {
-	for _, s := range c.Secrets {
+// Encode encodes the sensitive fields of the OCIPluginConfig.
+func (c *OCIPluginConfig) Encode(fc FieldCodec) error {
+	for i, s := range c.Secrets {
 		if s != nil {
-			s.encode()
+			if err := s.encode(fc, fmt.Sprintf("plugins.oci.secrets[%d]", i)); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func (c *OCIPluginConfig) decode() error {
-	for _, s := range c.Secrets {
+// Decode decodes the sensitive fields of the OCIPluginConfig.
+func (c *OCIPluginConfig) Decode(fc FieldCodec) error {
+	for i, s := range c.Secrets {
 		if s != nil {
-			if err := s.decode(); err != nil {
+			if err := s.decode(fc, fmt.Sprintf("plugins.oci.secrets[%d]", i)); err != nil {
 				return err
 			}
 		}
@@ -465,19 +558,26 @@ type VspherePluginConfig struct {
 	Validator *vsphereapi.VsphereValidatorSpec `yaml:"validator"`
 }
 
-func (c *VspherePluginConfig) encode() {
+// Encode encodes the sensitive fields of the VspherePluginConfig.
+func (c *VspherePluginConfig) Encode(fc FieldCodec) error {
 	if c.Validator == nil {
-		return
+		return nil
 	}
 	if c.Validator.Auth.Account == nil {
-		return
+		return nil
 	}
 
-	password := base64.StdEncoding.EncodeToString([]byte(c.Validator.Auth.Account.Password))
+	password, err := fc.Encode("plugins.vsphere.validator.auth.account.password", c.Validator.Auth.Account.Password)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode password")
+	}
 	c.Validator.Auth.Account.Password = password
+
+	return nil
 }
 
-func (c *VspherePluginConfig) decode() error {
+// Decode decodes the sensitive fields of the VspherePluginConfig.
+func (c *VspherePluginConfig) Decode(fc FieldCodec) error {
 	if c.Validator == nil {
 		return nil
 	}
@@ -485,11 +585,11 @@ func (c *VspherePluginConfig) decode() error {
 		return nil
 	}
 
-	bytes, err := base64.StdEncoding.DecodeString(c.Validator.Auth.Account.Password)
+	password, err := fc.Decode("plugins.vsphere.validator.auth.account.password", c.Validator.Auth.Account.Password)
 	if err != nil {
 		return errors.Wrap(err, "failed to decode password")
 	}
-	c.Validator.Auth.Account.Password = string(bytes)
+	c.Validator.Auth.Account.Password = password
 
 	return nil
 }
@@ -514,28 +614,34 @@ func (s *Secret) ShouldCreate() bool {
 	return !s.Exists && (s.BasicAuth.Configured() || len(s.Data) > 0 || s.CaCertFile != "")
 }
 
-func (s *Secret) encode() {
+func (s *Secret) encode(fc FieldCodec, field string) error {
 	if s.BasicAuth != nil {
-		s.BasicAuth.encode()
+		if err := s.BasicAuth.encode(fc, field+".basicAuth"); err != nil {
+			return err
+		}
 	}
 	for k, v := range s.Data {
-		v := base64.StdEncoding.EncodeToString([]byte(v))
+		v, err := fc.Encode(field+".data."+k, v)
+		if err != nil {
+			return fmt.Errorf("failed to encode value for secret key '%s': %w", k, err)
+		}
 		s.Data[k] = v
 	}
+	return nil
 }
 
-func (s *Secret) decode() error {
+func (s *Secret) decode(fc FieldCodec, field string) error {
 	if s.BasicAuth != nil {
-		if err := s.BasicAuth.decode(); err != nil {
+		if err := s.BasicAuth.decode(fc, field+".basicAuth"); err != nil {
 			return err
 		}
 	}
-	for k := range s.Data {
-		bytes, err := base64.StdEncoding.DecodeString(s.Data[k])
+	for k, v := range s.Data {
+		v, err := fc.Decode(field+".data."+k, v)
 		if err != nil {
 			return fmt.Errorf("failed to decode value for secret key '%s': %w", k, err)
 		}
-		s.Data[k] = string(bytes)
+		s.Data[k] = v
 	}
 	return nil
 }
@@ -551,17 +657,22 @@ func (ba *BasicAuth) Configured() bool {
 	return ba != nil && ba.Username != "" && ba.Password != ""
 }
 
-func (ba *BasicAuth) encode() {
-	password := base64.StdEncoding.EncodeToString([]byte(ba.Password))
+func (ba *BasicAuth) encode(fc FieldCodec, field string) error {
+	password, err := fc.Encode(field+".password", ba.Password)
+	if err != nil {
+		return fmt.Errorf("failed to encode password: %w", err)
+	}
 	ba.Password = password
+
+	return nil
 }
 
-func (ba *BasicAuth) decode() error {
-	bytes, err := base64.StdEncoding.DecodeString(ba.Password)
+func (ba *BasicAuth) decode(fc FieldCodec, field string) error {
+	password, err := fc.Decode(field+".password", ba.Password)
 	if err != nil {
 		return fmt.Errorf("failed to decode password: %w", err)
 	}
-	ba.Password = string(bytes)
+	ba.Password = password
 
 	return nil
 }
@@ -575,24 +686,94 @@ func NewValidatorFromConfig(tc *cfg.TaskConfig) (*ValidatorConfig, error) {
 	if err := c.decode(); err != nil {
 		return nil, err
 	}
+	if c.source != nil {
+		if c.loaded, err = yaml.Marshal(c); err != nil {
+			return nil, errors.Wrap(err, "failed to marshal validator config")
+		}
+	}
 	return c, nil
 }
 
+// ErrTemplated is returned when overwriting a validator configuration file which references variables
+var ErrTemplated = errors.New("it references variables, which would be replaced by their values")
+
+// Templated returns true if the validator configuration file references variables, in which case it can't be
+// overwritten. Only the fields which have changed since it was loaded are written back to it on save.
+func (c *ValidatorConfig) Templated() bool {
+	return c.source != nil
+}
+
+// Legacy returns true if the validator configuration file predates encryption, in which case its sensitive
+// values are encrypted using the default keyring when it is saved, unless encryption is explicitly removed.
+func (c *ValidatorConfig) Legacy() bool {
+	return c.legacy
+}
+
+// ReadValidatorConfig reads a validator configuration file from disk, returning both its contents and
+// its contents once the variables it references have been replaced by their values
+func ReadValidatorConfig(tc *cfg.TaskConfig) (data, rendered []byte, err error) {
+	data, err = os.ReadFile(tc.ConfigFile)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read validator config file")
+	}
+	v, err := vars.Load(tc.VarFiles, tc.Vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	rendered, err = v.Render(tc.ConfigFile, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, rendered, nil
+}
+
 // LoadValidatorConfig loads a validator configuration file from disk
 func LoadValidatorConfig(tc *cfg.TaskConfig) (*ValidatorConfig, error) {
-	bytes, err := os.ReadFile(tc.ConfigFile)
+	data, rendered, err := ReadValidatorConfig(tc)
+	if err != nil {
+		return nil, err
+	}
+	bytes, applied, err := MigrateConfig(rendered)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read validator config file")
+		return nil, err
+	}
+	if len(applied) > 0 {
+		log.Info("migrated validator config file %s to version %d; run 'validatorctl config migrate' to update it on disk", tc.ConfigFile, ConfigVersion)
+	}
+	c := &ValidatorConfig{migrated: len(applied) > 0, legacy: migratesEncryption(applied)}
+	if !slices.Equal(data, rendered) {
+		c.source = data
 	}
-	c := &ValidatorConfig{}
 	if err = yaml.Unmarshal(bytes, c); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal validator config")
 	}
 	return c, nil
 }
 
-// SaveValidatorConfig saves a validator configuration file to disk
+// SaveValidatorConfig saves a validator configuration file to disk.
+// If the file references variables, only the fields which have changed since it was loaded are written back
+// to it, leaving its variables intact. If that isn't possible, a warning is logged and the file is left as is.
 func SaveValidatorConfig(c *ValidatorConfig, tc *cfg.TaskConfig) error {
+	c.Version = ConfigVersion
+	var plain []byte
+	var changed [][]string
+	if c.Templated() {
+		var err error
+		if plain, err = yaml.Marshal(c); err != nil {
+			return errors.Wrap(err, "failed to marshal validator config")
+		}
+		if changed, err = c.changedFields(plain); err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			log.InfoCLI("\nvalidator configuration file %s is unchanged", tc.ConfigFile)
+			return nil
+		}
+		if err := c.patchable(); err != nil {
+			log.WarnCLI("validator config file references variables; changes were not saved", "file", tc.ConfigFile, "reason", err.Error())
+			return nil
+		}
+	}
 	if err := c.encode(); err != nil {
 		return err
 	}
@@ -603,9 +784,22 @@ func SaveValidatorConfig(c *ValidatorConfig, tc *cfg.TaskConfig) error {
 	if err := c.decode(); err != nil {
 		return err
 	}
+	if c.Templated() {
+		var skipped []string
+		if b, skipped, err = c.patchSource(b, changed); err != nil {
+			log.WarnCLI("validator config file references variables; changes were not saved", "file", tc.ConfigFile, "reason", err.Error())
+			return nil
+		}
+		if len(skipped) > 0 {
+			log.WarnCLI("changes to fields which reference variables or Go templates were not saved", "file", tc.ConfigFile, "fields", strings.Join(skipped, ", "))
+		}
+	}
 	if err = os.WriteFile(tc.ConfigFile, b, 0600); err != nil {
 		return errors.Wrap(err, "failed to create validator config file")
 	}
+	if c.Templated() {
+		c.source, c.loaded = b, plain
+	}
 	log.InfoCLI("\nvalidator configuration file saved: %s", tc.ConfigFile)
 	return nil
 }
@@ -28,16 +28,56 @@ type Config struct {
 // TaskConfig represents the validator task config.
 // CLI flags are bound to this struct.
 type TaskConfig struct {
-	CliVersion       string
-	ConfigFile       string
-	CustomResources  string
-	Apply            bool
-	CreateConfigOnly bool
-	DeleteCluster    bool
-	Direct           bool
-	Reconfigure      bool
-	UpdatePasswords  bool
-	Wait             bool
+	Baseline              string
+	CAFile                string
+	CliVersion            string
+	ConfigFile            string
+	CustomResources       string
+	HelmBackend           string
+	JUnitReport           string
+	KeyFile               string
+	KeyProvider           string
+	Namespace             string
+	OutputDir             string
+	OutputFormat          string
+	Profile               string
+	ProfileDir            string
+	Ref                   string
+	Rule                  string
+	SARIFReport           string
+	Selector              string
+	SigningKey            string
+	SortBy                string
+	State                 string
+	Answers               []string
+	AnswersFiles          []string
+	Exclude               []string
+	Include               []string
+	Plugins               []string
+	PublicKeys            []string
+	VarFiles              []string
+	Vars                  []string
+	Parallelism           int
+	InstallTimeout        time.Duration
+	PluginTimeout         time.Duration
+	Timeout               time.Duration
+	WaitTimeout           time.Duration
+	Apply                 bool
+	CreateConfigOnly      bool
+	DeleteCluster         bool
+	Diff                  bool
+	Direct                bool
+	DryRun                bool
+	Events                bool
+	FailFast              bool
+	InsecureSkipTLSVerify bool
+	NonInteractive        bool
+	OnlyFailures          bool
+	Reconfigure           bool
+	Redact                bool
+	Summary               bool
+	UpdatePasswords       bool
+	Wait                  bool
 }
 
 // DefaultWorkspaceLoc returns the default workspace location.
@@ -59,6 +99,16 @@ func (c *Config) initWorkspaceLoc() (err error) {
 	return
 }
 
+// ResolveWorkspace derives the workspace location, without creating it. It is used by
+// commands which only read from the workspace.
+func (c *Config) ResolveWorkspace() error {
+	if err := c.initWorkspaceLoc(); err != nil {
+		return err
+	}
+	c.RunLoc = c.WorkspaceLoc
+	return nil
+}
+
 // CreateWorkspace creates a new workspace with the specified folder and subdirs.
 func (c *Config) CreateWorkspace(folder string, subdirs []string, timestamped bool) error {
 	// Derive base dir
@@ -3,13 +3,17 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spectrocloud-labs/prompts-tui/prompts"
 
 	vtypes "github.com/validator-labs/validator/pkg/types"
 )
 
 const (
+	BaselineFile = ".validatorignore"
 	ConfigFile   = "validatorctl.yaml"
+	KeyringFile  = "keyring.yaml"
 	TimeFormat   = "20060102150405"
 	WorkspaceLoc = ".validator"
 
@@ -18,6 +22,49 @@ const (
 	KindImageTag          = "v1.30.2"
 	NoProxyPrompt         = "# Default NO_PROXY values are on the lines below.\n# Edit as you see fit (comments are ignored). The file should contain a list of NO_PROXY values, newline separated.\n# Type :wq to save and exit (if using vi).\n\n"
 
+	// Output formats
+	OutputFormatTable = "table"
+	OutputFormatJSON  = "json"
+	OutputFormatYAML  = "yaml"
+
+	// Validation result states, for filtering
+	ValidationStateFailed     = "failed"
+	ValidationStateSucceeded  = "succeeded"
+	ValidationStateInProgress = "inprogress"
+
+	// Validation result sort orders
+	SortByName     = "name"
+	SortByPlugin   = "plugin"
+	SortByState    = "state"
+	SortByFailures = "failures"
+
+	// Custom resource sources
+	CustomResourcesStdin        = "-"
+	CustomResourcesFetchTimeout = 30 * time.Second
+	OCIImageTitleAnnotation     = "org.opencontainers.image.title"
+
+	// Rules bundles
+	RulesBundleConfigMediaType = "application/vnd.validator.rules.bundle.config.v1+json"
+	RulesBundleLayerMediaType  = "application/vnd.validator.rules.layer.v1+yaml"
+	CosignPasswordEnv          = "COSIGN_PASSWORD"
+
+	// Rule profiles
+	ProfileSourceEmbedded = "embedded"
+
+	// Run history
+	HistoryLatest              = "latest"
+	HistoryPrevious            = "previous"
+	ValidationResultFileSuffix = "-validation-result.yaml"
+
+	// Plugin execution
+	DefaultPluginParallelism = 3
+	DefaultPluginTimeout     = 10 * time.Minute
+	DefaultInstallTimeout    = 10 * time.Minute
+
+	// Helm backends
+	HelmBackendSDK    = "sdk"
+	HelmBackendBinary = "binary"
+
 	LocalFilepath = "Local Filepath"
 	FileEditor    = "File Editor"
 
@@ -28,6 +75,7 @@ const (
 	ValidatorImageRegistry   = "quay.io"
 	ValidatorImageRepository = "validator-labs"
 	ValidatorHelmReleaseName = "validator-helm-release"
+	ValidatorDeployment      = "validator-controller-manager"
 
 	ValidatorPluginAws     = "validator-plugin-aws"
 	ValidatorPluginAzure   = "validator-plugin-azure"
@@ -61,8 +109,9 @@ const (
 	OciCreateNewSigSecPrompt  = "Create a new signature verification secret"
 
 	// Embed dirs
-	Kind      string = "kind"
-	Validator string = "validator"
+	Kind              string = "kind"
+	Validator         string = "validator"
+	ValidatorProfiles string = "validator/profiles"
 
 	// Regex
 	DomainRegex          = "([a-zA-Z0-9]{1,63}|[a-zA-Z0-9][a-zA-Z0-9\\-]{0,61}[a-zA-Z0-9])(\\.[a-zA-Z0-9]{1,63}|\\.[a-zA-Z0-9][a-zA-Z0-9\\-]{0,61}[a-zA-Z0-9]){0,10}\\.([a-zA-Z0-9][a-zA-Z0-9\\-]{0,61}[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]{0,30}[a-zA-Z0-9]\\.[a-zA-Z]{2,})"
@@ -88,6 +137,10 @@ var (
 	RegistryMirrorSeparator = "::"
 	FileInputs              = []string{LocalFilepath, FileEditor}
 	DNSRecordTypes          = []string{"A", "AAAA", "CNAME", "TXT", "MX", "NS", "SRV", "SSHFP"}
+	OutputFormats           = []string{OutputFormatTable, OutputFormatJSON, OutputFormatYAML}
+	ValidationStates        = []string{ValidationStateFailed, ValidationStateSucceeded, ValidationStateInProgress}
+	SortOrders              = []string{SortByName, SortByPlugin, SortByState, SortByFailures}
+	HelmBackends            = []string{HelmBackendSDK, HelmBackendBinary}
 
 	// Command dirs
 	ValidatorSubdirs = []string{"logs", "manifests"}
@@ -96,7 +149,6 @@ var (
 	ValidatorImagePath = func() string {
 		return ValidatorImageRegistry + "/" + ValidatorImageRepository
 	}
-	ValidatorWaitCmd       = []string{"wait", "--for=condition=available", "--timeout=600s", "deployment/validator-controller-manager", "-n", "validator"}
 	ValidatorBasicAuthKeys = []string{"username", "password"}
 	ValidatorSinkKeys      = map[vtypes.SinkType][]string{
 		vtypes.SinkTypeAlertmanager: {"endpoint", "insecureSkipVerify", "username", "password", "caCert"},
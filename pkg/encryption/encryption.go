@@ -0,0 +1,162 @@
+// Package encryption provides at-rest encryption for the sensitive values within a validator configuration file.
+//
+// Sensitive values are encrypted using AES-256-GCM with a random data key. The data key is in turn
+// wrapped by a KeySource, i.e., a passphrase, an age X25519 identity, or a local keyring file, and
+// recorded alongside the configuration so that it can be unwrapped when the configuration is loaded.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProviderPassphrase wraps the data key using a passphrase
+	ProviderPassphrase = "passphrase"
+	// ProviderAge wraps the data key using an age X25519 identity
+	ProviderAge = "age"
+	// ProviderKeyring wraps the data key using a key stored in a local keyring file
+	ProviderKeyring = "keyring"
+
+	// PassphraseEnv is the environment variable from which the passphrase is read, if set
+	PassphraseEnv = "VALIDATOR_CTL_PASSPHRASE"
+
+	// valuePrefix identifies encrypted values. Values without it are legacy, base64-encoded values.
+	valuePrefix = "enc:v1:"
+
+	dataKeySize = 32
+)
+
+// Providers are the supported key sources
+var Providers = []string{ProviderKeyring, ProviderPassphrase, ProviderAge}
+
+// ErrNoDataKey is returned when encrypting with a cipher that has no data key
+var ErrNoDataKey = errors.New("no data key available")
+
+// Config records how the sensitive values within a validator configuration file are encrypted
+type Config struct {
+	Provider string `yaml:"provider"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	KeyID    string `yaml:"keyId,omitempty"`
+	DataKey  string `yaml:"dataKey"`
+}
+
+// Cipher encrypts and decrypts individual configuration values
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// Legacy returns a cipher which base64-encodes and decodes values, i.e., no encryption
+func Legacy() *Cipher {
+	return &Cipher{}
+}
+
+// IsLegacy returns true if the cipher does not encrypt values
+func (c *Cipher) IsLegacy() bool {
+	return c.aead == nil
+}
+
+// NewCipher generates a new data key, wraps it using the provided key source and returns
+// a cipher for the data key along with the configuration required to unwrap it later
+func NewCipher(ks KeySource) (*Cipher, *Config, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrapped, err := ks.Wrap(dataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	c, err := newCipher(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := ks.Config()
+	cfg.DataKey = base64.StdEncoding.EncodeToString(wrapped)
+	return c, &cfg, nil
+}
+
+// Open unwraps the data key recorded in the provided configuration and returns a cipher for it
+func Open(cfg *Config) (*Cipher, error) {
+	ks, err := SourceFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(cfg.DataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data key: %w", err)
+	}
+	dataKey, err := ks.Unwrap(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return newCipher(dataKey)
+}
+
+func newCipher(dataKey []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cipher: %w", err)
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt encrypts a value. Empty values are left as-is.
+func (c *Cipher) Encrypt(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if c.IsLegacy() {
+		return base64.StdEncoding.EncodeToString([]byte(s)), nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(s), nil)
+	return valuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value. Legacy, base64-encoded values are decoded. Empty values are left as-is.
+func (c *Cipher) Decrypt(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if !IsEncrypted(s) {
+		bytes, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	}
+	if c.IsLegacy() {
+		return "", ErrNoDataKey
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, valuePrefix))
+	if err != nil {
+		return "", err
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("encrypted value is too short")
+	}
+	bytes, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// IsEncrypted returns true if the value was encrypted by a Cipher with a data key
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, valuePrefix)
+}
@@ -0,0 +1,145 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipherRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		provider string
+		keyFile  string
+		setup    func(t *testing.T)
+	}{
+		{
+			name:     "keyring",
+			provider: ProviderKeyring,
+			keyFile:  filepath.Join(dir, "keyring.yaml"),
+		},
+		{
+			name:     "age",
+			provider: ProviderAge,
+			keyFile:  filepath.Join(dir, "age.key"),
+		},
+		{
+			name:     "passphrase",
+			provider: ProviderPassphrase,
+			setup: func(t *testing.T) {
+				t.Setenv(PassphraseEnv, "correct horse battery staple")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+
+			ks, err := NewKeySource(tt.provider, tt.keyFile)
+			assert.NoError(t, err)
+
+			c, cfg, err := NewCipher(ks)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.provider, cfg.Provider)
+			assert.NotEmpty(t, cfg.DataKey)
+
+			enc, err := c.Encrypt("hunter2")
+			assert.NoError(t, err)
+			assert.True(t, IsEncrypted(enc))
+			assert.NotContains(t, enc, "hunter2")
+
+			// reopen the cipher using only the recorded configuration
+			c, err = Open(cfg)
+			assert.NoError(t, err)
+
+			dec, err := c.Decrypt(enc)
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter2", dec)
+		})
+	}
+}
+
+func TestCipherLegacy(t *testing.T) {
+	c := Legacy()
+
+	dec, err := c.Decrypt(base64.StdEncoding.EncodeToString([]byte("hunter2")))
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", dec)
+
+	enc, err := c.Encrypt("hunter2")
+	assert.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("hunter2")), enc)
+
+	_, err = c.Decrypt(valuePrefix + "AAAA")
+	assert.ErrorIs(t, err, ErrNoDataKey)
+}
+
+func TestCipherEmptyValues(t *testing.T) {
+	ks, err := NewKeySource(ProviderKeyring, filepath.Join(t.TempDir(), "keyring.yaml"))
+	assert.NoError(t, err)
+	c, _, err := NewCipher(ks)
+	assert.NoError(t, err)
+
+	enc, err := c.Encrypt("")
+	assert.NoError(t, err)
+	assert.Empty(t, enc)
+
+	dec, err := c.Decrypt("")
+	assert.NoError(t, err)
+	assert.Empty(t, dec)
+}
+
+func TestOpenWrongKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "keyring.yaml")
+
+	ks, err := NewKeySource(ProviderKeyring, keyFile)
+	assert.NoError(t, err)
+	_, cfg, err := NewCipher(ks)
+	assert.NoError(t, err)
+
+	// a second key in the same keyring must not unwrap the first data key
+	ks, err = NewKeySource(ProviderKeyring, keyFile)
+	assert.NoError(t, err)
+	_, other, err := NewCipher(ks)
+	assert.NoError(t, err)
+	assert.NotEqual(t, cfg.KeyID, other.KeyID)
+
+	cfg.KeyID = other.KeyID
+	_, err = Open(cfg)
+	assert.Error(t, err)
+
+	cfg.KeyID = "missing"
+	_, err = Open(cfg)
+	assert.ErrorContains(t, err, "key missing not found")
+}
+
+func TestOpenMissingKeyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, provider := range []string{ProviderAge, ProviderKeyring} {
+		t.Run(provider, func(t *testing.T) {
+			keyFile := filepath.Join(dir, provider)
+
+			ks, err := NewKeySource(provider, keyFile)
+			assert.NoError(t, err)
+			_, cfg, err := NewCipher(ks)
+			assert.NoError(t, err)
+			assert.FileExists(t, keyFile)
+
+			// a missing key file is reported, rather than replaced by a new key
+			assert.NoError(t, os.Remove(keyFile))
+			_, err = Open(cfg)
+			assert.ErrorContains(t, err, fmt.Sprintf("key file %s not found", keyFile))
+			assert.NoFileExists(t, keyFile)
+		})
+	}
+}
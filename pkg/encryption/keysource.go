@@ -0,0 +1,343 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"filippo.io/age"
+	"github.com/spectrocloud-labs/prompts-tui/prompts"
+	"gopkg.in/yaml.v2"
+
+	"github.com/validator-labs/validatorctl/pkg/config"
+)
+
+// KeySource wraps and unwraps the data key used to encrypt configuration values
+type KeySource interface {
+	// Wrap encrypts a data key
+	Wrap(dataKey []byte) ([]byte, error)
+	// Unwrap decrypts a data key
+	Unwrap(wrapped []byte) ([]byte, error)
+	// Config returns the parameters required to reconstruct the key source
+	Config() Config
+}
+
+// DefaultKeyringFile returns the path to the keyring file used when no keyring file is specified.
+// Configuration files encrypted using the default keyring don't record its path.
+var DefaultKeyringFile = func() (string, error) {
+	workspaceLoc, err := config.DefaultWorkspaceLoc()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(workspaceLoc, config.KeyringFile), nil
+}
+
+// NewKeySource returns a key source for the provided provider. For the keyring provider,
+// a new key is generated on the first call to Wrap, and the default keyring file is used
+// if keyFile is empty.
+func NewKeySource(provider, keyFile string) (KeySource, error) {
+	switch provider {
+	case ProviderPassphrase:
+		return &passphraseSource{}, nil
+	case ProviderAge:
+		if keyFile == "" {
+			return nil, errors.New("an age identity file must be provided")
+		}
+		return &ageSource{keyFile: keyFile}, nil
+	case ProviderKeyring:
+		return &keyringSource{keyFile: keyFile}, nil
+	}
+	return nil, fmt.Errorf("unknown encryption provider: %s. One of: %v", provider, Providers)
+}
+
+// SourceFromConfig returns the key source which wrapped the data key recorded in the provided configuration
+func SourceFromConfig(cfg *Config) (KeySource, error) {
+	ks, err := NewKeySource(cfg.Provider, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if krs, ok := ks.(*keyringSource); ok {
+		if cfg.KeyID == "" {
+			return nil, errors.New("keyring key ID is not set")
+		}
+		krs.keyID = cfg.KeyID
+	}
+	return ks, nil
+}
+
+// passphraseSource wraps the data key using an age scrypt recipient
+type passphraseSource struct {
+	passphrase string
+}
+
+func (s *passphraseSource) read(label string) (string, error) {
+	if s.passphrase != "" {
+		return s.passphrase, nil
+	}
+	s.passphrase = os.Getenv(PassphraseEnv)
+	if s.passphrase != "" {
+		return s.passphrase, nil
+	}
+	passphrase, err := prompts.ReadPassword(label, "", false, -1)
+	if err != nil {
+		return "", err
+	}
+	s.passphrase = passphrase
+	return s.passphrase, nil
+}
+
+func (s *passphraseSource) Wrap(dataKey []byte) ([]byte, error) {
+	passphrase, err := s.read("Enter a passphrase to encrypt the validator configuration with")
+	if err != nil {
+		return nil, err
+	}
+	r, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ageEncrypt(dataKey, r)
+}
+
+func (s *passphraseSource) Unwrap(wrapped []byte) ([]byte, error) {
+	passphrase, err := s.read("Enter the validator configuration passphrase")
+	if err != nil {
+		return nil, err
+	}
+	id, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ageDecrypt(wrapped, id)
+}
+
+func (s *passphraseSource) Config() Config {
+	return Config{Provider: ProviderPassphrase}
+}
+
+// ageSource wraps the data key using the X25519 identities in an age identity file
+type ageSource struct {
+	keyFile string
+}
+
+// identities parses the identity file. If it does not exist and generate is true, a new identity is generated.
+func (s *ageSource) identities(generate bool) ([]age.Identity, error) {
+	f, err := os.Open(s.keyFile) // #nosec
+	if errors.Is(err, os.ErrNotExist) {
+		if !generate {
+			return nil, fmt.Errorf("key file %s not found", s.keyFile)
+		}
+		id, err := age.GenerateX25519Identity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate age identity: %w", err)
+		}
+		data := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n", time.Now().Format(time.RFC3339), id.Recipient(), id)
+		if err := writeKeyFile(s.keyFile, []byte(data)); err != nil {
+			return nil, err
+		}
+		return []age.Identity{id}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open age identity file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	ids, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age identity file %s: %w", s.keyFile, err)
+	}
+	return ids, nil
+}
+
+func (s *ageSource) Wrap(dataKey []byte) ([]byte, error) {
+	ids, err := s.identities(true)
+	if err != nil {
+		return nil, err
+	}
+	recipients := make([]age.Recipient, 0, len(ids))
+	for _, id := range ids {
+		if x, ok := id.(*age.X25519Identity); ok {
+			recipients = append(recipients, x.Recipient())
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no X25519 identities found in %s", s.keyFile)
+	}
+	return ageEncrypt(dataKey, recipients...)
+}
+
+func (s *ageSource) Unwrap(wrapped []byte) ([]byte, error) {
+	ids, err := s.identities(false)
+	if err != nil {
+		return nil, err
+	}
+	return ageDecrypt(wrapped, ids...)
+}
+
+func (s *ageSource) Config() Config {
+	return Config{Provider: ProviderAge, KeyFile: s.keyFile}
+}
+
+// keyring is a local file containing named AES-256 keys
+type keyring struct {
+	Keys map[string]string `yaml:"keys"`
+}
+
+// keyringSource wraps the data key using a key stored in a local keyring file.
+// If keyFile is empty, the default keyring file is used.
+type keyringSource struct {
+	keyFile string
+	keyID   string
+}
+
+// path returns the path to the keyring file
+func (s *keyringSource) path() (string, error) {
+	if s.keyFile != "" {
+		return s.keyFile, nil
+	}
+	return DefaultKeyringFile()
+}
+
+// load parses the keyring file. If it does not exist and create is true, an empty keyring is returned.
+func (s *keyringSource) load(path string, create bool) (*keyring, error) {
+	kr := &keyring{Keys: make(map[string]string)}
+	data, err := os.ReadFile(path) // #nosec
+	if errors.Is(err, os.ErrNotExist) {
+		if !create {
+			return nil, fmt.Errorf("key file %s not found", path)
+		}
+		return kr, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read keyring file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, kr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal keyring file %s: %w", path, err)
+	}
+	if kr.Keys == nil {
+		kr.Keys = make(map[string]string)
+	}
+	return kr, nil
+}
+
+// key returns the key with the source's key ID, generating and persisting a new key if the ID is unset
+func (s *keyringSource) key() ([]byte, error) {
+	path, err := s.path()
+	if err != nil {
+		return nil, err
+	}
+	kr, err := s.load(path, s.keyID == "")
+	if err != nil {
+		return nil, err
+	}
+	if s.keyID == "" {
+		key := make([]byte, dataKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate keyring key: %w", err)
+		}
+		id := make([]byte, 4)
+		if _, err := rand.Read(id); err != nil {
+			return nil, fmt.Errorf("failed to generate keyring key ID: %w", err)
+		}
+		s.keyID = fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102150405"), hex.EncodeToString(id))
+		kr.Keys[s.keyID] = base64.StdEncoding.EncodeToString(key)
+
+		data, err := yaml.Marshal(kr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal keyring: %w", err)
+		}
+		if err := writeKeyFile(path, data); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+
+	encoded, ok := kr.Keys[s.keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in keyring %s", s.keyID, path)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key %s: %w", s.keyID, err)
+	}
+	return key, nil
+}
+
+func (s *keyringSource) aead() (cipher.AEAD, error) {
+	key, err := s.key()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *keyringSource) Wrap(dataKey []byte) ([]byte, error) {
+	aead, err := s.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+func (s *keyringSource) Unwrap(wrapped []byte) ([]byte, error) {
+	aead, err := s.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := aead.NonceSize()
+	if len(wrapped) < nonceSize {
+		return nil, errors.New("wrapped data key is too short")
+	}
+	return aead.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], nil)
+}
+
+func (s *keyringSource) Config() Config {
+	return Config{Provider: ProviderKeyring, KeyFile: s.keyFile, KeyID: s.keyID}
+}
+
+func ageEncrypt(data []byte, recipients ...age.Recipient) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := age.Encrypt(buf, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func ageDecrypt(data []byte, identities ...age.Identity) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func writeKeyFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create key file directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write key file %s: %w", path, err)
+	}
+	return nil
+}
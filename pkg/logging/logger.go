@@ -24,6 +24,9 @@ var (
 	log    *logrus.Logger
 	cliLog = pterm.DefaultLogger
 
+	// console is the destination for all console output.
+	console io.Writer = os.Stdout
+
 	// Newline determines whether a newline character is appended to the end of each log message.
 	Newline = true
 )
@@ -56,6 +59,13 @@ func SetOutput(runLoc string) {
 	log.SetOutput(f)
 }
 
+// SetConsoleOutput sets the output location for console messages.
+// Defaults to os.Stdout.
+func SetConsoleOutput(w io.Writer) {
+	console = w
+	cliLog.Writer = w
+}
+
 // logContext recovers the original caller context of each log message
 func logContext() *logrus.Entry {
 	if pc, file, line, ok := runtime.Caller(2); ok {
@@ -114,6 +124,13 @@ func ErrorCLI(msg string, args ...any) {
 	entry.Info(msg, args)
 }
 
+// WarnCLI prints a warning message to the terminal & creates a log entry
+func WarnCLI(msg string, args ...any) {
+	entry := logContext()
+	ptermLog(cliLog.Warn, entry, msg, args...)
+	entry.Warn(msg, args)
+}
+
 func ptermLog(f func(string, ...[]pterm.LoggerArgument), entry *logrus.Entry, msg string, args ...any) {
 	// pterm.Logger does not support an odd number of arguments
 	numArgs := len(args)
@@ -128,9 +145,9 @@ func ptermLog(f func(string, ...[]pterm.LoggerArgument), entry *logrus.Entry, ms
 
 func printToConsole(format string, v ...interface{}) {
 	s := fmt.Sprintf(format, v...)
-	fmt.Fprint(os.Stdout, strings.TrimSuffix(s, "\n")) // nolint:errcheck
+	fmt.Fprint(console, strings.TrimSuffix(s, "\n")) // nolint:errcheck
 	if Newline {
-		fmt.Fprintf(os.Stdout, "\n") // nolint:errcheck
+		fmt.Fprintf(console, "\n") // nolint:errcheck
 	}
 }
 
@@ -141,14 +158,15 @@ func Header(s string) {
 
 // HeaderCustom prints a header to the console with custom colors
 func HeaderCustom(s string, bgColor, textColor pterm.Color) {
-	fmt.Fprintf(os.Stdout, "\n") // nolint:errcheck
+	fmt.Fprintf(console, "\n") // nolint:errcheck
 	pterm.DefaultHeader.
 		WithMargin(15).
 		WithBackgroundStyle(pterm.NewStyle(bgColor)).
 		WithTextStyle(pterm.NewStyle(textColor)).
 		WithFullWidth(true).
+		WithWriter(console).
 		Println(s)
-	fmt.Fprintf(os.Stdout, "\n") // nolint:errcheck
+	fmt.Fprintf(console, "\n") // nolint:errcheck
 }
 
 // Out returns the global logger's io.Writer
@@ -0,0 +1,84 @@
+package plugins
+
+import (
+	"fmt"
+	"reflect"
+
+	vplugins "github.com/validator-labs/validator/pkg/plugins"
+	"github.com/validator-labs/validator/pkg/validationrule"
+)
+
+var ruleType = reflect.TypeOf((*validationrule.Interface)(nil)).Elem()
+
+// MergeSpec merges the rules in src into dst. Both must be specs for the same plugin.
+//
+// Rules are matched by name: a rule in src replaces the rule in dst with the same name,
+// otherwise it is appended. Any other fields, e.g., auth, are only set in dst if unset.
+// The names of the added and replaced rules are returned.
+func MergeSpec(dst, src vplugins.PluginSpec) (added, replaced []string, err error) {
+	dv := reflect.ValueOf(dst)
+	sv := reflect.ValueOf(src)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || sv.Kind() != reflect.Pointer || sv.IsNil() {
+		return nil, nil, fmt.Errorf("cannot merge %T into %T: specs must be non-nil pointers", src, dst)
+	}
+	if dv.Type() != sv.Type() {
+		return nil, nil, fmt.Errorf("cannot merge %T into %T: spec types differ", src, dst)
+	}
+	dv, sv = dv.Elem(), sv.Elem()
+
+	for i := 0; i < dv.NumField(); i++ {
+		df, sf := dv.Field(i), sv.Field(i)
+		if !df.CanSet() || sf.IsZero() {
+			continue
+		}
+		if df.Kind() == reflect.Slice && reflect.PointerTo(df.Type().Elem()).Implements(ruleType) {
+			for j := 0; j < sf.Len(); j++ {
+				rule := sf.Index(j)
+				name := rule.Addr().Interface().(validationrule.Interface).Name()
+				if name == "" {
+					return nil, nil, fmt.Errorf("rule %d in %s has no name", j, dv.Type().Field(i).Name)
+				}
+				if k := indexOfRule(df, name); k >= 0 {
+					df.Index(k).Set(rule)
+					replaced = append(replaced, name)
+					continue
+				}
+				df.Set(reflect.Append(df, rule))
+				added = append(added, name)
+			}
+			continue
+		}
+		if df.IsZero() {
+			df.Set(sf)
+		}
+	}
+	return added, replaced, nil
+}
+
+// indexOfRule returns the index of the rule with the provided name in a slice of rules, or -1
+func indexOfRule(rules reflect.Value, name string) int {
+	for i := 0; i < rules.Len(); i++ {
+		if rules.Index(i).Addr().Interface().(validationrule.Interface).Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Rules returns a copy of spec which only contains its rules. Any other fields, e.g., auth, are unset.
+func Rules(spec vplugins.PluginSpec) (vplugins.PluginSpec, error) {
+	sv := reflect.ValueOf(spec)
+	if sv.Kind() != reflect.Pointer || sv.IsNil() {
+		return nil, fmt.Errorf("cannot copy the rules of %T: spec must be a non-nil pointer", spec)
+	}
+	sv = sv.Elem()
+	rv := reflect.New(sv.Type())
+
+	for i := 0; i < sv.NumField(); i++ {
+		sf, rf := sv.Field(i), rv.Elem().Field(i)
+		if rf.CanSet() && sf.Kind() == reflect.Slice && reflect.PointerTo(sf.Type().Elem()).Implements(ruleType) {
+			rf.Set(sf)
+		}
+	}
+	return rv.Interface().(vplugins.PluginSpec), nil
+}
@@ -0,0 +1,169 @@
+// Package plugins provides a registry of the validator plugins supported by the CLI.
+//
+// Each plugin is described by a Plugin implementation, which is registered via Register,
+// typically from an init function. All plugin-specific behavior, i.e., configuration,
+// Helm chart installation, rule application and direct rule evaluation, is driven by
+// iterating over the registry.
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/kubernetes"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vplugins "github.com/validator-labs/validator/pkg/plugins"
+	"github.com/validator-labs/validator/pkg/types"
+	vres "github.com/validator-labs/validator/pkg/validationresult"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+)
+
+// ValidateFunc directly evaluates a plugin's rules
+type ValidateFunc func(context.Context, logr.Logger) types.ValidationResponse
+
+// Plugin describes a validator plugin
+type Plugin interface {
+	components.Plugin
+
+	// Code returns the plugin code, e.g., AWS
+	Code() string
+	// Kind returns the kind of the plugin's custom resource, e.g., AwsValidator
+	Kind() string
+	// Chart returns the name of the plugin's Helm chart, e.g., validator-plugin-aws
+	Chart() string
+	// ValuesTemplate returns the name of the template used to render the plugin's Helm values
+	ValuesTemplate() string
+	// RulesTemplate returns the name of the template used to render the plugin's custom resource
+	RulesTemplate() string
+	// TemplateKey returns the key under which the plugin's configuration is passed to the base validator values template
+	TemplateKey() string
+	// Description returns a description of the plugin's capabilities
+	Description() string
+
+	// Enabled returns true if the plugin is enabled in the validator configuration
+	Enabled(vc *components.ValidatorConfig) bool
+	// SetEnabled enables or disables the plugin in the validator configuration
+	SetEnabled(vc *components.ValidatorConfig, enabled bool)
+	// Config returns the plugin's section of the validator configuration
+	Config(vc *components.ValidatorConfig) any
+	// Release returns the plugin's Helm release
+	Release(vc *components.ValidatorConfig) *vapi.HelmRelease
+	// Spec returns the plugin's rules
+	Spec(vc *components.ValidatorConfig) vplugins.PluginSpec
+	// NewSpec returns an empty spec for the plugin's custom resource
+	NewSpec() vplugins.PluginSpec
+	// NewValidator returns a validator for the provided spec and a function which directly evaluates its rules
+	NewValidator(spec vplugins.PluginSpec) (vres.Validator, ValidateFunc)
+
+	// ReadConfig prompts the user to configure the plugin's installation
+	ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error
+	// ReadRules prompts the user to configure the plugin's rules
+	ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error
+	// ReadCredentials prompts the user to update the plugin's credentials
+	ReadCredentials(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Plugin)
+)
+
+// Register adds a plugin to the registry. It panics if a plugin with the same code or kind is already registered.
+func Register(p Plugin) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := registry[p.Code()]; ok {
+		panic(fmt.Sprintf("plugin %s is already registered", p.Code()))
+	}
+	for _, rp := range registry {
+		if rp.Kind() == p.Kind() {
+			panic(fmt.Sprintf("plugin kind %s is already registered", p.Kind()))
+		}
+	}
+	registry[p.Code()] = p
+	components.RegisterPlugin(p)
+}
+
+// All returns all registered plugins, sorted by plugin code
+func All() []Plugin {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	ps := make([]Plugin, 0, len(registry))
+	for _, p := range registry {
+		ps = append(ps, p)
+	}
+	slices.SortFunc(ps, func(a, b Plugin) int {
+		return strings.Compare(a.Code(), b.Code())
+	})
+	return ps
+}
+
+// Codes returns the codes of all registered plugins, sorted
+func Codes() []string {
+	ps := All()
+	codes := make([]string, 0, len(ps))
+	for _, p := range ps {
+		codes = append(codes, p.Code())
+	}
+	return codes
+}
+
+// Get returns the plugin with the provided code
+func Get(code string) (Plugin, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	p, ok := registry[code]
+	return p, ok
+}
+
+// GetByKind returns the plugin whose custom resource is of the provided kind
+func GetByKind(kind string) (Plugin, bool) {
+	for _, p := range All() {
+		if p.Kind() == kind {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Enabled returns all plugins enabled in the validator configuration
+func Enabled(vc *components.ValidatorConfig) []Plugin {
+	ps := make([]Plugin, 0)
+	for _, p := range All() {
+		if p.Enabled(vc) {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// EnabledSpecs returns the rules for all plugins enabled in the validator configuration
+func EnabledSpecs(vc *components.ValidatorConfig) []vplugins.PluginSpec {
+	specs := make([]vplugins.PluginSpec, 0)
+	for _, p := range Enabled(vc) {
+		specs = append(specs, p.Spec(vc))
+	}
+	return specs
+}
+
+// EnabledPluginsHaveRules returns true if all enabled plugins have at least one rule configured.
+// If not, the codes of the enabled plugins without rules are returned.
+func EnabledPluginsHaveRules(vc *components.ValidatorConfig) (bool, []string) {
+	invalidPlugins := []string{}
+	for _, p := range Enabled(vc) {
+		if p.Spec(vc).ResultCount() == 0 {
+			invalidPlugins = append(invalidPlugins, p.Code())
+		}
+	}
+	return len(invalidPlugins) == 0, invalidPlugins
+}
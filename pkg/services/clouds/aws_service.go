@@ -17,6 +17,7 @@ import (
 	"github.com/validator-labs/validator-plugin-aws/pkg/aws"
 	"github.com/validator-labs/validatorctl/pkg/components"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 const (
@@ -48,6 +49,10 @@ func ReadAwsProfile(c *components.AWSPluginConfig) (bool, error) {
 		}
 		return true, nil
 	}
+	if answers.NonInteractive() {
+		// profiles can't be selected non-interactively, so try the default auth keychain
+		return true, nil
+	}
 	if os.Getenv("IS_TEST") == "true" {
 		// TUI values for integration tests assume no credentials file exists, so exit early
 		return false, nil
@@ -103,7 +108,7 @@ func ReadAwsSTSProfile(c *components.AWSPluginConfig) error {
 		}
 		return nil
 	}
-	if os.Getenv("IS_TEST") == "true" {
+	if os.Getenv("IS_TEST") == "true" || answers.NonInteractive() {
 		// TUI values for integration tests assume no config file exists, so exit early
 		return nil
 	}
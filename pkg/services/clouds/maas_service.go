@@ -8,6 +8,7 @@ import (
 	"github.com/canonical/gomaasclient/entity"
 	"github.com/spectrocloud-labs/prompts-tui/prompts"
 	"github.com/validator-labs/validatorctl/pkg/components"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 var (
@@ -16,10 +17,11 @@ var (
 	host          = "https://maas.io/MAAS"
 )
 
-// ReadMaasClientProps gathers and validates MAAS client credentials
-func ReadMaasClientProps(c *components.MaasPluginConfig) error {
+// ReadMaasClientProps gathers and validates MAAS client credentials. The configuration path of
+// the plugin's validator spec is key, e.g., plugins.maas.validator.
+func ReadMaasClientProps(key string, c *components.MaasPluginConfig) error {
 	var err error
-	c.Validator.Auth.APIToken, err = prompts.ReadPassword("MAAS API token", c.Validator.Auth.APIToken, false, -1)
+	c.Validator.Auth.APIToken, err = prompts.ReadPassword(answers.For(key+".auth.apiToken", "MAAS API token"), c.Validator.Auth.APIToken, false, -1)
 	if err != nil {
 		return fmt.Errorf("failed to prompt for password for MAAS API token: %w", err)
 	}
@@ -27,7 +29,7 @@ func ReadMaasClientProps(c *components.MaasPluginConfig) error {
 	if c.Validator.Host != "" {
 		host = c.Validator.Host
 	}
-	c.Validator.Host, err = prompts.ReadText("MAAS Domain", host, false, -1)
+	c.Validator.Host, err = prompts.ReadText(answers.For(key+".host", "MAAS Domain"), host, false, -1)
 	if err != nil {
 		return err
 	}
@@ -38,7 +40,7 @@ func ReadMaasClientProps(c *components.MaasPluginConfig) error {
 			return err
 		}
 		if val == "Continue" {
-			return ReadMaasClientProps(c)
+			return ReadMaasClientProps(key, c)
 		}
 		os.Exit(0)
 	}
@@ -63,6 +65,9 @@ func validateMaasClient(maasURL, maasToken string) error {
 }
 
 func handleMaasClientError(err error) (string, error) {
+	if answers.NonInteractive() {
+		return "", fmt.Errorf("MAAS credentials validation failed: %w", err)
+	}
 	errMsg := fmt.Sprintf("MAAS credentials validation failed with error: %v. Please update your credentials.", err)
 	val, err := prompts.Select(errMsg, []string{"Continue", "Exit"})
 	if err != nil {
@@ -14,21 +14,23 @@ import (
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
 
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 	string_utils "github.com/validator-labs/validatorctl/pkg/utils/string"
 )
 
 // GetVSphereDriver enables monkey-patching the vSphere driver for integration tests
 var GetVSphereDriver = getVSphereDriver
 
-// ReadVsphereAccountProps prompts the user to configure vSphere account properties
-func ReadVsphereAccountProps(account *vcenter.Account) error {
+// ReadVsphereAccountProps prompts the user to configure vSphere account properties. The configuration
+// path of the account is key, e.g., plugins.vsphere.validator.auth.account.
+func ReadVsphereAccountProps(key string, account *vcenter.Account) error {
 	vcenterServer := account.Host
 	username := account.Username
 	password := account.Password
 
 	// Identity Endpoint
 	vcenterServer, err := prompts.ReadDomainsOrIPs(
-		"vSphere Endpoint", vcenterServer, "vSphere Endpoint should be a valid FQDN or IP", false, 1,
+		answers.For(key+".host", "vSphere Endpoint"), vcenterServer, "vSphere Endpoint should be a valid FQDN or IP", false, 1,
 	)
 	if err != nil {
 		return err
@@ -38,7 +40,7 @@ func ReadVsphereAccountProps(account *vcenter.Account) error {
 
 	// Username
 	username, err = prompts.ReadTextRegex(
-		"vSphere Username (with domain)", username, "Invalid username", cfg.VSphereUsernameRegex,
+		answers.For(key+".username", "vSphere Username (with domain)"), username, "Invalid username", cfg.VSphereUsernameRegex,
 	)
 	if err != nil {
 		return err
@@ -46,14 +48,14 @@ func ReadVsphereAccountProps(account *vcenter.Account) error {
 	account.Username = username
 
 	// Password
-	password, err = prompts.ReadPassword("vSphere Password", password, false, -1)
+	password, err = prompts.ReadPassword(answers.For(key+".password", "vSphere Password"), password, false, -1)
 	if err != nil {
 		return err
 	}
 	account.Password = password
 
 	// Allow Insecure Connection
-	insecure, err := prompts.ReadBool("Allow Insecure Connection (Bypass x509 Verification)", true)
+	insecure, err := prompts.ReadBool(answers.For(key+".insecure", "Allow Insecure Connection (Bypass x509 Verification)"), true)
 	if err != nil {
 		return err
 	}
@@ -66,7 +68,7 @@ func ReadVsphereAccountProps(account *vcenter.Account) error {
 			return err
 		}
 		if val == "Continue" {
-			return ReadVsphereAccountProps(account)
+			return ReadVsphereAccountProps(key, account)
 		}
 
 		os.Exit(0)
@@ -107,6 +109,9 @@ func ValidateCloudAccountVsphere(account vcenter.Account) error {
 }
 
 func handleCloudAccountError(err error) (string, error) {
+	if answers.NonInteractive() {
+		return "", fmt.Errorf("cloud account validation failed: %w", err)
+	}
 	errMsg := fmt.Sprintf("Cloud Account validation failed with error: %v. Please update account properties.", err)
 	val, err := prompts.Select(errMsg, []string{"Continue", "Exit"})
 	if err != nil {
@@ -15,40 +15,54 @@ import (
 	"github.com/validator-labs/validatorctl/pkg/components"
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 	exec_utils "github.com/validator-labs/validatorctl/pkg/utils/exec"
 	"github.com/validator-labs/validatorctl/pkg/utils/network"
 )
 
+const (
+	// proxyEnvKey is the configuration path of the proxy settings configured by ReadProxyProps
+	proxyEnvKey = "proxyConfig.env"
+	// registryKey is the configuration path of the registry settings configured by ReadHaulerProps and ReadRegistryProps
+	registryKey = "registryConfig.registry"
+)
+
 // ReadProxyProps prompts the user to configure proxy settings.
 func ReadProxyProps(e *components.Env) error {
 	var err error
 
 	// https_proxy
-	e.HTTPSProxy, err = prompts.ReadURL("HTTPS Proxy", e.HTTPSProxy, "HTTPS Proxy should be a valid URL", true)
+	e.HTTPSProxy, err = prompts.ReadURL(answers.For(proxyEnvKey+".httpsProxy", "HTTPS Proxy"), e.HTTPSProxy, "HTTPS Proxy should be a valid URL", true)
 	if err != nil {
 		return err
 	}
 
 	// http_proxy
-	e.HTTPProxy, err = prompts.ReadURL("HTTP Proxy", e.HTTPProxy, "HTTP Proxy should be a valid URL", true)
+	e.HTTPProxy, err = prompts.ReadURL(answers.For(proxyEnvKey+".httpProxy", "HTTP Proxy"), e.HTTPProxy, "HTTP Proxy should be a valid URL", true)
 	if err != nil {
 		return err
 	}
 
 	if e.HTTPProxy != "" || e.HTTPSProxy != "" {
 		// no_proxy
-		log.InfoCLI("Configure NO_PROXY")
-		time.Sleep(2 * time.Second)
-		e.NoProxy, err = prompts.EditFileValidatedByLine(cfg.NoProxyPrompt, e.NoProxy, ",", prompts.ValidateNoProxy, -1)
+		skip, err := answers.Skip(proxyEnvKey+".noProxy", "Configure NO_PROXY")
 		if err != nil {
 			return err
 		}
+		if !skip {
+			log.InfoCLI("Configure NO_PROXY")
+			time.Sleep(2 * time.Second)
+			e.NoProxy, err = prompts.EditFileValidatedByLine(cfg.NoProxyPrompt, e.NoProxy, ",", prompts.ValidateNoProxy, -1)
+			if err != nil {
+				return err
+			}
+		}
 
 		// Proxy CA certificate
 		if e.ProxyCACert == nil {
 			e.ProxyCACert = &components.CACert{}
 		}
-		caCertPath, caCertName, caCertData, err := prompts.ReadCACert("Proxy CA certificate filepath", e.ProxyCACert.Path, "")
+		caCertPath, caCertName, caCertData, err := prompts.ReadCACert(answers.For(proxyEnvKey+".proxyCaCert.path", "Proxy CA certificate filepath"), e.ProxyCACert.Path, "")
 		if err != nil {
 			return err
 		}
@@ -68,14 +82,14 @@ func ReadHaulerProps(h *components.Registry, e *components.Env) error {
 	if h.Host == "" {
 		h.Host = network.GetDefaultHostAddress()
 	}
-	h.Host, err = prompts.ReadText("Hauler Host (IPv4 address of primary NIC)", h.Host, false, -1)
+	h.Host, err = prompts.ReadText(answers.For(registryKey+".host", "Hauler Host (IPv4 address of primary NIC)"), h.Host, false, -1)
 	if err != nil {
 		return err
 	}
 	if h.Port == 0 {
 		h.Port = 5000
 	}
-	h.Port, err = prompts.ReadInt("Hauler Port", fmt.Sprintf("%d", h.Port), 1024, 65535)
+	h.Port, err = prompts.ReadInt(answers.For(registryKey+".port", "Hauler Port"), fmt.Sprintf("%d", h.Port), 1024, 65535)
 	if err != nil {
 		return err
 	}
@@ -87,7 +101,7 @@ func ReadHaulerProps(h *components.Registry, e *components.Env) error {
 
 	// ca cert
 	if e.ProxyCACert.Path != "" {
-		h.ReuseProxyCACert, err = prompts.ReadBool("Reuse proxy CA cert for Hauler registry", true)
+		h.ReuseProxyCACert, err = prompts.ReadBool(answers.For(registryKey+".reuseProxyCACert", "Reuse proxy CA cert for Hauler registry"), true)
 		if err != nil {
 			return err
 		}
@@ -99,7 +113,7 @@ func ReadHaulerProps(h *components.Registry, e *components.Env) error {
 		h.CACert = e.ProxyCACert
 		return nil
 	}
-	caCertPath, caCertName, caCertData, err := prompts.ReadCACert("Hauler CA certificate filepath", h.CACert.Path, "")
+	caCertPath, caCertName, caCertData, err := prompts.ReadCACert(answers.For(registryKey+".caCert.path", "Hauler CA certificate filepath"), h.CACert.Path, "")
 	if err != nil {
 		return err
 	}
@@ -117,29 +131,35 @@ func ReadHaulerProps(h *components.Registry, e *components.Env) error {
 
 // ReadRegistryProps prompts the user to configure custom private registry settings.
 func ReadRegistryProps(r *components.Registry, e *components.Env) error {
-	ociURL, err := prompts.ReadURL(
-		"Registry Endpoint", "", "Invalid Registry Endpoint. A scheme is required, e.g.: 'https://'.", false,
-	)
+	skip, err := answers.Skip(registryKey+".host", "Registry Endpoint")
 	if err != nil {
 		return err
 	}
+	if !skip {
+		ociURL, err := prompts.ReadURL(
+			"Registry Endpoint", "", "Invalid Registry Endpoint. A scheme is required, e.g.: 'https://'.", false,
+		)
+		if err != nil {
+			return err
+		}
 
-	parsedURL, err := url.Parse(ociURL)
-	if err != nil {
-		return err
-	}
-	r.Host = parsedURL.Hostname()
-	port := parsedURL.Port()
-	if port == "" {
-		r.Port = components.UnspecifiedPort
-	} else {
-		r.Port, err = strconv.Atoi(port)
+		parsedURL, err := url.Parse(ociURL)
 		if err != nil {
 			return err
 		}
+		r.Host = parsedURL.Hostname()
+		port := parsedURL.Port()
+		if port == "" {
+			r.Port = components.UnspecifiedPort
+		} else {
+			r.Port, err = strconv.Atoi(port)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	baseContentPath, err := prompts.ReadText("Registry Base Content Path", "", true, -1)
+	baseContentPath, err := prompts.ReadText(answers.For(registryKey+".baseContentPath", "Registry Base Content Path"), "", true, -1)
 	if err != nil {
 		return err
 	}
@@ -152,7 +172,7 @@ func ReadRegistryProps(r *components.Registry, e *components.Env) error {
 
 	// ca cert
 	if e.ProxyCACert.Path != "" {
-		r.ReuseProxyCACert, err = prompts.ReadBool("Reuse proxy CA cert for OCI registry", true)
+		r.ReuseProxyCACert, err = prompts.ReadBool(answers.For(registryKey+".reuseProxyCACert", "Reuse proxy CA cert for OCI registry"), true)
 		if err != nil {
 			return err
 		}
@@ -163,7 +183,7 @@ func ReadRegistryProps(r *components.Registry, e *components.Env) error {
 	if r.ReuseProxyCACert {
 		r.CACert = e.ProxyCACert
 	} else {
-		caCertPath, caCertName, caCertData, err := prompts.ReadCACert("OCI registry CA certificate filepath", r.CACert.Path, "")
+		caCertPath, caCertName, caCertData, err := prompts.ReadCACert(answers.For(registryKey+".caCert.path", "OCI registry CA certificate filepath"), r.CACert.Path, "")
 		if err != nil {
 			return err
 		}
@@ -186,14 +206,15 @@ func readAuthTLSProps(r *components.Registry) error {
 		r.BasicAuth = &components.BasicAuth{}
 	}
 	r.BasicAuth.Username, r.BasicAuth.Password, err = prompts.ReadBasicCreds(
-		"Username", "Password", r.BasicAuth.Username, r.BasicAuth.Password, true, false,
+		answers.For(registryKey+".basicAuth.username", "Username"), answers.For(registryKey+".basicAuth.password", "Password"),
+		r.BasicAuth.Username, r.BasicAuth.Password, true, false,
 	)
 	if err != nil {
 		return err
 	}
 
 	// tls verification
-	r.InsecureSkipTLSVerify, err = prompts.ReadBool("Allow Insecure Connection (Bypass x509 Verification)", true)
+	r.InsecureSkipTLSVerify, err = prompts.ReadBool(answers.For(registryKey+".insecureSkipTLSVerify", "Allow Insecure Connection (Bypass x509 Verification)"), true)
 	if err != nil {
 		return err
 	}
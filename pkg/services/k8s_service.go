@@ -14,6 +14,7 @@ import (
 	prompt_utils "github.com/spectrocloud-labs/prompts-tui/prompts"
 
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 	kube_utils "github.com/validator-labs/validatorctl/pkg/utils/kube"
 )
 
@@ -64,9 +65,10 @@ func GetSecretsWithRegexKeys(k8sClient kubernetes.Interface, namespace string, k
 	return secrets, nil
 }
 
-// ReadSecret prompts the user to select a secret from the given namespace that contains the specified keys
-func ReadSecret(k8sClient kubernetes.Interface, namespace string, optional bool, keys []string) (*corev1.Secret, error) {
-	name, err := prompt_utils.ReadK8sName("Secret Name", "", optional)
+// ReadSecret prompts the user to select a secret from the given namespace that contains the specified keys.
+// The secret's name is answered using the answer for the configuration path at key.
+func ReadSecret(k8sClient kubernetes.Interface, namespace, key string, optional bool, keys []string) (*corev1.Secret, error) {
+	name, err := prompt_utils.ReadK8sName(answers.For(key, "Secret Name"), "", optional)
 	if err != nil {
 		return nil, err
 	}
@@ -74,29 +76,39 @@ func ReadSecret(k8sClient kubernetes.Interface, namespace string, optional bool,
 	if name != "" {
 		secret, err = k8sClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 		if err != nil {
+			if answers.NonInteractive() {
+				return nil, fmt.Errorf("failed to get secret %s in the %s namespace: %w", name, namespace, err)
+			}
 			log.InfoCLI("Secret %s does not exist in the %s namespace. Please try again.", name, namespace)
-			return ReadSecret(k8sClient, namespace, optional, keys)
+			return ReadSecret(k8sClient, namespace, key, optional, keys)
 		}
 		for _, k := range keys {
 			if _, ok := secret.Data[k]; !ok {
+				if answers.NonInteractive() {
+					return nil, fmt.Errorf("secret %s does not contain required key %s", name, k)
+				}
 				log.InfoCLI("Secret %s does not contain required key %s. Please try again.", name, k)
-				return ReadSecret(k8sClient, namespace, optional, keys)
+				return ReadSecret(k8sClient, namespace, key, optional, keys)
 			}
 		}
 	}
 	return secret, nil
 }
 
-// ReadServiceAccount prompts the user to select a service account from the given namespace
-func ReadServiceAccount(k8sClient kubernetes.Interface, namespace string) (string, error) {
-	serviceAccount, err := prompt_utils.ReadK8sName("ServiceAccount Name", "", true)
+// ReadServiceAccount prompts the user to select a service account from the given namespace.
+// The service account's name is answered using the answer for the configuration path at key.
+func ReadServiceAccount(k8sClient kubernetes.Interface, namespace, key string) (string, error) {
+	serviceAccount, err := prompt_utils.ReadK8sName(answers.For(key, "ServiceAccount Name"), "", true)
 	if err != nil {
 		return "", err
 	}
 	if serviceAccount != "" {
 		if _, err := k8sClient.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), serviceAccount, metav1.GetOptions{}); err != nil {
+			if answers.NonInteractive() {
+				return "", fmt.Errorf("failed to get ServiceAccount %s in the %s namespace: %w", serviceAccount, namespace, err)
+			}
 			log.InfoCLI("ServiceAccount %s does not exist in the %s namespace. Please try again.", serviceAccount, namespace)
-			return ReadServiceAccount(k8sClient, namespace)
+			return ReadServiceAccount(k8sClient, namespace, key)
 		}
 	}
 	return serviceAccount, nil
@@ -117,7 +129,7 @@ func ReadKubeconfig() (kubernetes.Interface, string, error) {
 		} else {
 			log.Warn("unable to determine user home directory path: %v", err)
 		}
-		kubeconfigPath, err = prompt_utils.ReadFilePath("KUBECONFIG path", defaultKubeConfigPath, "Invalid KUBECONFIG path", false)
+		kubeconfigPath, err = prompt_utils.ReadFilePath(answers.For("kubeconfig", "KUBECONFIG path"), defaultKubeConfigPath, "Invalid KUBECONFIG path", false)
 		if err != nil {
 			return nil, "", err
 		}
@@ -15,10 +15,13 @@ import (
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
 	"github.com/validator-labs/validatorctl/pkg/services/clouds"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 
 	"github.com/spectrocloud-labs/prompts-tui/prompts"
 )
 
+const stsAuthKey = awsPath + ".validator.auth.stsAuth"
+
 var (
 	region             = "us-east-1"
 	stsDurationSeconds = "3600"
@@ -26,10 +29,10 @@ var (
 )
 
 func readAwsPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClient kubernetes.Interface) error {
-	c := vc.AWSPlugin
+	c := awsConfig(vc)
 
 	if !tc.Direct {
-		if err := readHelmRelease(cfg.ValidatorPluginAws, vc, c.Release); err != nil {
+		if err := readHelmRelease(cfg.ValidatorPluginAws, awsPath+".helmRelease", vc, c.Release); err != nil {
 			return fmt.Errorf("failed to read Helm release: %w", err)
 		}
 	}
@@ -43,12 +46,12 @@ func readAwsPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClient
 func readAwsPluginRules(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _ kubernetes.Interface) error {
 	log.Header("AWS Plugin Rule Configuration")
 	var err error
-	c := vc.AWSPlugin
+	c := awsConfig(vc)
 
 	if c.Validator.DefaultRegion != "" {
 		region = c.Validator.DefaultRegion
 	}
-	c.Validator.DefaultRegion, err = prompts.ReadText("Default AWS region", region, false, -1)
+	c.Validator.DefaultRegion, err = prompts.ReadText(answers.For(awsPath+".validator.defaultRegion", "Default AWS region"), region, false, -1)
 	if err != nil {
 		return err
 	}
@@ -91,7 +94,8 @@ func configureIamRoleRules(c *components.AWSPluginConfig, ruleNames *[]string) e
 	specified in the provided policy document(s).
 	`)
 
-	validateRoles, err := prompts.ReadBool("Enable IAM Role validation", true)
+	rulesKey := awsPath + ".validator.iamRoleRules"
+	validateRoles, err := prompts.ReadBool(answers.For(rulesKey, "Enable IAM Role validation"), true)
 	if err != nil {
 		return err
 	}
@@ -99,6 +103,9 @@ func configureIamRoleRules(c *components.AWSPluginConfig, ruleNames *[]string) e
 		c.Validator.IamRoleRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.IamRoleRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.IamRoleRules {
 		r := r
 		if err := readIamRoleRule(c, &r, i, ruleNames); err != nil {
@@ -180,7 +187,8 @@ func configureIamUserRules(c *components.AWSPluginConfig, ruleNames *[]string) e
 	specified in the provided policy document(s).
 	`)
 
-	validateUsers, err := prompts.ReadBool("Enable IAM User validation", true)
+	rulesKey := awsPath + ".validator.iamUserRules"
+	validateUsers, err := prompts.ReadBool(answers.For(rulesKey, "Enable IAM User validation"), true)
 	if err != nil {
 		return err
 	}
@@ -188,6 +196,9 @@ func configureIamUserRules(c *components.AWSPluginConfig, ruleNames *[]string) e
 		c.Validator.IamUserRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.IamUserRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.IamUserRules {
 		r := r
 		if err := readIamUserRule(c, &r, i, ruleNames); err != nil {
@@ -269,7 +280,8 @@ func configureIamGroupRules(c *components.AWSPluginConfig, ruleNames *[]string)
 	specified in the provided policy document(s).
 	`)
 
-	validateGroups, err := prompts.ReadBool("Enable IAM Group validation", true)
+	rulesKey := awsPath + ".validator.iamGroupRules"
+	validateGroups, err := prompts.ReadBool(answers.For(rulesKey, "Enable IAM Group validation"), true)
 	if err != nil {
 		return err
 	}
@@ -277,6 +289,9 @@ func configureIamGroupRules(c *components.AWSPluginConfig, ruleNames *[]string)
 		c.Validator.IamGroupRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.IamGroupRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.IamGroupRules {
 		r := r
 		if err := readIamGroupRule(c, &r, i, ruleNames); err != nil {
@@ -358,7 +373,8 @@ func configureIamPolicyRules(c *components.AWSPluginConfig, ruleNames *[]string)
 	specified in the provided policy document(s).
 	`)
 
-	validatePolicies, err := prompts.ReadBool("Enable IAM Policy validation", true)
+	rulesKey := awsPath + ".validator.iamPolicyRules"
+	validatePolicies, err := prompts.ReadBool(answers.For(rulesKey, "Enable IAM Policy validation"), true)
 	if err != nil {
 		return err
 	}
@@ -366,6 +382,9 @@ func configureIamPolicyRules(c *components.AWSPluginConfig, ruleNames *[]string)
 		c.Validator.IamPolicyRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.IamPolicyRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.IamPolicyRules {
 		r := r
 		if err := readIamPolicyRule(c, &r, i, ruleNames); err != nil {
@@ -511,7 +530,8 @@ func configureServiceQuotaRules(c *components.AWSPluginConfig, ruleNames *[]stri
 	remains below a specific buffer.
 	`)
 
-	validateQuotas, err := prompts.ReadBool("Enable Service Quota validation", true)
+	rulesKey := awsPath + ".validator.serviceQuotaRules"
+	validateQuotas, err := prompts.ReadBool(answers.For(rulesKey, "Enable Service Quota validation"), true)
 	if err != nil {
 		return err
 	}
@@ -519,6 +539,9 @@ func configureServiceQuotaRules(c *components.AWSPluginConfig, ruleNames *[]stri
 		c.Validator.ServiceQuotaRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.ServiceQuotaRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.ServiceQuotaRules {
 		r := r
 		if err := readServiceQuotaRule(c, &r, i, ruleNames); err != nil {
@@ -557,7 +580,8 @@ func configureAwsTagRules(c *components.AWSPluginConfig, ruleNames *[]string) er
 	AWS tag validation ensures that specific tags are set on selected AWS resources.
 	`)
 
-	validateTags, err := prompts.ReadBool("Enable Tag validation", true)
+	rulesKey := awsPath + ".validator.tagRules"
+	validateTags, err := prompts.ReadBool(answers.For(rulesKey, "Enable Tag validation"), true)
 	if err != nil {
 		return err
 	}
@@ -565,6 +589,9 @@ func configureAwsTagRules(c *components.AWSPluginConfig, ruleNames *[]string) er
 		c.Validator.TagRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.TagRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.TagRules {
 		r := r
 		switch r.ResourceType {
@@ -617,7 +644,8 @@ func configureAmiRules(c *components.AWSPluginConfig, ruleNames *[]string) error
 	considered successful if at least one AMI is found.
 	`)
 
-	validateTags, err := prompts.ReadBool("Enable AMI validation", true)
+	rulesKey := awsPath + ".validator.amiRules"
+	validateTags, err := prompts.ReadBool(answers.For(rulesKey, "Enable AMI validation"), true)
 	if err != nil {
 		return err
 	}
@@ -625,6 +653,9 @@ func configureAmiRules(c *components.AWSPluginConfig, ruleNames *[]string) error
 		c.Validator.AmiRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.AmiRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.AmiRules {
 		r := r
 		if err := readAmiRule(c, &r, i, ruleNames); err != nil {
@@ -671,7 +702,7 @@ func readAwsCredentials(c *components.AWSPluginConfig, tc *cfg.TaskConfig, k8sCl
 		}
 	}
 
-	useSTS, err := prompts.ReadBool("Configure Credentials for STS", false)
+	useSTS, err := prompts.ReadBool(answers.For(stsAuthKey, "Configure Credentials for STS"), false)
 	if err != nil {
 		return err
 	}
@@ -685,6 +716,9 @@ func readAwsCredentials(c *components.AWSPluginConfig, tc *cfg.TaskConfig, k8sCl
 }
 
 func readSTS(c *components.AWSPluginConfig) error {
+	if answers.Answered(stsAuthKey) {
+		return nil
+	}
 	c.Validator.Auth.StsAuth = &vpawsapi.AwsSTSAuth{}
 	err := clouds.ReadAwsSTSProfile(c)
 	if err != nil {
@@ -692,11 +726,11 @@ func readSTS(c *components.AWSPluginConfig) error {
 	}
 
 	if c.Validator.Auth.StsAuth.RoleArn == "" {
-		c.Validator.Auth.StsAuth.RoleArn, err = prompts.ReadText("AWS STS Role ARN", c.Validator.Auth.StsAuth.RoleArn, false, -1)
+		c.Validator.Auth.StsAuth.RoleArn, err = prompts.ReadText(answers.For(stsAuthKey+".roleArn", "AWS STS Role ARN"), c.Validator.Auth.StsAuth.RoleArn, false, -1)
 		if err != nil {
 			return err
 		}
-		c.Validator.Auth.StsAuth.RoleSessionName, err = prompts.ReadText("AWS STS Session Name", c.Validator.Auth.StsAuth.RoleSessionName, false, -1)
+		c.Validator.Auth.StsAuth.RoleSessionName, err = prompts.ReadText(answers.For(stsAuthKey+".roleSessionName", "AWS STS Session Name"), c.Validator.Auth.StsAuth.RoleSessionName, false, -1)
 		if err != nil {
 			return err
 		}
@@ -704,7 +738,7 @@ func readSTS(c *components.AWSPluginConfig) error {
 		if c.Validator.Auth.StsAuth.DurationSeconds != 0 {
 			duration = intToStringDefault(c.Validator.Auth.StsAuth.DurationSeconds)
 		}
-		c.Validator.Auth.StsAuth.DurationSeconds, err = prompts.ReadInt("AWS STS Session Duration", duration, 900, 43200)
+		c.Validator.Auth.StsAuth.DurationSeconds, err = prompts.ReadInt(answers.For(stsAuthKey+".durationSeconds", "AWS STS Session Duration"), duration, 900, 43200)
 		if err != nil {
 			return err
 		}
@@ -715,12 +749,12 @@ func readSTS(c *components.AWSPluginConfig) error {
 func readInstallAwsCredentials(c *components.AWSPluginConfig, k8sClient kubernetes.Interface) error {
 	var err error
 
-	c.Validator.Auth.Implicit, err = prompts.ReadBool("Use implicit AWS auth", true)
+	c.Validator.Auth.Implicit, err = prompts.ReadBool(answers.For(awsPath+".validator.auth.implicit", "Use implicit AWS auth"), true)
 	if err != nil {
 		return err
 	}
 	if c.Validator.Auth.Implicit {
-		c.ServiceAccountName, err = services.ReadServiceAccount(k8sClient, cfg.Validator)
+		c.ServiceAccountName, err = services.ReadServiceAccount(k8sClient, cfg.Validator, awsPath+".serviceAccountName")
 		if err != nil {
 			return err
 		}
@@ -736,7 +770,7 @@ func readInstallAwsCredentials(c *components.AWSPluginConfig, k8sClient kubernet
 	If using an existing secret, it must contain the following keys: %+v.
 	`, cfg.Validator, cfg.ValidatorPluginAwsKeys,
 		)
-		createSecret, err = prompts.ReadBool("Create AWS credential secret", true)
+		createSecret, err = prompts.ReadBool(answers.For(awsPath+".accessKeyId", "Create AWS credential secret"), true)
 		if err != nil {
 			return err
 		}
@@ -746,7 +780,7 @@ func readInstallAwsCredentials(c *components.AWSPluginConfig, k8sClient kubernet
 		if c.Validator.Auth.SecretName != "" {
 			awsSecretName = c.Validator.Auth.SecretName
 		}
-		c.Validator.Auth.SecretName, err = prompts.ReadText("AWS credentials secret name", awsSecretName, false, -1)
+		c.Validator.Auth.SecretName, err = prompts.ReadText(answers.For(awsPath+".validator.auth.secretName", "AWS credentials secret name"), awsSecretName, false, -1)
 		if err != nil {
 			return err
 		}
@@ -754,7 +788,7 @@ func readInstallAwsCredentials(c *components.AWSPluginConfig, k8sClient kubernet
 			return err
 		}
 	} else {
-		secret, err := services.ReadSecret(k8sClient, cfg.Validator, false, cfg.ValidatorPluginAwsKeys)
+		secret, err := services.ReadSecret(k8sClient, cfg.Validator, awsPath+".validator.auth.secretName", false, cfg.ValidatorPluginAwsKeys)
 		if err != nil {
 			return err
 		}
@@ -786,6 +820,11 @@ func readDirectAwsCredentials(c *components.AWSPluginConfig) error {
 }
 
 func readAwsCredsHelper(c *components.AWSPluginConfig) error {
+	// credentials provided as answers, skip prompting for credentials
+	if answers.Answered(awsPath + ".accessKeyId") {
+		return nil
+	}
+
 	tryKeychain, err := clouds.ReadAwsProfile(c)
 	if err != nil {
 		return err
@@ -802,6 +841,9 @@ func readAwsCredsHelper(c *components.AWSPluginConfig) error {
 		return nil
 	}
 
+	if _, err := answers.Skip(awsPath+".accessKeyId", "AWS Access Key ID"); err != nil {
+		return err
+	}
 	c.AccessKeyID, c.SecretAccessKey, c.SessionToken, err = readAwsCreds(c.AccessKeyID, c.SecretAccessKey, c.SessionToken)
 	if err != nil {
 		return err
@@ -19,12 +19,14 @@ var awsDummyConfig = &components.ValidatorConfig{
 	RegistryConfig: &components.RegistryConfig{
 		Enabled: false,
 	},
-	AWSPlugin: &components.AWSPluginConfig{
-		Release: &v1alpha1.HelmRelease{
-			Chart: v1alpha1.HelmChart{},
-		},
-		Validator: &aws.AwsValidatorSpec{
-			Auth: aws.AwsAuth{},
+	Plugins: components.PluginConfigs{
+		awsKey: &components.AWSPluginConfig{
+			Release: &v1alpha1.HelmRelease{
+				Chart: v1alpha1.HelmChart{},
+			},
+			Validator: &aws.AwsValidatorSpec{
+				Auth: aws.AwsAuth{},
+			},
 		},
 	},
 	Release: &v1alpha1.HelmRelease{
@@ -19,6 +19,7 @@ import (
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 const (
@@ -38,17 +39,17 @@ var (
 )
 
 func readAzurePlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClient kubernetes.Interface) error {
-	c := vc.AzurePlugin
+	c := azureConfig(vc)
 
 	log.InfoCLI("Select the Azure cloud environment to connect to.")
 	var err error
-	vc.AzurePlugin.Cloud, err = prompts.Select("Azure cloud", cfg.ValidatorAzureClouds)
+	c.Cloud, err = prompts.Select(answers.For(azurePath+".cloud", "Azure cloud"), cfg.ValidatorAzureClouds)
 	if err != nil {
 		return err
 	}
 
 	if !tc.Direct {
-		if err := readHelmRelease(cfg.ValidatorPluginAzure, vc, c.Release); err != nil {
+		if err := readHelmRelease(cfg.ValidatorPluginAzure, azurePath+".helmRelease", vc, c.Release); err != nil {
 			return fmt.Errorf("failed to read Helm release: %w", err)
 		}
 	}
@@ -63,7 +64,7 @@ func readAzurePlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClie
 func readAzurePluginRules(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _ kubernetes.Interface) error {
 	log.Header("Azure Plugin Rule Configuration")
 
-	c := vc.AzurePlugin
+	c := azureConfig(vc)
 	ruleNames := make([]string, 0)
 
 	if err := configureRBACRules(c, &ruleNames); err != nil {
@@ -130,12 +131,12 @@ func readDirectAzureCredentials(c *components.AzurePluginConfig) error {
 func readInstallAzureCredentials(c *components.AzurePluginConfig, k8sClient kubernetes.Interface) error {
 	var err error
 
-	c.Validator.Auth.Implicit, err = prompts.ReadBool("Use implicit Azure auth", true)
+	c.Validator.Auth.Implicit, err = prompts.ReadBool(answers.For(azurePath+".validator.auth.implicit", "Use implicit Azure auth"), true)
 	if err != nil {
 		return fmt.Errorf("failed to prompt for bool for use implicit Azure auth: %w", err)
 	}
 	if c.Validator.Auth.Implicit {
-		c.ServiceAccountName, err = services.ReadServiceAccount(k8sClient, cfg.Validator)
+		c.ServiceAccountName, err = services.ReadServiceAccount(k8sClient, cfg.Validator, azurePath+".serviceAccountName")
 		if err != nil {
 			return fmt.Errorf("failed to read k8s ServiceAccount: %w", err)
 		}
@@ -150,7 +151,7 @@ func readInstallAzureCredentials(c *components.AzurePluginConfig, k8sClient kube
 	If using an existing secret, it must contain the following keys: %+v.
 	`, cfg.Validator, cfg.ValidatorPluginAzureKeys,
 		)
-		createSecret, err = prompts.ReadBool("Create Azure credential secret", true)
+		createSecret, err = prompts.ReadBool(answers.For(azurePath+".clientSecret", "Create Azure credential secret"), true)
 		if err != nil {
 			return fmt.Errorf("failed to create Azure credential secret: %w", err)
 		}
@@ -160,7 +161,7 @@ func readInstallAzureCredentials(c *components.AzurePluginConfig, k8sClient kube
 		if c.Validator.Auth.SecretName != "" {
 			azureSecretName = c.Validator.Auth.SecretName
 		}
-		c.Validator.Auth.SecretName, err = prompts.ReadText("Azure credentials secret name", azureSecretName, false, -1)
+		c.Validator.Auth.SecretName, err = prompts.ReadText(answers.For(azurePath+".validator.auth.secretName", "Azure credentials secret name"), azureSecretName, false, -1)
 		if err != nil {
 			return fmt.Errorf("failed to prompt for text for Azure credentials secret name: %w", err)
 		}
@@ -171,7 +172,7 @@ func readInstallAzureCredentials(c *components.AzurePluginConfig, k8sClient kube
 		}
 
 	} else {
-		secret, err := services.ReadSecret(k8sClient, cfg.Validator, false, cfg.ValidatorPluginAzureKeys)
+		secret, err := services.ReadSecret(k8sClient, cfg.Validator, azurePath+".validator.auth.secretName", false, cfg.ValidatorPluginAzureKeys)
 		if err != nil {
 			return fmt.Errorf("failed to read k8s Secret: %w", err)
 		}
@@ -186,15 +187,15 @@ func readInstallAzureCredentials(c *components.AzurePluginConfig, k8sClient kube
 
 func readAzureCredsHelper(c *components.AzurePluginConfig) error {
 	var err error
-	c.TenantID, err = prompts.ReadTextRegex("Azure Tenant ID", c.TenantID, mustBeValidUUID, prompts.UUIDRegex)
+	c.TenantID, err = prompts.ReadTextRegex(answers.For(azurePath+".tenantId", "Azure Tenant ID"), c.TenantID, mustBeValidUUID, prompts.UUIDRegex)
 	if err != nil {
 		return fmt.Errorf("failed to prompt for text for Azure Tenant ID: %w", err)
 	}
-	c.ClientID, err = prompts.ReadTextRegex("Azure Client ID", c.ClientID, mustBeValidUUID, prompts.UUIDRegex)
+	c.ClientID, err = prompts.ReadTextRegex(answers.For(azurePath+".clientId", "Azure Client ID"), c.ClientID, mustBeValidUUID, prompts.UUIDRegex)
 	if err != nil {
 		return fmt.Errorf("failed to prompt for text for Azure Client ID: %w", err)
 	}
-	c.ClientSecret, err = prompts.ReadPassword("Azure Client Secret", c.ClientSecret, false, -1)
+	c.ClientSecret, err = prompts.ReadPassword(answers.For(azurePath+".clientSecret", "Azure Client Secret"), c.ClientSecret, false, -1)
 	if err != nil {
 		return fmt.Errorf("failed to prompt for password for Azure Client Secret: %w", err)
 	}
@@ -208,7 +209,8 @@ func configureRBACRules(c *components.AzurePluginConfig, ruleNames *[]string) er
 	RBAC validation rules ensure that security principals have the required permissions.
 	`)
 
-	validateRBAC, err := prompts.ReadBool("Enable Azure RBAC validation", true)
+	rulesKey := azurePath + ".validator.rbacRules"
+	validateRBAC, err := prompts.ReadBool(answers.For(rulesKey, "Enable Azure RBAC validation"), true)
 	if err != nil {
 		return err
 	}
@@ -216,6 +218,9 @@ func configureRBACRules(c *components.AzurePluginConfig, ruleNames *[]string) er
 		c.Validator.RBACRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.RBACRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.RBACRules {
 		r := r
 		if err := readRBACRule(c, &r, i, ruleNames); err != nil {
@@ -336,7 +341,8 @@ func configureCommunityGalleryImageRules(c *components.AzurePluginConfig, ruleNa
 	Community gallery image validation rules ensure that images are publicly available via community galleries.
 	`)
 
-	validateCommunityGalleryImage, err := prompts.ReadBool("Enable Community Gallery Image validation", true)
+	rulesKey := azurePath + ".validator.communityGalleryImageRules"
+	validateCommunityGalleryImage, err := prompts.ReadBool(answers.For(rulesKey, "Enable Community Gallery Image validation"), true)
 	if err != nil {
 		return err
 	}
@@ -344,6 +350,9 @@ func configureCommunityGalleryImageRules(c *components.AzurePluginConfig, ruleNa
 		c.Validator.CommunityGalleryImageRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.CommunityGalleryImageRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.CommunityGalleryImageRules {
 		r := r
 		if err := readCommunityGalleryImageRule(c, &r, i, ruleNames); err != nil {
@@ -428,12 +437,16 @@ func configureQuotaRules(c *components.AzurePluginConfig, ruleNames *[]string) e
 	Quota validation rules ensure that quota limits are set high enough to account for current usage plus a buffer.
 	`)
 
-	validateQuotas, err := prompts.ReadBool("Enable quota validation", true)
+	rulesKey := azurePath + ".validator.quotaRules"
+	validateQuotas, err := prompts.ReadBool(answers.For(rulesKey, "Enable quota validation"), true)
 	if err != nil {
 		return err
 	}
 	if !validateQuotas {
-		c.Validator.RBACRules = nil
+		c.Validator.QuotaRules = nil
+		return nil
+	}
+	if answeredRules(rulesKey, c.Validator.QuotaRules, ruleNames) {
 		return nil
 	}
 	for i, r := range c.Validator.QuotaRules {
@@ -20,6 +20,7 @@ import (
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 var errNoRulesEnabled = errors.New("no validation rules enabled")
@@ -41,24 +42,24 @@ func readHelmConfig(name string, k8sClient kubernetes.Interface, vc *components.
 		return nil
 	}
 
-	vc.HelmConfig.Registry, err = prompts.ReadText("Helm registry", cfg.ValidatorHelmRegistry, false, -1)
+	vc.HelmConfig.Registry, err = prompts.ReadText(answers.For("helmConfig.registry", "Helm registry"), cfg.ValidatorHelmRegistry, false, -1)
 	if err != nil {
 		return err
 	}
 
-	vc.HelmConfig.InsecureSkipTLSVerify, err = prompts.ReadBool("Allow Insecure Connection (Bypass x509 Verification)", true)
+	vc.HelmConfig.InsecureSkipTLSVerify, err = prompts.ReadBool(answers.For("helmConfig.insecureSkipVerify", "Allow Insecure Connection (Bypass x509 Verification)"), true)
 	if err != nil {
 		return err
 	}
 
 	if !vc.HelmConfig.InsecureSkipTLSVerify {
-		vc.HelmConfig.CAFile, _, _, err = prompts.ReadCACert("Helm repository CA certificate filepath", vc.HelmConfig.CAFile, "")
+		vc.HelmConfig.CAFile, _, _, err = prompts.ReadCACert(answers.For("helmConfig.caFile", "Helm repository CA certificate filepath"), vc.HelmConfig.CAFile, "")
 		if err != nil {
 			return err
 		}
 	}
 
-	useBasicAuth, err := prompts.ReadBool("Configure Helm basic authentication", false)
+	useBasicAuth, err := prompts.ReadBool(answers.For("helmReleaseSecret.basicAuth", "Configure Helm basic authentication"), false)
 	if err != nil {
 		return err
 	}
@@ -72,12 +73,12 @@ func readHelmConfig(name string, k8sClient kubernetes.Interface, vc *components.
 	If using an existing secret, it must contain the following keys: %+v.
 	`, cfg.Validator, cfg.ValidatorBasicAuthKeys,
 			)
-			useExistingSecret, err = prompts.ReadBool("Use existing secret", true)
+			useExistingSecret, err = prompts.ReadBool(answers.For("helmReleaseSecret.exists", "Use existing secret"), true)
 			if err != nil {
 				return err
 			}
 			if useExistingSecret {
-				secret, err := services.ReadSecret(k8sClient, cfg.Validator, false, cfg.ValidatorBasicAuthKeys)
+				secret, err := services.ReadSecret(k8sClient, cfg.Validator, "helmReleaseSecret.name", false, cfg.ValidatorBasicAuthKeys)
 				if err != nil {
 					return err
 				}
@@ -103,7 +104,8 @@ func readHelmConfig(name string, k8sClient kubernetes.Interface, vc *components.
 	return nil
 }
 
-func readHelmRelease(name string, vc *components.ValidatorConfig, c *vapi.HelmRelease) error {
+// readHelmRelease prompts the user to configure a Helm release, whose configuration path is key, e.g., plugins.aws.helmRelease
+func readHelmRelease(name, key string, vc *components.ValidatorConfig, c *vapi.HelmRelease) error {
 	log.Header(fmt.Sprintf("%s Helm Chart Configuration", name))
 
 	c.Chart.Name = name
@@ -122,12 +124,12 @@ func readHelmRelease(name string, vc *components.ValidatorConfig, c *vapi.HelmRe
 			log.InfoCLI("Failed to fetch chart versions from Helm repo due to error: %v. Falling back to manual input.", err)
 		}
 		if availableVersions != nil {
-			c.Chart.Version, err = prompts.Select(versionPrompt, availableVersions)
+			c.Chart.Version, err = prompts.Select(answers.For(key+".chart.version", versionPrompt), availableVersions)
 			if err != nil {
 				return err
 			}
 		} else {
-			c.Chart.Version, err = prompts.ReadSemVer(versionPrompt, c.Chart.Version, "invalid Helm version")
+			c.Chart.Version, err = prompts.ReadSemVer(answers.For(key+".chart.version", versionPrompt), c.Chart.Version, "invalid Helm version")
 			if err != nil {
 				return err
 			}
@@ -183,10 +185,11 @@ func getReleasesFromHelmRepo(repoURL string) ([]string, error) {
 	return versions, nil
 }
 
+// readBasicAuthSecret prompts the user to configure the Helm release secret
 func readBasicAuthSecret(secret *components.Secret) error {
 	var err error
 	if secret.Name == "" {
-		secret.Name, err = prompts.ReadK8sName("Secret Name", "", false)
+		secret.Name, err = prompts.ReadK8sName(answers.For("helmReleaseSecret.name", "Secret Name"), "", false)
 		if err != nil {
 			return err
 		}
@@ -199,7 +202,7 @@ func readBasicAuthSecret(secret *components.Secret) error {
 	}
 
 	secret.BasicAuth.Username, secret.BasicAuth.Password, err = prompts.ReadBasicCreds(
-		"Username", "Password", secret.BasicAuth.Username, secret.BasicAuth.Password, false, false,
+		answers.For("helmReleaseSecret.basicAuth.username", "Username"), answers.For("helmReleaseSecret.basicAuth.password", "Password"), secret.BasicAuth.Username, secret.BasicAuth.Password, false, false,
 	)
 	if err != nil {
 		return err
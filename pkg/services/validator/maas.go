@@ -11,6 +11,7 @@ import (
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
 	"github.com/validator-labs/validatorctl/pkg/services/clouds"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 
 	"github.com/spectrocloud-labs/prompts-tui/prompts"
 
@@ -23,10 +24,10 @@ var (
 )
 
 func readMaasPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClient kubernetes.Interface) error {
-	c := vc.MaasPlugin
+	c := maasConfig(vc)
 
 	if !tc.Direct {
-		if err := readHelmRelease(cfg.ValidatorPluginMaas, vc, c.Release); err != nil {
+		if err := readHelmRelease(cfg.ValidatorPluginMaas, maasPath+".helmRelease", vc, c.Release); err != nil {
 			return fmt.Errorf("failed to read Helm release: %w", err)
 		}
 	}
@@ -39,7 +40,7 @@ func readMaasPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClien
 // nolint:dupl
 func readMaasPluginRules(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _ kubernetes.Interface) error {
 	log.Header("MAAS Plugin Rule Configuration")
-	c := vc.MaasPlugin
+	c := maasConfig(vc)
 	ruleNames := make([]string, 0)
 
 	if err := configureMaasResourceRules(c, &ruleNames); err != nil {
@@ -76,7 +77,7 @@ func readMaasCredentials(c *components.MaasPluginConfig, tc *cfg.TaskConfig, k8s
 		Either specify MAAS credentials or provide the name of a secret in the target K8s cluster's %s namespace.
 		`, cfg.Validator,
 		)
-		createSecret, err = prompts.ReadBool("Create MAAS credential secret", true)
+		createSecret, err = prompts.ReadBool(answers.For(maasPath+".validator.auth.apiToken", "Create MAAS credential secret"), true)
 		if err != nil {
 			return fmt.Errorf("failed to create MAAS credential secret: %w", err)
 		}
@@ -88,26 +89,26 @@ func readMaasCredentials(c *components.MaasPluginConfig, tc *cfg.TaskConfig, k8s
 		}
 
 		if !tc.Direct {
-			c.Validator.Auth.SecretName, err = prompts.ReadK8sName("MAAS credentials secret name", maasSecretName, false)
+			c.Validator.Auth.SecretName, err = prompts.ReadK8sName(answers.For(maasPath+".validator.auth.secretName", "MAAS credentials secret name"), maasSecretName, false)
 			if err != nil {
 				return fmt.Errorf("failed to prompt for text for MAAS credentials secret name: %w", err)
 			}
-			c.Validator.Auth.TokenKey, err = prompts.ReadText("MAAS API token key", maasTokenKey, false, -1)
+			c.Validator.Auth.TokenKey, err = prompts.ReadText(answers.For(maasPath+".validator.auth.tokenKey", "MAAS API token key"), maasTokenKey, false, -1)
 			if err != nil {
 				return fmt.Errorf("failed to prompt for text for MAAS API token key: %w", err)
 			}
 		}
 
-		if err := clouds.ReadMaasClientProps(c); err != nil {
+		if err := clouds.ReadMaasClientProps(maasPath+".validator", c); err != nil {
 			return err
 		}
 
 	} else {
-		c.Validator.Auth.TokenKey, err = prompts.ReadText("MAAS API token key", maasTokenKey, false, -1)
+		c.Validator.Auth.TokenKey, err = prompts.ReadText(answers.For(maasPath+".validator.auth.tokenKey", "MAAS API token key"), maasTokenKey, false, -1)
 		if err != nil {
 			return fmt.Errorf("failed to prompt for text for MAAS API token key: %w", err)
 		}
-		secret, err := services.ReadSecret(k8sClient, cfg.Validator, false, []string{c.Validator.Auth.TokenKey})
+		secret, err := services.ReadSecret(k8sClient, cfg.Validator, maasPath+".validator.auth.secretName", false, []string{c.Validator.Auth.TokenKey})
 		if err != nil {
 			return fmt.Errorf("failed to read k8s Secret: %w", err)
 		}
@@ -126,7 +127,8 @@ func configureMaasResourceRules(c *components.MaasPluginConfig, ruleNames *[]str
 	Each availability zone should have no more than 1 rule configured.
 	`)
 
-	validateResources, err := prompts.ReadBool("Enable Resource Availability validation", true)
+	rulesKey := maasPath + ".validator.resourceAvailabilityRules"
+	validateResources, err := prompts.ReadBool(answers.For(rulesKey, "Enable Resource Availability validation"), true)
 	if err != nil {
 		return err
 	}
@@ -135,6 +137,9 @@ func configureMaasResourceRules(c *components.MaasPluginConfig, ruleNames *[]str
 		c.Validator.ResourceAvailabilityRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.ResourceAvailabilityRules, ruleNames) {
+		return nil
+	}
 
 	for i, r := range c.Validator.ResourceAvailabilityRules {
 		r := r
@@ -278,7 +283,8 @@ func configureMaasImageRules(c *components.MaasPluginConfig, ruleNames *[]string
 	OS Image validation ensures that the specified images are available for use.
 	`)
 
-	validateImages, err := prompts.ReadBool("Enable OS Image validation", true)
+	rulesKey := maasPath + ".validator.imageRules"
+	validateImages, err := prompts.ReadBool(answers.For(rulesKey, "Enable OS Image validation"), true)
 	if err != nil {
 		return err
 	}
@@ -287,6 +293,9 @@ func configureMaasImageRules(c *components.MaasPluginConfig, ruleNames *[]string
 		c.Validator.ImageRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.ImageRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.ImageRules {
 		r := r
 		if err := readMaasImageRule(c, &r, i, ruleNames); err != nil {
@@ -382,7 +391,8 @@ func configureMaasInternalDNSRules(c *components.MaasPluginConfig, ruleNames *[]
 	Internal DNS validation ensures that the expected DNS setting are configured inside your MAAS cluster.
 	`)
 
-	validateIDNS, err := prompts.ReadBool("Enable Internal DNS validation", true)
+	rulesKey := maasPath + ".validator.internalDNSRules"
+	validateIDNS, err := prompts.ReadBool(answers.For(rulesKey, "Enable Internal DNS validation"), true)
 	if err != nil {
 		return err
 	}
@@ -391,6 +401,9 @@ func configureMaasInternalDNSRules(c *components.MaasPluginConfig, ruleNames *[]
 		c.Validator.InternalDNSRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.InternalDNSRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.InternalDNSRules {
 		r := r
 		if err := readMaasInternalDNSRule(c, &r, i, ruleNames); err != nil {
@@ -528,7 +541,8 @@ func configureMaasUpstreamDNSRules(c *components.MaasPluginConfig, ruleNames *[]
 	Upstream DNS validation ensures that the expected number of upstream DNS server are configured.
 	`)
 
-	validateUDNS, err := prompts.ReadBool("Enable Upstream DNS validation", true)
+	rulesKey := maasPath + ".validator.upstreamDNSRules"
+	validateUDNS, err := prompts.ReadBool(answers.For(rulesKey, "Enable Upstream DNS validation"), true)
 	if err != nil {
 		return err
 	}
@@ -537,6 +551,9 @@ func configureMaasUpstreamDNSRules(c *components.MaasPluginConfig, ruleNames *[]
 		c.Validator.UpstreamDNSRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.UpstreamDNSRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.UpstreamDNSRules {
 		r := r
 		if err := readMaasUpstreamDNSRule(c, &r, i, ruleNames); err != nil {
@@ -14,13 +14,14 @@ import (
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 func readNetworkPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, _ kubernetes.Interface) error {
-	c := vc.NetworkPlugin
+	c := networkConfig(vc)
 
 	if !tc.Direct {
-		if err := readHelmRelease(cfg.ValidatorPluginNetwork, vc, c.Release); err != nil {
+		if err := readHelmRelease(cfg.ValidatorPluginNetwork, networkPath+".helmRelease", vc, c.Release); err != nil {
 			return fmt.Errorf("failed to read Helm release: %w", err)
 		}
 	}
@@ -31,7 +32,7 @@ func readNetworkPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, _ kub
 func readNetworkPluginRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
 	log.Header("Network Plugin Rule Configuration")
 
-	c := vc.NetworkPlugin
+	c := networkConfig(vc)
 	ruleNames := make([]string, 0)
 
 	if err := configureDNSRules(c, &ruleNames); err != nil {
@@ -147,7 +148,8 @@ func configureDNSRules(c *components.NetworkPluginConfig, ruleNames *[]string) e
 	DNS validation rules ensure that DNS lookups succeed for the specified host(s).
 	`)
 
-	validateDNS, err := prompts.ReadBool("Enable DNS validation", true)
+	rulesKey := networkPath + ".validator.dnsRules"
+	validateDNS, err := prompts.ReadBool(answers.For(rulesKey, "Enable DNS validation"), true)
 	if err != nil {
 		return err
 	}
@@ -155,6 +157,9 @@ func configureDNSRules(c *components.NetworkPluginConfig, ruleNames *[]string) e
 		c.Validator.DNSRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.DNSRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.DNSRules {
 		r := r
 		if err := readDNSRule(c, &r, i, ruleNames); err != nil {
@@ -194,7 +199,8 @@ func configureIcmpRules(c *components.NetworkPluginConfig, ruleNames *[]string)
 	ICMP validation rules ensure that ICMP pings succeed for the specified host(s).
 	`)
 
-	validateIcmp, err := prompts.ReadBool("Enable ICMP validation", true)
+	rulesKey := networkPath + ".validator.icmpRules"
+	validateIcmp, err := prompts.ReadBool(answers.For(rulesKey, "Enable ICMP validation"), true)
 	if err != nil {
 		return err
 	}
@@ -202,6 +208,9 @@ func configureIcmpRules(c *components.NetworkPluginConfig, ruleNames *[]string)
 		c.Validator.ICMPRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.ICMPRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.ICMPRules {
 		r := r
 		if err := readIcmpRule(c, &r, i, ruleNames); err != nil {
@@ -242,7 +251,8 @@ func configureIPRangeRules(c *components.NetworkPluginConfig, ruleNames *[]strin
 	IP addresses (starting IP + next N IPs) are unallocated.
 	`)
 
-	validateIPRange, err := prompts.ReadBool("Enable IP range validation", true)
+	rulesKey := networkPath + ".validator.ipRangeRules"
+	validateIPRange, err := prompts.ReadBool(answers.For(rulesKey, "Enable IP range validation"), true)
 	if err != nil {
 		return err
 	}
@@ -250,6 +260,9 @@ func configureIPRangeRules(c *components.NetworkPluginConfig, ruleNames *[]strin
 		c.Validator.IPRangeRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.IPRangeRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.IPRangeRules {
 		r := r
 		if err := readIPRangeRule(c, &r, i, ruleNames); err != nil {
@@ -290,7 +303,8 @@ func configureMtuRules(c *components.NetworkPluginConfig, ruleNames *[]string) e
 	MTU of at least X, where X is the provided MTU.
 	`)
 
-	validateMTU, err := prompts.ReadBool("Enable MTU validation", true)
+	rulesKey := networkPath + ".validator.mtuRules"
+	validateMTU, err := prompts.ReadBool(answers.For(rulesKey, "Enable MTU validation"), true)
 	if err != nil {
 		return err
 	}
@@ -298,6 +312,9 @@ func configureMtuRules(c *components.NetworkPluginConfig, ruleNames *[]string) e
 		c.Validator.MTURules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.MTURules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.MTURules {
 		r := r
 		if err := readMtuRule(c, &r, i, ruleNames); err != nil {
@@ -338,7 +355,8 @@ func configureTCPConnRules(c *components.NetworkPluginConfig, ruleNames *[]strin
 	to the specified host(s) and port(s) are successful.
 	`)
 
-	validateTCP, err := prompts.ReadBool("Enable TCP connection validation", true)
+	rulesKey := networkPath + ".validator.tcpConnRules"
+	validateTCP, err := prompts.ReadBool(answers.For(rulesKey, "Enable TCP connection validation"), true)
 	if err != nil {
 		return err
 	}
@@ -346,6 +364,9 @@ func configureTCPConnRules(c *components.NetworkPluginConfig, ruleNames *[]strin
 		c.Validator.TCPConnRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.TCPConnRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.TCPConnRules {
 		r := r
 		if err := readTCPConnRule(c, &r, i, ruleNames); err != nil {
@@ -386,7 +407,8 @@ func configureHTTPFileRules(c *components.NetworkPluginConfig, tc *cfg.TaskConfi
 	optionally with basic authentication.
 	`)
 
-	validateFiles, err := prompts.ReadBool("Enable HTTP file validation", true)
+	rulesKey := networkPath + ".validator.httpFileRules"
+	validateFiles, err := prompts.ReadBool(answers.For(rulesKey, "Enable HTTP file validation"), true)
 	if err != nil {
 		return err
 	}
@@ -394,6 +416,9 @@ func configureHTTPFileRules(c *components.NetworkPluginConfig, tc *cfg.TaskConfi
 		c.Validator.HTTPFileRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.HTTPFileRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.HTTPFileRules {
 		r := r
 		if err := readHTTPFileRule(c, tc, &r, i, ruleNames, kClient); err != nil {
@@ -629,7 +654,7 @@ func readHTTPFileRuleCredentialsSecret(c *components.NetworkPluginConfig, r *net
 		if err != nil {
 			return err
 		}
-		secret, err := services.ReadSecret(kClient, cfg.Validator, false, []string{usernameKey, passwordKey})
+		secret, err := services.ReadSecret(kClient, cfg.Validator, fmt.Sprintf("%s.validator.httpFileRules.%d.auth.secretRef.name", networkPath, idx), false, []string{usernameKey, passwordKey})
 		if err != nil {
 			return err
 		}
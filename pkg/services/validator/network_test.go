@@ -19,11 +19,13 @@ var networkDummyConfig = &components.ValidatorConfig{
 	RegistryConfig: &components.RegistryConfig{
 		Enabled: false,
 	},
-	NetworkPlugin: &components.NetworkPluginConfig{
-		Release: &v1alpha1.HelmRelease{
-			Chart: v1alpha1.HelmChart{},
+	Plugins: components.PluginConfigs{
+		networkKey: &components.NetworkPluginConfig{
+			Release: &v1alpha1.HelmRelease{
+				Chart: v1alpha1.HelmChart{},
+			},
+			Validator: &network.NetworkValidatorSpec{},
 		},
-		Validator: &network.NetworkValidatorSpec{},
 	},
 	Release: &v1alpha1.HelmRelease{
 		Chart: v1alpha1.HelmChart{},
@@ -16,6 +16,7 @@ import (
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 const (
@@ -24,10 +25,10 @@ const (
 )
 
 func readOciPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, _ kubernetes.Interface) error {
-	c := vc.OCIPlugin
+	c := ociConfig(vc)
 
 	if !tc.Direct {
-		if err := readHelmRelease(cfg.ValidatorPluginOci, vc, c.Release); err != nil {
+		if err := readHelmRelease(cfg.ValidatorPluginOci, ociPath+".helmRelease", vc, c.Release); err != nil {
 			return fmt.Errorf("failed to read Helm release: %w", err)
 		}
 	}
@@ -37,7 +38,7 @@ func readOciPlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, _ kuberne
 
 func readOciPluginRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
 	log.Header("OCI Plugin Rule Configuration")
-	c := vc.OCIPlugin
+	c := ociConfig(vc)
 	ruleNames := make([]string, 0)
 	authSecretNames := make([]string, 0)
 	sigSecretNames := make([]string, 0)
@@ -227,6 +228,14 @@ func configureOciRegistryRules(c *components.OCIPluginConfig, ruleNames, authSec
 	OCI registry rule(s) ensure that specific OCI artifacts are present in an OCI registry.
 	`)
 
+	rulesKey := ociPath + ".validator.ociRegistryRules"
+	if answeredRules(rulesKey, c.Validator.OciRegistryRules, ruleNames) {
+		return nil
+	}
+	if _, err := answers.Skip(rulesKey, "OCI registry rules"); err != nil {
+		return err
+	}
+
 	for i, r := range c.Validator.OciRegistryRules {
 		r := r
 		if err := readOciRegistryRule(c, &r, i, ruleNames, authSecretNames, sigSecretNames, kClient, direct); err != nil {
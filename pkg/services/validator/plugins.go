@@ -0,0 +1,602 @@
+package validator
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	awsapi "github.com/validator-labs/validator-plugin-aws/api/v1alpha1"
+	awsconst "github.com/validator-labs/validator-plugin-aws/pkg/constants"
+	awsval "github.com/validator-labs/validator-plugin-aws/pkg/validate"
+	azureapi "github.com/validator-labs/validator-plugin-azure/api/v1alpha1"
+	azureconst "github.com/validator-labs/validator-plugin-azure/pkg/constants"
+	azureval "github.com/validator-labs/validator-plugin-azure/pkg/validate"
+	maasapi "github.com/validator-labs/validator-plugin-maas/api/v1alpha1"
+	maasconst "github.com/validator-labs/validator-plugin-maas/pkg/constants"
+	maasval "github.com/validator-labs/validator-plugin-maas/pkg/validate"
+	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+	netconst "github.com/validator-labs/validator-plugin-network/pkg/constants"
+	netval "github.com/validator-labs/validator-plugin-network/pkg/validate"
+	ociapi "github.com/validator-labs/validator-plugin-oci/api/v1alpha1"
+	ociconst "github.com/validator-labs/validator-plugin-oci/pkg/constants"
+	ocival "github.com/validator-labs/validator-plugin-oci/pkg/validate"
+	vsphereapi "github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	vsphereconst "github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	vsphereval "github.com/validator-labs/validator-plugin-vsphere/pkg/validate"
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vplugins "github.com/validator-labs/validator/pkg/plugins"
+	"github.com/validator-labs/validator/pkg/types"
+	vres "github.com/validator-labs/validator/pkg/validationresult"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+	"github.com/validator-labs/validatorctl/pkg/utils/exec"
+)
+
+func init() {
+	plugins.Register(awsPlugin{descriptor{
+		key:            awsKey,
+		code:           awsconst.PluginCode,
+		kind:           cfg.ValidatorPluginAwsKind,
+		chart:          cfg.ValidatorPluginAws,
+		valuesTemplate: "validator-plugin-aws-values.tmpl",
+		rulesTemplate:  cfg.ValidatorPluginAwsTemplate,
+		templateKey:    "AWSPlugin",
+		description: `
+	The AWS validator plugin reconciles AwsValidator custom resources to perform the
+	following validations against your AWS environment:
+
+	- Ensure that one or more EC2 AMI(s) exist in a particular region.
+	- Compare the IAM permissions associated with an IAM user / group / role / policy
+	  against an expected permission set.
+	- Compare the usage for a particular service quota against the active quota to
+	  avoid unexpectedly hitting quota limits.
+	- Compare the tags associated with a subnet against an expected tag set.
+		`,
+	}})
+	// TODO: support image gallery rules
+	// - Verify that images in community image galleries exist.
+	plugins.Register(azurePlugin{descriptor{
+		key:            azureKey,
+		code:           azureconst.PluginCode,
+		kind:           cfg.ValidatorPluginAzureKind,
+		chart:          cfg.ValidatorPluginAzure,
+		valuesTemplate: "validator-plugin-azure-values.tmpl",
+		rulesTemplate:  cfg.ValidatorPluginAzureTemplate,
+		templateKey:    "AzurePlugin",
+		description: `
+	The Azure validator plugin reconciles AzureValidator custom resources to perform
+	the following validations against your Azure environment:
+
+	- Compare the Azure RBAC permissions associated with a security principal against
+	  an expected permission set.
+		`,
+	}})
+	plugins.Register(maasPlugin{descriptor{
+		key:            maasKey,
+		code:           maasconst.PluginCode,
+		kind:           cfg.ValidatorPluginMaasKind,
+		chart:          cfg.ValidatorPluginMaas,
+		valuesTemplate: "validator-plugin-maas-values.tmpl",
+		rulesTemplate:  cfg.ValidatorPluginMaasTemplate,
+		templateKey:    "MAASPlugin",
+		description: `
+	The MAAS validator plugin reconciles MaasValidator custom resources to perform
+	the following validation against your MAAS cluster:
+
+	- Check that a certain number of Upstream DNS servers are configured.
+	- Check that the expected Internal DNS settings are present.
+	- Check that the expected number of Machines matching a certain spec are "Ready" for use in each Availability Zone.
+	- Check that all required OS Images are "Synced" to the cluster.
+		`,
+	}})
+	plugins.Register(networkPlugin{descriptor{
+		key:            networkKey,
+		code:           netconst.PluginCode,
+		kind:           cfg.ValidatorPluginNetworkKind,
+		chart:          cfg.ValidatorPluginNetwork,
+		valuesTemplate: "validator-plugin-network-values.tmpl",
+		rulesTemplate:  cfg.ValidatorPluginNetworkTemplate,
+		templateKey:    "NetworkPlugin",
+		description: `
+	The Network validator plugin reconciles NetworkValidator custom resources to perform
+	the following validations against your network:
+
+	- Execute DNS lookups.
+	- Execute ICMP pings.
+	- Validate TCP connections to arbitrary host + port(s).
+	- Check each IP in an IP range to ensure that they're all unallocated.
+	- Check that the default NIC has an MTU greater than or equal to a specified value.
+	- Check that each file in a list of URLs is available and publicly accessible
+	  via an HTTP HEAD request, with optional basic auth.
+		`,
+	}})
+	plugins.Register(ociPlugin{descriptor{
+		key:            ociKey,
+		code:           ociconst.PluginCode,
+		kind:           cfg.ValidatorPluginOciKind,
+		chart:          cfg.ValidatorPluginOci,
+		valuesTemplate: "validator-plugin-oci-values.tmpl",
+		rulesTemplate:  cfg.ValidatorPluginOciTemplate,
+		templateKey:    "OCIPlugin",
+		description: `
+	The OCI validator plugin reconciles OciValidator custom resources to perform the
+	following validations against your OCI registry:
+
+	- Validate OCI registry authentication.
+	- Validate the existence of arbitrary OCI artifacts, with optional signature
+	  verification.
+	- Validate downloading arbitrary OCI artifacts.
+		`,
+	}})
+	plugins.Register(vspherePlugin{descriptor{
+		key:            vsphereKey,
+		code:           vsphereconst.PluginCode,
+		kind:           cfg.ValidatorPluginVsphereKind,
+		chart:          cfg.ValidatorPluginVsphere,
+		valuesTemplate: "validator-plugin-vsphere-values.tmpl",
+		rulesTemplate:  cfg.ValidatorPluginVsphereTemplate,
+		templateKey:    "VspherePlugin",
+		description: `
+	The vSphere validator plugin reconciles VsphereValidator custom resources to perform
+	the following validations against your vSphere environment:
+
+	- Compare the privileges associated with a user against an expected privileges set.
+	- Compare the privileges associated with a user against an expected privileges set
+	  on a particular entity (cluster, resourcepool, folder, vapp, host).
+	- Verify availability of compute resources on an ESXi host, resourcepool, or cluster.
+	- Compare the tags associated with a datacenter, cluster, host, vm, resourcepool or vm
+	  against an expected tag set.
+	- Verify that a set of ESXi hosts have valid NTP configuration.
+		`,
+	}})
+}
+
+// descriptor contains the static properties of a built-in validator plugin
+type descriptor struct {
+	key            string
+	code           string
+	kind           string
+	chart          string
+	valuesTemplate string
+	rulesTemplate  string
+	templateKey    string
+	description    string
+}
+
+func (d descriptor) Key() string            { return d.key }
+func (d descriptor) Code() string           { return d.code }
+func (d descriptor) Kind() string           { return d.kind }
+func (d descriptor) Chart() string          { return d.chart }
+func (d descriptor) ValuesTemplate() string { return d.valuesTemplate }
+func (d descriptor) RulesTemplate() string  { return d.rulesTemplate }
+func (d descriptor) TemplateKey() string    { return d.templateKey }
+func (d descriptor) Description() string    { return d.description }
+
+// keys of the built-in plugins' sections within the plugins section of the validator configuration
+const (
+	awsKey     = "aws"
+	azureKey   = "azure"
+	maasKey    = "maas"
+	networkKey = "network"
+	ociKey     = "oci"
+	vsphereKey = "vsphere"
+)
+
+// configuration paths of the built-in plugins' sections, which prompts are answered relative to
+const (
+	awsPath     = "plugins." + awsKey
+	azurePath   = "plugins." + azureKey
+	maasPath    = "plugins." + maasKey
+	networkPath = "plugins." + networkKey
+	ociPath     = "plugins." + ociKey
+	vspherePath = "plugins." + vsphereKey
+)
+
+func awsConfig(vc *components.ValidatorConfig) *components.AWSPluginConfig {
+	return components.PluginConfig[*components.AWSPluginConfig](vc, awsKey)
+}
+
+func azureConfig(vc *components.ValidatorConfig) *components.AzurePluginConfig {
+	return components.PluginConfig[*components.AzurePluginConfig](vc, azureKey)
+}
+
+func maasConfig(vc *components.ValidatorConfig) *components.MaasPluginConfig {
+	return components.PluginConfig[*components.MaasPluginConfig](vc, maasKey)
+}
+
+func networkConfig(vc *components.ValidatorConfig) *components.NetworkPluginConfig {
+	return components.PluginConfig[*components.NetworkPluginConfig](vc, networkKey)
+}
+
+func ociConfig(vc *components.ValidatorConfig) *components.OCIPluginConfig {
+	return components.PluginConfig[*components.OCIPluginConfig](vc, ociKey)
+}
+
+func vsphereConfig(vc *components.ValidatorConfig) *components.VspherePluginConfig {
+	return components.PluginConfig[*components.VspherePluginConfig](vc, vsphereKey)
+}
+
+// directMeta returns the metadata for a validator whose rules are evaluated directly
+func directMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "N/A",
+	}
+}
+
+type awsPlugin struct{ descriptor }
+
+func (awsPlugin) Enabled(vc *components.ValidatorConfig) bool {
+	c := awsConfig(vc)
+	return c != nil && c.Enabled
+}
+func (awsPlugin) SetEnabled(vc *components.ValidatorConfig, enabled bool) {
+	awsConfig(vc).Enabled = enabled
+}
+func (awsPlugin) Config(vc *components.ValidatorConfig) any { return awsConfig(vc) }
+func (awsPlugin) NewConfig() any                            { return &components.AWSPluginConfig{} }
+func (awsPlugin) InitConfig(vc *components.ValidatorConfig) {
+	c := awsConfig(vc)
+	if c == nil {
+		c = &components.AWSPluginConfig{Release: &vapi.HelmRelease{}}
+		vc.Plugins[awsKey] = c
+	}
+	if c.Validator == nil {
+		c.Validator = &awsapi.AwsValidatorSpec{}
+	}
+}
+func (awsPlugin) Release(vc *components.ValidatorConfig) *vapi.HelmRelease {
+	return awsConfig(vc).Release
+}
+func (awsPlugin) Spec(vc *components.ValidatorConfig) vplugins.PluginSpec {
+	return awsConfig(vc).Validator
+}
+func (awsPlugin) NewSpec() vplugins.PluginSpec { return &awsapi.AwsValidatorSpec{} }
+func (awsPlugin) NewValidator(spec vplugins.PluginSpec) (vres.Validator, plugins.ValidateFunc) {
+	s := spec.(*awsapi.AwsValidatorSpec)
+	v := &awsapi.AwsValidator{ObjectMeta: directMeta("aws-validator"), Spec: *s}
+	return v, func(_ context.Context, l logr.Logger) types.ValidationResponse {
+		return awsval.Validate(*s, l)
+	}
+}
+func (awsPlugin) ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readAwsPlugin(vc, tc, kClient)
+}
+func (awsPlugin) ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readAwsPluginRules(vc, tc, kClient)
+}
+func (awsPlugin) ReadCredentials(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readAwsCredentials(awsConfig(vc), tc, kClient)
+}
+func (awsPlugin) Encode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := awsConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Encode(fc), "failed to encode AWS plugin configuration")
+}
+func (awsPlugin) Decode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := awsConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Decode(fc), "failed to decode AWS plugin configuration")
+}
+
+type azurePlugin struct{ descriptor }
+
+func (azurePlugin) Enabled(vc *components.ValidatorConfig) bool {
+	c := azureConfig(vc)
+	return c != nil && c.Enabled
+}
+func (azurePlugin) SetEnabled(vc *components.ValidatorConfig, enabled bool) {
+	azureConfig(vc).Enabled = enabled
+}
+func (azurePlugin) Config(vc *components.ValidatorConfig) any { return azureConfig(vc) }
+func (azurePlugin) NewConfig() any                            { return &components.AzurePluginConfig{} }
+func (azurePlugin) InitConfig(vc *components.ValidatorConfig) {
+	c := azureConfig(vc)
+	if c == nil {
+		c = &components.AzurePluginConfig{Release: &vapi.HelmRelease{}}
+		vc.Plugins[azureKey] = c
+	}
+	if c.Validator == nil {
+		c.Validator = &azureapi.AzureValidatorSpec{}
+	}
+}
+func (azurePlugin) Release(vc *components.ValidatorConfig) *vapi.HelmRelease {
+	return azureConfig(vc).Release
+}
+func (azurePlugin) Spec(vc *components.ValidatorConfig) vplugins.PluginSpec {
+	return azureConfig(vc).Validator
+}
+func (azurePlugin) NewSpec() vplugins.PluginSpec { return &azureapi.AzureValidatorSpec{} }
+func (azurePlugin) NewValidator(spec vplugins.PluginSpec) (vres.Validator, plugins.ValidateFunc) {
+	s := spec.(*azureapi.AzureValidatorSpec)
+	v := &azureapi.AzureValidator{ObjectMeta: directMeta("azure-validator"), Spec: *s}
+	return v, func(ctx context.Context, l logr.Logger) types.ValidationResponse {
+		return azureval.Validate(ctx, *s, l)
+	}
+}
+func (azurePlugin) ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readAzurePlugin(vc, tc, kClient)
+}
+func (azurePlugin) ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readAzurePluginRules(vc, tc, kClient)
+}
+func (azurePlugin) ReadCredentials(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readAzureCredentials(azureConfig(vc), tc, kClient)
+}
+func (azurePlugin) Encode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := azureConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Encode(fc), "failed to encode Azure plugin configuration")
+}
+func (azurePlugin) Decode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := azureConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Decode(fc), "failed to decode Azure plugin configuration")
+}
+
+type maasPlugin struct{ descriptor }
+
+func (maasPlugin) Enabled(vc *components.ValidatorConfig) bool {
+	c := maasConfig(vc)
+	return c != nil && c.Enabled
+}
+func (maasPlugin) SetEnabled(vc *components.ValidatorConfig, enabled bool) {
+	maasConfig(vc).Enabled = enabled
+}
+func (maasPlugin) Config(vc *components.ValidatorConfig) any { return maasConfig(vc) }
+func (maasPlugin) NewConfig() any                            { return &components.MaasPluginConfig{} }
+func (maasPlugin) InitConfig(vc *components.ValidatorConfig) {
+	c := maasConfig(vc)
+	if c == nil {
+		c = &components.MaasPluginConfig{Release: &vapi.HelmRelease{}}
+		vc.Plugins[maasKey] = c
+	}
+	if c.Validator == nil {
+		c.Validator = &maasapi.MaasValidatorSpec{}
+	}
+}
+func (maasPlugin) Release(vc *components.ValidatorConfig) *vapi.HelmRelease {
+	return maasConfig(vc).Release
+}
+func (maasPlugin) Spec(vc *components.ValidatorConfig) vplugins.PluginSpec {
+	return maasConfig(vc).Validator
+}
+func (maasPlugin) NewSpec() vplugins.PluginSpec { return &maasapi.MaasValidatorSpec{} }
+func (maasPlugin) NewValidator(spec vplugins.PluginSpec) (vres.Validator, plugins.ValidateFunc) {
+	s := spec.(*maasapi.MaasValidatorSpec)
+	v := &maasapi.MaasValidator{ObjectMeta: directMeta("maas-validator"), Spec: *s}
+	return v, func(_ context.Context, l logr.Logger) types.ValidationResponse {
+		return maasval.Validate(*s, l)
+	}
+}
+func (maasPlugin) ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readMaasPlugin(vc, tc, kClient)
+}
+func (maasPlugin) ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readMaasPluginRules(vc, tc, kClient)
+}
+func (maasPlugin) ReadCredentials(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readMaasCredentials(maasConfig(vc), tc, kClient)
+}
+func (maasPlugin) Encode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := maasConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Encode(fc), "failed to encode MAAS plugin configuration")
+}
+func (maasPlugin) Decode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := maasConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Decode(fc), "failed to decode MAAS plugin configuration")
+}
+
+type networkPlugin struct{ descriptor }
+
+func (networkPlugin) Enabled(vc *components.ValidatorConfig) bool {
+	c := networkConfig(vc)
+	return c != nil && c.Enabled
+}
+func (networkPlugin) SetEnabled(vc *components.ValidatorConfig, enabled bool) {
+	networkConfig(vc).Enabled = enabled
+}
+func (networkPlugin) Config(vc *components.ValidatorConfig) any { return networkConfig(vc) }
+func (networkPlugin) NewConfig() any                            { return &components.NetworkPluginConfig{} }
+func (networkPlugin) InitConfig(vc *components.ValidatorConfig) {
+	c := networkConfig(vc)
+	if c == nil {
+		c = &components.NetworkPluginConfig{Release: &vapi.HelmRelease{}, HTTPFileAuths: make([][]string, 0)}
+		vc.Plugins[networkKey] = c
+	}
+	if c.Validator == nil {
+		c.Validator = &netapi.NetworkValidatorSpec{CACerts: netapi.CACertificates{}}
+	}
+}
+func (networkPlugin) Release(vc *components.ValidatorConfig) *vapi.HelmRelease {
+	return networkConfig(vc).Release
+}
+func (networkPlugin) Spec(vc *components.ValidatorConfig) vplugins.PluginSpec {
+	return networkConfig(vc).Validator
+}
+func (networkPlugin) NewSpec() vplugins.PluginSpec { return &netapi.NetworkValidatorSpec{} }
+func (networkPlugin) NewValidator(spec vplugins.PluginSpec) (vres.Validator, plugins.ValidateFunc) {
+	s := spec.(*netapi.NetworkValidatorSpec)
+	v := &netapi.NetworkValidator{ObjectMeta: directMeta("network-validator"), Spec: *s}
+	return v, func(_ context.Context, l logr.Logger) types.ValidationResponse {
+		return netval.Validate(*s,
+			s.CACerts.RawCerts(),
+			s.HTTPFileAuthBytesDirect(),
+			l,
+		)
+	}
+}
+func (networkPlugin) ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	if tc.Direct {
+		if err := exec.CheckBinaries([]exec.Binary{exec.NslookupBin, exec.PingBin}); err != nil {
+			return err
+		}
+	}
+	return readNetworkPlugin(vc, tc, kClient)
+}
+func (networkPlugin) ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readNetworkPluginRules(vc, tc, kClient)
+}
+func (networkPlugin) ReadCredentials(_ *components.ValidatorConfig, _ *cfg.TaskConfig, _ kubernetes.Interface) error {
+	return nil
+}
+func (networkPlugin) Encode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := networkConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Encode(fc), "failed to encode Network plugin configuration")
+}
+func (networkPlugin) Decode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := networkConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Decode(fc), "failed to decode Network plugin configuration")
+}
+
+type ociPlugin struct{ descriptor }
+
+func (ociPlugin) Enabled(vc *components.ValidatorConfig) bool {
+	c := ociConfig(vc)
+	return c != nil && c.Enabled
+}
+func (ociPlugin) SetEnabled(vc *components.ValidatorConfig, enabled bool) {
+	ociConfig(vc).Enabled = enabled
+}
+func (ociPlugin) Config(vc *components.ValidatorConfig) any { return ociConfig(vc) }
+func (ociPlugin) NewConfig() any                            { return &components.OCIPluginConfig{} }
+func (ociPlugin) InitConfig(vc *components.ValidatorConfig) {
+	c := ociConfig(vc)
+	if c == nil {
+		c = &components.OCIPluginConfig{Release: &vapi.HelmRelease{}, CaCertPaths: make(map[int]string)}
+		vc.Plugins[ociKey] = c
+	}
+	if c.Validator == nil {
+		c.Validator = &ociapi.OciValidatorSpec{}
+	}
+}
+func (ociPlugin) Release(vc *components.ValidatorConfig) *vapi.HelmRelease {
+	return ociConfig(vc).Release
+}
+func (ociPlugin) Spec(vc *components.ValidatorConfig) vplugins.PluginSpec {
+	return ociConfig(vc).Validator
+}
+func (ociPlugin) NewSpec() vplugins.PluginSpec { return &ociapi.OciValidatorSpec{} }
+func (ociPlugin) NewValidator(spec vplugins.PluginSpec) (vres.Validator, plugins.ValidateFunc) {
+	s := spec.(*ociapi.OciValidatorSpec)
+	v := &ociapi.OciValidator{ObjectMeta: directMeta("oci-validator"), Spec: *s}
+	return v, func(_ context.Context, l logr.Logger) types.ValidationResponse {
+		return ocival.Validate(*s,
+			s.DeepCopy().BasicAuthsDirect(),
+			s.DeepCopy().AllPubKeysDirect(),
+			l,
+		)
+	}
+}
+func (ociPlugin) ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readOciPlugin(vc, tc, kClient)
+}
+func (ociPlugin) ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readOciPluginRules(vc, tc, kClient)
+}
+func (ociPlugin) ReadCredentials(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _ kubernetes.Interface) error {
+	for _, secret := range ociConfig(vc).Secrets {
+		if err := readOciSecret(secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (ociPlugin) Encode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := ociConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Encode(fc), "failed to encode OCI plugin configuration")
+}
+func (ociPlugin) Decode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := ociConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Decode(fc), "failed to decode OCI plugin configuration")
+}
+
+type vspherePlugin struct{ descriptor }
+
+func (vspherePlugin) Enabled(vc *components.ValidatorConfig) bool {
+	c := vsphereConfig(vc)
+	return c != nil && c.Enabled
+}
+func (vspherePlugin) SetEnabled(vc *components.ValidatorConfig, enabled bool) {
+	vsphereConfig(vc).Enabled = enabled
+}
+func (vspherePlugin) Config(vc *components.ValidatorConfig) any { return vsphereConfig(vc) }
+func (vspherePlugin) NewConfig() any                            { return &components.VspherePluginConfig{} }
+func (vspherePlugin) InitConfig(vc *components.ValidatorConfig) {
+	c := vsphereConfig(vc)
+	if c == nil {
+		c = &components.VspherePluginConfig{Release: &vapi.HelmRelease{}}
+		vc.Plugins[vsphereKey] = c
+	}
+	if c.Validator == nil {
+		c.Validator = &vsphereapi.VsphereValidatorSpec{}
+	}
+}
+func (vspherePlugin) Release(vc *components.ValidatorConfig) *vapi.HelmRelease {
+	return vsphereConfig(vc).Release
+}
+func (vspherePlugin) Spec(vc *components.ValidatorConfig) vplugins.PluginSpec {
+	return vsphereConfig(vc).Validator
+}
+func (vspherePlugin) NewSpec() vplugins.PluginSpec { return &vsphereapi.VsphereValidatorSpec{} }
+func (vspherePlugin) NewValidator(spec vplugins.PluginSpec) (vres.Validator, plugins.ValidateFunc) {
+	s := spec.(*vsphereapi.VsphereValidatorSpec)
+	v := &vsphereapi.VsphereValidator{ObjectMeta: directMeta("vsphere-validator"), Spec: *s}
+	return v, func(ctx context.Context, l logr.Logger) types.ValidationResponse {
+		return vsphereval.Validate(ctx, *s, l)
+	}
+}
+func (vspherePlugin) ReadConfig(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readVspherePlugin(vc, tc, kClient)
+}
+func (vspherePlugin) ReadRules(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readVspherePluginRules(vc, tc, kClient)
+}
+func (vspherePlugin) ReadCredentials(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface) error {
+	return readVsphereCredentials(vsphereConfig(vc), tc, kClient)
+}
+func (vspherePlugin) Encode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := vsphereConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Encode(fc), "failed to encode vSphere plugin configuration")
+}
+func (vspherePlugin) Decode(vc *components.ValidatorConfig, fc components.FieldCodec) error {
+	c := vsphereConfig(vc)
+	if c == nil {
+		return nil
+	}
+	return errors.Wrap(c.Decode(fc), "failed to decode vSphere plugin configuration")
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	netapi "github.com/validator-labs/validator-plugin-network/api/v1alpha1"
+	"github.com/validator-labs/validator/api/v1alpha1"
+
+	"github.com/validator-labs/validatorctl/pkg/components"
+	cfg "github.com/validator-labs/validatorctl/pkg/config"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
+)
+
+func TestRegisteredPlugins(t *testing.T) {
+	assert.Equal(t, []string{"AWS", "Azure", "MAAS", "Network", "OCI", "vSphere"}, plugins.Codes())
+
+	p, ok := plugins.GetByKind(cfg.ValidatorPluginNetworkKind)
+	assert.True(t, ok)
+	assert.Equal(t, "Network", p.Code())
+	assert.Equal(t, cfg.ValidatorPluginNetwork, p.Chart())
+
+	_, ok = plugins.GetByKind("SomeRandomKind")
+	assert.False(t, ok)
+}
+
+func TestEnabledPlugins(t *testing.T) {
+	vc := components.NewValidatorConfig()
+	assert.Empty(t, plugins.Enabled(vc))
+
+	networkConfig(vc).Enabled = true
+	networkConfig(vc).Validator = &netapi.NetworkValidatorSpec{}
+	networkConfig(vc).Release = &v1alpha1.HelmRelease{}
+
+	enabled := plugins.Enabled(vc)
+	assert.Len(t, enabled, 1)
+	assert.Equal(t, "Network", enabled[0].Code())
+
+	ok, invalid := plugins.EnabledPluginsHaveRules(vc)
+	assert.False(t, ok)
+	assert.Equal(t, []string{"Network"}, invalid)
+
+	networkConfig(vc).Validator.DNSRules = []netapi.DNSRule{{RuleName: "Resolve Google", Host: "google.com"}}
+	ok, invalid = plugins.EnabledPluginsHaveRules(vc)
+	assert.True(t, ok)
+	assert.Empty(t, invalid)
+}
+
+func TestInitPlugins(t *testing.T) {
+	vc := &components.ValidatorConfig{}
+	vc.InitPlugins()
+	for _, p := range plugins.All() {
+		assert.NotNil(t, p.Config(vc), p.Code())
+		assert.NotNil(t, p.Release(vc), p.Code())
+		assert.NotNil(t, p.Spec(vc), p.Code())
+		assert.False(t, p.Enabled(vc), p.Code())
+	}
+
+	// existing sections are retained
+	vc = &components.ValidatorConfig{Plugins: components.PluginConfigs{networkKey: &components.NetworkPluginConfig{Enabled: true}}}
+	vc.InitPlugins()
+	assert.True(t, networkConfig(vc).Enabled)
+	assert.NotNil(t, networkConfig(vc).Validator)
+	assert.Nil(t, networkConfig(vc).Release)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/validator-labs/validator/pkg/validationrule"
 
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 )
 
 // initRule initializes a rule by ensuring its name is set. Optionally prints a message when the
@@ -33,3 +34,20 @@ func initRule(rule validationrule.Interface, ruleType, message string, ruleNames
 
 	return nil
 }
+
+// answeredRules returns true if the list of rules at the configuration path key, e.g., plugins.aws.validator.iamRoleRules,
+// is answered, in which case the rules have already been configured and are used as is. Their names are reserved.
+func answeredRules[T any, PT interface {
+	*T
+	validationrule.Interface
+}](key string, rules []T, ruleNames *[]string) bool {
+	if !answers.Answered(key) {
+		return false
+	}
+	for i := range rules {
+		if name := PT(&rules[i]).Name(); name != "" {
+			*ruleNames = append(*ruleNames, name)
+		}
+	}
+	return true
+}
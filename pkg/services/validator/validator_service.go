@@ -4,7 +4,6 @@ package validator
 import (
 	"fmt"
 	"path/filepath"
-	"slices"
 	"strconv"
 	"strings"
 
@@ -14,51 +13,20 @@ import (
 
 	"github.com/spectrocloud-labs/prompts-tui/prompts"
 
-	awsconsts "github.com/validator-labs/validator-plugin-aws/pkg/constants"
-	azureconsts "github.com/validator-labs/validator-plugin-azure/pkg/constants"
-	maasconsts "github.com/validator-labs/validator-plugin-maas/pkg/constants"
-	netconsts "github.com/validator-labs/validator-plugin-network/pkg/constants"
-	ociconsts "github.com/validator-labs/validator-plugin-oci/pkg/constants"
-	vsphereconsts "github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
-
 	"github.com/validator-labs/validatorctl/pkg/components"
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/plugins"
 	"github.com/validator-labs/validatorctl/pkg/services"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 	"github.com/validator-labs/validatorctl/pkg/utils/exec"
 	"github.com/validator-labs/validatorctl/pkg/utils/kind"
 	"github.com/validator-labs/validatorctl/pkg/utils/kube"
 	string_utils "github.com/validator-labs/validatorctl/pkg/utils/string"
 )
 
-type pluginFuncMap map[string]func(*components.ValidatorConfig, *cfg.TaskConfig, kubernetes.Interface) error
-
-var (
-	pluginInstallFuncs = pluginFuncMap{
-		awsconsts.PluginCode:     readAwsPlugin,
-		azureconsts.PluginCode:   readAzurePlugin,
-		maasconsts.PluginCode:    readMaasPlugin,
-		netconsts.PluginCode:     readNetworkPlugin,
-		ociconsts.PluginCode:     readOciPlugin,
-		vsphereconsts.PluginCode: readVspherePlugin,
-	}
-	pluginRuleFuncs = pluginFuncMap{
-		awsconsts.PluginCode:     readAwsPluginRules,
-		azureconsts.PluginCode:   readAzurePluginRules,
-		maasconsts.PluginCode:    readMaasPluginRules,
-		netconsts.PluginCode:     readNetworkPluginRules,
-		ociconsts.PluginCode:     readOciPluginRules,
-		vsphereconsts.PluginCode: readVspherePluginRules,
-	}
-	plugins = make([]string, 0, len(pluginInstallFuncs))
-)
-
-func init() {
-	for k := range pluginInstallFuncs {
-		plugins = append(plugins, k)
-	}
-	slices.Sort(plugins)
-}
+// pluginReadFunc prompts the user to configure a plugin
+type pluginReadFunc func(plugins.Plugin, *components.ValidatorConfig, *cfg.TaskConfig, kubernetes.Interface) error
 
 // ReadValidatorConfig prompts the user to configure installation settings for validator and its plugins.
 // nolint:gocyclo
@@ -81,7 +49,7 @@ func ReadValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components.Valid
 	var kClient kubernetes.Interface
 
 	log.Header("Kind Configuration")
-	vc.KindConfig.UseKindCluster, err = prompts.ReadBool("Provision & use kind cluster", true)
+	vc.KindConfig.UseKindCluster, err = prompts.ReadBool(answers.For("kindConfig.useKindCluster", "Provision & use kind cluster"), true)
 	if err != nil {
 		return err
 	}
@@ -129,7 +97,7 @@ func ReadValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components.Valid
 	}
 
 	// Configure validator HelmRelease
-	if err := readHelmRelease(cfg.Validator, vc, vc.Release); err != nil {
+	if err := readHelmRelease(cfg.Validator, "helmRelease", vc, vc.Release); err != nil {
 		return err
 	}
 
@@ -143,10 +111,13 @@ func ReadValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components.Valid
 	`)
 
 	// Enable plugin(s) & read install config
-	if err := handlePlugins(vc, tc, kClient, "Install", true, pluginInstallFuncs); err != nil {
+	if err := handlePlugins(vc, tc, kClient, "Install", true, plugins.Plugin.ReadConfig); err != nil {
 		return err
 	}
 
+	if answers.NonInteractive() {
+		return nil
+	}
 	log.Header("Finalize Installation Configuration")
 	restart, err := prompts.ReadBool("Restart configuration", false)
 	if err != nil {
@@ -161,11 +132,12 @@ func ReadValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components.Valid
 			return err
 		}
 		if revisit {
-			pluginCode, err := prompts.Select("Plugin", plugins)
+			pluginCode, err := prompts.Select("Plugin", plugins.Codes())
 			if err != nil {
 				return err
 			}
-			if err := pluginInstallFuncs[pluginCode](vc, tc, kClient); err != nil {
+			p, _ := plugins.Get(pluginCode)
+			if err := p.ReadConfig(vc, tc, kClient); err != nil {
 				return err
 			}
 			continue
@@ -176,193 +148,39 @@ func ReadValidatorConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components.Valid
 	return nil
 }
 
-// handlePlugins supports three distinct logical flows:
+// handlePlugins iterates over all registered plugins and supports three distinct logical flows:
 // 1. validator install
 //   - prompt to enable plugins
-//   - verb is Install & tc.Direct will never be true, so readXYZPlugin calls are never hit
+//   - verb is Install & tc.Direct will never be true, so only Plugin.ReadConfig is called
 //
 // 2. validator check (without --direct)
 //   - no prompt to enable plugins as they're already enabled in the validator config file
-//   - verb is Enable & tc.Direct is false, so readXYZPlugin are never called, but readXYZPluginRules are
+//   - verb is Enable & tc.Direct is false, so Plugin.ReadConfig is never called, but Plugin.ReadRules is
 //
 // 3. validator check --direct
 //   - prompt to enable plugins (since we don't have a validator config file)
-//   - verb is Enable & tc.Direct is true, so readXYZPlugin and readXYZPluginRules are called
-//
-// nolint:gocyclo
-func handlePlugins(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface, verb string, enablePlugins bool, funcMap pluginFuncMap) error {
-	var err error
-
-	if enablePlugins {
-		log.Header("AWS Plugin")
-		log.InfoCLI(`
-	The AWS validator plugin reconciles AwsValidator custom resources to perform the
-	following validations against your AWS environment:
-
-	- Ensure that one or more EC2 AMI(s) exist in a particular region.
-	- Compare the IAM permissions associated with an IAM user / group / role / policy
-	  against an expected permission set.
-	- Compare the usage for a particular service quota against the active quota to
-	  avoid unexpectedly hitting quota limits.
-	- Compare the tags associated with a subnet against an expected tag set.
-		`)
-		vc.AWSPlugin.Enabled, err = prompts.ReadBool(fmt.Sprintf("%s AWS plugin", verb), true)
-		if err != nil {
-			return err
-		}
-	}
-	if vc.AWSPlugin.Enabled {
-		if tc.Direct {
-			if err = readAwsPlugin(vc, tc, kClient); err != nil {
-				return err
-			}
-		}
-		if err := funcMap[awsconsts.PluginCode](vc, tc, kClient); err != nil {
-			return err
-		}
-	}
-
-	// TODO: support image gallery rules
-	// - Verify that images in community image galleries exist.
-	if enablePlugins {
-		log.Header("Azure Plugin")
-		log.InfoCLI(`
-	The Azure validator plugin reconciles AzureValidator custom resources to perform
-	the following validations against your Azure environment:
-
-	- Compare the Azure RBAC permissions associated with a security principal against
-	  an expected permission set.
-		`)
-		vc.AzurePlugin.Enabled, err = prompts.ReadBool(fmt.Sprintf("%s Azure plugin", verb), true)
-		if err != nil {
-			return err
-		}
-	}
-	if vc.AzurePlugin.Enabled {
-		if tc.Direct {
-			if err = readAzurePlugin(vc, tc, kClient); err != nil {
-				return err
-			}
-		}
-
-		if err := funcMap[azureconsts.PluginCode](vc, tc, kClient); err != nil {
-			return err
-		}
-	}
-
-	if enablePlugins {
-		log.Header("MAAS Plugin")
-		log.InfoCLI(`
-	The MAAS validator plugin reconciles MaasValidator custom resources to perform
-	the following validation against your MAAS cluster:
-
-	- Check that a certain number of Upstream DNS servers are configured.
-	- Check that the expected Internal DNS settings are present.
-	- Check that the expected number of Machines matching a certain spec are "Ready" for use in each Availability Zone.
-	- Check that all required OS Images are "Synced" to the cluster.
-		`)
-		vc.MaasPlugin.Enabled, err = prompts.ReadBool(fmt.Sprintf("%s MAAS plugin", verb), true)
-		if err != nil {
-			return err
-		}
-	}
-	if vc.MaasPlugin.Enabled {
-		if tc.Direct {
-			if err = readMaasPlugin(vc, tc, kClient); err != nil {
-				return err
-			}
-		}
-		if err := funcMap[maasconsts.PluginCode](vc, tc, kClient); err != nil {
-			return err
-		}
-	}
-
-	if enablePlugins {
-		log.Header("Network Plugin")
-		log.InfoCLI(`
-	The Network validator plugin reconciles NetworkValidator custom resources to perform
-	the following validations against your network:
-
-	- Execute DNS lookups.
-	- Execute ICMP pings.
-	- Validate TCP connections to arbitrary host + port(s).
-	- Check each IP in an IP range to ensure that they're all unallocated.
-	- Check that the default NIC has an MTU greater than or equal to a specified value.
-	- Check that each file in a list of URLs is available and publicly accessible
-	  via an HTTP HEAD request, with optional basic auth.
-		`)
-		vc.NetworkPlugin.Enabled, err = prompts.ReadBool(fmt.Sprintf("%s Network plugin", verb), true)
-		if err != nil {
-			return err
-		}
-	}
-	if vc.NetworkPlugin.Enabled {
-		if tc.Direct {
-			if err := exec.CheckBinaries([]exec.Binary{exec.NslookupBin, exec.PingBin}); err != nil {
-				return err
-			}
-			if err = readNetworkPlugin(vc, tc, kClient); err != nil {
-				return err
-			}
-		}
-		if err := funcMap[netconsts.PluginCode](vc, tc, kClient); err != nil {
-			return err
-		}
-	}
-
-	if enablePlugins {
-		log.Header("OCI Plugin")
-		log.InfoCLI(`
-	The OCI validator plugin reconciles OciValidator custom resources to perform the
-	following validations against your OCI registry:
-
-	- Validate OCI registry authentication.
-	- Validate the existence of arbitrary OCI artifacts, with optional signature
-	  verification.
-	- Validate downloading arbitrary OCI artifacts.
-		`)
-		vc.OCIPlugin.Enabled, err = prompts.ReadBool(fmt.Sprintf("%s OCI plugin", verb), true)
-		if err != nil {
-			return err
-		}
-	}
-	if vc.OCIPlugin.Enabled {
-		if tc.Direct {
-			if err = readOciPlugin(vc, tc, kClient); err != nil {
+//   - verb is Enable & tc.Direct is true, so Plugin.ReadConfig and Plugin.ReadRules are called
+func handlePlugins(vc *components.ValidatorConfig, tc *cfg.TaskConfig, kClient kubernetes.Interface, verb string, enablePlugins bool, read pluginReadFunc) error {
+	for _, p := range plugins.All() {
+		if enablePlugins {
+			log.Header(fmt.Sprintf("%s Plugin", p.Code()))
+			log.InfoCLI(p.Description())
+			label := fmt.Sprintf("%s %s plugin", verb, p.Code())
+			enabled, err := prompts.ReadBool(answers.For(fmt.Sprintf("plugins.%s.enabled", p.Key()), label), true)
+			if err != nil {
 				return err
 			}
+			p.SetEnabled(vc, enabled)
 		}
-		if err := funcMap[ociconsts.PluginCode](vc, tc, kClient); err != nil {
-			return err
-		}
-	}
-
-	if enablePlugins {
-		log.Header("vSphere Plugin")
-		log.InfoCLI(`
-	The vSphere validator plugin reconciles VsphereValidator custom resources to perform
-	the following validations against your vSphere environment:
-
-	- Compare the privileges associated with a user against an expected privileges set.
-	- Compare the privileges associated with a user against an expected privileges set
-	  on a particular entity (cluster, resourcepool, folder, vapp, host).
-	- Verify availability of compute resources on an ESXi host, resourcepool, or cluster.
-	- Compare the tags associated with a datacenter, cluster, host, vm, resourcepool or vm
-	  against an expected tag set.
-	- Verify that a set of ESXi hosts have valid NTP configuration.
-		`)
-		vc.VspherePlugin.Enabled, err = prompts.ReadBool(fmt.Sprintf("%s vSphere plugin", verb), true)
-		if err != nil {
-			return err
+		if !p.Enabled(vc) {
+			continue
 		}
-	}
-	if vc.VspherePlugin.Enabled {
 		if tc.Direct {
-			if err = readVspherePlugin(vc, tc, kClient); err != nil {
+			if err := p.ReadConfig(vc, tc, kClient); err != nil {
 				return err
 			}
 		}
-		if err := funcMap[vsphereconsts.PluginCode](vc, tc, kClient); err != nil {
+		if err := read(p, vc, tc, kClient); err != nil {
 			return err
 		}
 	}
@@ -422,10 +240,13 @@ func ReadValidatorPluginConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components
 
 	// If direct, enable plugin(s) & read partial install config & rule config.
 	// If not direct, read rule config for enabled plugin(s).
-	if err := handlePlugins(vc, tc, kClient, "Enable", enablePlugins, pluginRuleFuncs); err != nil {
+	if err := handlePlugins(vc, tc, kClient, "Enable", enablePlugins, plugins.Plugin.ReadRules); err != nil {
 		return err
 	}
 
+	if answers.NonInteractive() {
+		return nil
+	}
 	log.Header("Finalize Plugin Rule Configuration")
 	restart, err := prompts.ReadBool("Restart configuration", false)
 	if err != nil {
@@ -440,11 +261,12 @@ func ReadValidatorPluginConfig(c *cfg.Config, tc *cfg.TaskConfig, vc *components
 			return err
 		}
 		if revisit {
-			pluginCode, err := prompts.Select("Plugin", plugins)
+			pluginCode, err := prompts.Select("Plugin", plugins.Codes())
 			if err != nil {
 				return err
 			}
-			if err := pluginRuleFuncs[pluginCode](vc, tc, kClient); err != nil {
+			p, _ := plugins.Get(pluginCode)
+			if err := p.ReadRules(vc, tc, kClient); err != nil {
 				return err
 			}
 			continue
@@ -483,31 +305,9 @@ func UpdateValidatorPluginCredentials(c *components.ValidatorConfig, tc *cfg.Tas
 			return err
 		}
 	}
-	if c.AWSPlugin != nil && c.AWSPlugin.Enabled {
-		if err := readAwsCredentials(c.AWSPlugin, tc, kClient); err != nil {
-			return fmt.Errorf("failed to update AWS credentials: %w", err)
-		}
-	}
-	if c.AzurePlugin != nil && c.AzurePlugin.Enabled {
-		if err := readAzureCredentials(c.AzurePlugin, tc, kClient); err != nil {
-			return fmt.Errorf("failed to update Azure credentials: %w", err)
-		}
-	}
-	if c.MaasPlugin != nil && c.MaasPlugin.Enabled {
-		if err := readMaasCredentials(c.MaasPlugin, tc, kClient); err != nil {
-			return fmt.Errorf("failed to update MAAS credentials: %w", err)
-		}
-	}
-	if c.OCIPlugin != nil && c.OCIPlugin.Enabled {
-		for _, secret := range c.OCIPlugin.Secrets {
-			if err := readOciSecret(secret); err != nil {
-				return fmt.Errorf("failed to update OCI secret: %w", err)
-			}
-		}
-	}
-	if c.VspherePlugin != nil && c.VspherePlugin.Enabled {
-		if err := readVsphereCredentials(c.VspherePlugin, tc, kClient); err != nil {
-			return fmt.Errorf("failed to update vSphere credentials: %w", err)
+	for _, p := range plugins.Enabled(c) {
+		if err := p.ReadCredentials(c, tc, kClient); err != nil {
+			return fmt.Errorf("failed to update %s credentials: %w", p.Code(), err)
 		}
 	}
 	return nil
@@ -528,7 +328,7 @@ func k8sClientFromConfig(c *components.ValidatorConfig) (kubernetes.Interface, e
 }
 
 func readRegistryConfig(vc *components.ValidatorConfig) (err error) {
-	airgapped, err := prompts.ReadBool("Configure Hauler for air-gapped installation", false)
+	airgapped, err := prompts.ReadBool(answers.For("registryConfig.registry.isAirgapped", "Configure Hauler for air-gapped installation"), false)
 	if err != nil {
 		return err
 	}
@@ -543,7 +343,7 @@ func readRegistryConfig(vc *components.ValidatorConfig) (err error) {
 		return nil
 	}
 
-	privateRegistry, err := prompts.ReadBool("Configure private OCI registry", false)
+	privateRegistry, err := prompts.ReadBool(answers.For("registryConfig.enabled", "Configure private OCI registry"), false)
 	if err != nil {
 		return err
 	}
@@ -561,7 +361,7 @@ func readRegistryConfig(vc *components.ValidatorConfig) (err error) {
 	if vc.ImageRegistry != "" {
 		imageRegistry = vc.ImageRegistry
 	}
-	vc.ImageRegistry, err = prompts.ReadText("Validator image registry", imageRegistry, false, -1)
+	vc.ImageRegistry, err = prompts.ReadText(answers.For("imageRegistry", "Validator image registry"), imageRegistry, false, -1)
 	if err != nil {
 		return err
 	}
@@ -570,10 +370,14 @@ func readRegistryConfig(vc *components.ValidatorConfig) (err error) {
 }
 
 func readProxyConfig(vc *components.ValidatorConfig) error {
-	vc.ProxyConfig.Env.PodCIDR = &cfg.DefaultPodCIDR
-	vc.ProxyConfig.Env.ServiceIPRange = &cfg.DefaultServiceIPRange
+	if vc.ProxyConfig.Env.PodCIDR == nil {
+		vc.ProxyConfig.Env.PodCIDR = &cfg.DefaultPodCIDR
+	}
+	if vc.ProxyConfig.Env.ServiceIPRange == nil {
+		vc.ProxyConfig.Env.ServiceIPRange = &cfg.DefaultServiceIPRange
+	}
 
-	configureProxy, err := prompts.ReadBool("Configure an HTTP proxy", false)
+	configureProxy, err := prompts.ReadBool(answers.For("proxyConfig.enabled", "Configure an HTTP proxy"), false)
 	if err != nil {
 		return err
 	}
@@ -591,7 +395,7 @@ func readProxyConfig(vc *components.ValidatorConfig) error {
 
 func readSinkConfig(vc *components.ValidatorConfig, k8sClient kubernetes.Interface) error {
 	var err error
-	vc.SinkConfig.Enabled, err = prompts.ReadBool("Configure a sink", false)
+	vc.SinkConfig.Enabled, err = prompts.ReadBool(answers.For("sinkConfig.enabled", "Configure a sink"), false)
 	if err != nil {
 		return err
 	}
@@ -599,7 +403,7 @@ func readSinkConfig(vc *components.ValidatorConfig, k8sClient kubernetes.Interfa
 		return nil
 	}
 
-	sinkType, err := prompts.Select("Sink Type", sinkTypes())
+	sinkType, err := prompts.Select(answers.For("sinkConfig.type", "Sink Type"), sinkTypes())
 	if err != nil {
 		return err
 	}
@@ -615,12 +419,12 @@ func readSinkConfig(vc *components.ValidatorConfig, k8sClient kubernetes.Interfa
 	If using an existing secret, it must contain the following keys: %+v.
 	`, cfg.Validator, keys,
 		)
-		vc.SinkConfig.CreateSecret, err = prompts.ReadBool("Create sink credential secret", true)
+		vc.SinkConfig.CreateSecret, err = prompts.ReadBool(answers.For("sinkConfig.createSecret", "Create sink credential secret"), true)
 		if err != nil {
 			return err
 		}
 		if !vc.SinkConfig.CreateSecret {
-			secret, err := services.ReadSecret(k8sClient, cfg.Validator, false, keys)
+			secret, err := services.ReadSecret(k8sClient, cfg.Validator, "sinkConfig.secretName", false, keys)
 			if err != nil {
 				return err
 			}
@@ -629,7 +433,7 @@ func readSinkConfig(vc *components.ValidatorConfig, k8sClient kubernetes.Interfa
 		}
 	}
 
-	vc.SinkConfig.SecretName, err = prompts.ReadText("Sink credentials secret name", "sink-creds", false, -1)
+	vc.SinkConfig.SecretName, err = prompts.ReadText(answers.For("sinkConfig.secretName", "Sink credentials secret name"), "sink-creds", false, -1)
 	if err != nil {
 		return err
 	}
@@ -646,30 +450,37 @@ func readSinkConfig(vc *components.ValidatorConfig, k8sClient kubernetes.Interfa
 		}
 
 		endpoint, err := prompts.ReadURL(
-			"Alertmanager endpoint", vc.SinkConfig.Values["endpoint"], "Alertmanager endpoint must be a valid URL", false,
+			answers.For("sinkConfig.values.endpoint", "Alertmanager endpoint"), vc.SinkConfig.Values["endpoint"], "Alertmanager endpoint must be a valid URL", false,
 		)
 		if err != nil {
 			return err
 		}
 		vc.SinkConfig.Values["endpoint"] = endpoint
 
-		insecure, err := prompts.ReadBool("Allow Insecure Connection (Bypass x509 Verification)", true)
+		insecure, err := prompts.ReadBool(answers.For("sinkConfig.values.insecureSkipVerify", "Allow Insecure Connection (Bypass x509 Verification)"), true)
 		if err != nil {
 			return err
 		}
 		vc.SinkConfig.Values["insecureSkipVerify"] = strconv.FormatBool(insecure)
 
 		if !insecure {
-			var caCertData []byte
-			_, _, caCertData, err = prompts.ReadCACert("Alertmanager CA certificate filepath", vc.SinkConfig.Values["caCert"], "")
+			// the CA certificate is answered with its data, rather than its path
+			skip, err := answers.Skip("sinkConfig.values.caCert", "Alertmanager CA certificate filepath")
 			if err != nil {
 				return err
 			}
-			vc.SinkConfig.Values["caCert"] = string(caCertData)
+			if !skip {
+				var caCertData []byte
+				_, _, caCertData, err = prompts.ReadCACert("Alertmanager CA certificate filepath", vc.SinkConfig.Values["caCert"], "")
+				if err != nil {
+					return err
+				}
+				vc.SinkConfig.Values["caCert"] = string(caCertData)
+			}
 		}
 
 		username, password, err := prompts.ReadBasicCreds(
-			"Alertmanager Username", "Alertmanager Password",
+			answers.For("sinkConfig.values.username", "Alertmanager Username"), answers.For("sinkConfig.values.password", "Alertmanager Password"),
 			vc.SinkConfig.Values["username"], vc.SinkConfig.Values["password"], true, false,
 		)
 		if err != nil {
@@ -686,13 +497,13 @@ func readSinkConfig(vc *components.ValidatorConfig, k8sClient kubernetes.Interfa
 			}
 		}
 
-		botToken, err := prompts.ReadPassword("Bot token", vc.SinkConfig.Values["apiToken"], false, -1)
+		botToken, err := prompts.ReadPassword(answers.For("sinkConfig.values.apiToken", "Bot token"), vc.SinkConfig.Values["apiToken"], false, -1)
 		if err != nil {
 			return err
 		}
 		vc.SinkConfig.Values["apiToken"] = botToken
 
-		channelID, err := prompts.ReadText("Channel ID", vc.SinkConfig.Values["channelID"], false, -1)
+		channelID, err := prompts.ReadText(answers.For("sinkConfig.values.channelID", "Channel ID"), vc.SinkConfig.Values["channelID"], false, -1)
 		if err != nil {
 			return err
 		}
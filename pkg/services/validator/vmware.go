@@ -27,19 +27,22 @@ import (
 	log "github.com/validator-labs/validatorctl/pkg/logging"
 	"github.com/validator-labs/validatorctl/pkg/services"
 	"github.com/validator-labs/validatorctl/pkg/services/clouds"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 	"github.com/validator-labs/validatorctl/pkg/utils/embed"
 )
 
+const vsphereAccountKey = vspherePath + ".validator.auth.account"
+
 var (
 	vSphereSecretName = "vsphere-creds" //#nosec G101
 	dataCenter        = "Datacenter"
 )
 
 func readVspherePlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sClient kubernetes.Interface) error {
-	c := vc.VspherePlugin
+	c := vsphereConfig(vc)
 
 	if !tc.Direct {
-		if err := readHelmRelease(cfg.ValidatorPluginVsphere, vc, c.Release); err != nil {
+		if err := readHelmRelease(cfg.ValidatorPluginVsphere, vspherePath+".helmRelease", vc, c.Release); err != nil {
 			return fmt.Errorf("failed to read Helm release: %w", err)
 		}
 	}
@@ -52,7 +55,7 @@ func readVspherePlugin(vc *components.ValidatorConfig, tc *cfg.TaskConfig, k8sCl
 
 func readVspherePluginRules(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _ kubernetes.Interface) error {
 	log.Header("vSphere Plugin Rule Configuration")
-	c := vc.VspherePlugin
+	c := vsphereConfig(vc)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -65,7 +68,7 @@ func readVspherePluginRules(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _
 	if c.Validator.Datacenter != "" {
 		dataCenter = c.Validator.Datacenter
 	}
-	c.Validator.Datacenter, err = prompts.ReadText("Datacenter", dataCenter, false, -1)
+	c.Validator.Datacenter, err = prompts.ReadText(answers.For(vspherePath+".validator.datacenter", "Datacenter"), dataCenter, false, -1)
 	if err != nil {
 		return err
 	}
@@ -94,7 +97,9 @@ func readVspherePluginRules(vc *components.ValidatorConfig, _ *cfg.TaskConfig, _
 
 func readVsphereCredentials(c *components.VspherePluginConfig, tc *cfg.TaskConfig, k8sClient kubernetes.Interface) error {
 	var err error
-	c.Validator.Auth.Account = &vcenter.Account{}
+	if c.Validator.Auth.Account == nil {
+		c.Validator.Auth.Account = &vcenter.Account{}
+	}
 	// always create vSphere credential secret if creating a new kind cluster
 	createSecret := true
 
@@ -104,7 +109,7 @@ func readVsphereCredentials(c *components.VspherePluginConfig, tc *cfg.TaskConfi
 	If using an existing secret, it must contain the following keys: %+v.
 	`, cfg.Validator, cfg.ValidatorPluginVsphereKeys,
 		)
-		createSecret, err = prompts.ReadBool("Create vSphere credential secret", true)
+		createSecret, err = prompts.ReadBool(answers.For(vsphereAccountKey, "Create vSphere credential secret"), true)
 		if err != nil {
 			return fmt.Errorf("failed to create vSphere credential secret: %w", err)
 		}
@@ -115,16 +120,16 @@ func readVsphereCredentials(c *components.VspherePluginConfig, tc *cfg.TaskConfi
 			vSphereSecretName = c.Validator.Auth.SecretName
 		}
 		if !tc.Direct {
-			c.Validator.Auth.SecretName, err = prompts.ReadText("vSphere credentials secret name", vSphereSecretName, false, -1)
+			c.Validator.Auth.SecretName, err = prompts.ReadText(answers.For(vspherePath+".validator.auth.secretName", "vSphere credentials secret name"), vSphereSecretName, false, -1)
 			if err != nil {
 				return err
 			}
 		}
-		if err := clouds.ReadVsphereAccountProps(c.Validator.Auth.Account); err != nil {
+		if err := clouds.ReadVsphereAccountProps(vsphereAccountKey, c.Validator.Auth.Account); err != nil {
 			return err
 		}
 	} else {
-		secret, err := services.ReadSecret(k8sClient, cfg.Validator, false, cfg.ValidatorPluginVsphereKeys)
+		secret, err := services.ReadSecret(k8sClient, cfg.Validator, vspherePath+".validator.auth.secretName", false, cfg.ValidatorPluginVsphereKeys)
 		if err != nil {
 			return err
 		}
@@ -159,7 +164,8 @@ func configureNtpRules(ctx context.Context, c *components.VspherePluginConfig, d
 	If enabled, you will be prompted to select one or more of ESXi hosts.
 	`)
 
-	validateNtp, err := prompts.ReadBool("Enable NTP validation for ESXi host(s)", true)
+	rulesKey := vspherePath + ".validator.ntpValidationRules"
+	validateNtp, err := prompts.ReadBool(answers.For(rulesKey, "Enable NTP validation for ESXi host(s)"), true)
 	if err != nil {
 		return err
 	}
@@ -167,6 +173,9 @@ func configureNtpRules(ctx context.Context, c *components.VspherePluginConfig, d
 		c.Validator.NTPValidationRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.NTPValidationRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.NTPValidationRules {
 		r := r
 		if err := readNtpRule(ctx, c, &r, driver, i, ruleNames); err != nil {
@@ -366,7 +375,8 @@ func configurePrivilegeRules(ctx context.Context, c *components.VspherePluginCon
 	privileges with respect to a specific vSphere resource.
 	`)
 
-	validatePrivileges, err := prompts.ReadBool("Enable privilege validation", true)
+	rulesKey := vspherePath + ".validator.privilegeValidationRules"
+	validatePrivileges, err := prompts.ReadBool(answers.For(rulesKey, "Enable privilege validation"), true)
 	if err != nil {
 		return err
 	}
@@ -374,6 +384,9 @@ func configurePrivilegeRules(ctx context.Context, c *components.VspherePluginCon
 		c.Validator.PrivilegeValidationRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.PrivilegeValidationRules, ruleNames) {
+		return nil
+	}
 
 	for i, r := range c.Validator.PrivilegeValidationRules {
 		r := r
@@ -507,7 +520,8 @@ func configureResourceRequirementRules(ctx context.Context, c *components.Vspher
 	a vSphere Datacenter or Cluster for a configurable number of VMs with specific CPU, RAM, and Storage minimums.
 	`)
 
-	validateResourceRequirements, err := prompts.ReadBool("Enable resource requirement validation", true)
+	rulesKey := vspherePath + ".validator.computeResourceRules"
+	validateResourceRequirements, err := prompts.ReadBool(answers.For(rulesKey, "Enable resource requirement validation"), true)
 	if err != nil {
 		return err
 	}
@@ -515,6 +529,9 @@ func configureResourceRequirementRules(ctx context.Context, c *components.Vspher
 		c.Validator.ComputeResourceRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.ComputeResourceRules, ruleNames) {
+		return nil
+	}
 	for i, r := range c.Validator.ComputeResourceRules {
 		r := r
 		if err := readResourceRequirementRule(ctx, c, &r, driver, i, ruleNames); err != nil {
@@ -654,7 +671,8 @@ func configureVsphereTagRules(ctx context.Context, c *components.VspherePluginCo
 	Tag validation ensures that a specific tag is present on a particular vSphere resource.
 	`)
 
-	validateTags, err := prompts.ReadBool("Enable tag validation", true)
+	rulesKey := vspherePath + ".validator.tagValidationRules"
+	validateTags, err := prompts.ReadBool(answers.For(rulesKey, "Enable tag validation"), true)
 	if err != nil {
 		return err
 	}
@@ -662,6 +680,9 @@ func configureVsphereTagRules(ctx context.Context, c *components.VspherePluginCo
 		c.Validator.TagValidationRules = nil
 		return nil
 	}
+	if answeredRules(rulesKey, c.Validator.TagValidationRules, ruleNames) {
+		return nil
+	}
 
 	for i, r := range c.Validator.TagValidationRules {
 		r := r
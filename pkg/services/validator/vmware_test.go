@@ -22,13 +22,15 @@ var vSphereDummyConfig = &components.ValidatorConfig{
 	RegistryConfig: &components.RegistryConfig{
 		Enabled: false,
 	},
-	VspherePlugin: &components.VspherePluginConfig{
-		Release: &v1alpha1.HelmRelease{
-			Chart: v1alpha1.HelmChart{},
-		},
-		Validator: &vsphereapi.VsphereValidatorSpec{
-			Auth: vsphereapi.VsphereAuth{
-				Account: &vcenter.Account{},
+	Plugins: components.PluginConfigs{
+		vsphereKey: &components.VspherePluginConfig{
+			Release: &v1alpha1.HelmRelease{
+				Chart: v1alpha1.HelmChart{},
+			},
+			Validator: &vsphereapi.VsphereValidatorSpec{
+				Auth: vsphereapi.VsphereAuth{
+					Account: &vcenter.Account{},
+				},
 			},
 		},
 	},
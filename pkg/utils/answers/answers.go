@@ -0,0 +1,489 @@
+// Package answers answers configuration prompts using predefined answers, enabling validatorctl
+// to be configured without a TTY.
+//
+// Answers are a partial validator configuration file, keyed by configuration path, e.g.,
+// plugins.aws.validator.defaultRegion. They are applied to the validator configuration before
+// any prompts, so that prompts default to them. Each prompt which configures a path is associated
+// with it via For, and is answered using the answer for the path, if any. Sections which are
+// answered as a whole, e.g., a plugin's list of rules, are skipped via Skip.
+package answers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spectrocloud-labs/prompts-tui/prompts"
+	"github.com/spectrocloud-labs/prompts-tui/prompts/mocks"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/schema"
+)
+
+// MissingAnswerError is returned in non-interactive mode when a prompt has no answer
+type MissingAnswerError struct {
+	Key    string
+	Prompt string
+}
+
+// Error implements error
+func (e MissingAnswerError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("no answer provided for prompt %q, which can't be answered via --set or --values", e.Prompt)
+	}
+	return fmt.Sprintf("no answer provided for %s (prompt: %q). Provide one via --set %s=<value> or --values", e.Key, e.Prompt, e.Key)
+}
+
+// Answers are a partial validator configuration, keyed by configuration path
+type Answers struct {
+	root *yaml.Node
+}
+
+// Load reads answers from YAML files, followed by path=value pairs, e.g.,
+// kindConfig.useKindCluster=true. Answers in later sources take precedence.
+// Each source is validated against the validator configuration schema.
+//
+// Values provided via path=value are parsed as YAML, e.g., plugins.aws.validator.iamRoleRules=[]
+// answers with an empty list. Quote a value to provide it as a string.
+func Load(s *schema.Schema, files, sets []string) (*Answers, error) {
+	a := &Answers{root: newMapping()}
+
+	for _, f := range files {
+		bytes, err := os.ReadFile(f) // #nosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answers file: %w", err)
+		}
+		root, err := schema.Parse(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse answers file %s: %w", f, err)
+		}
+		if root.Kind == 0 {
+			continue
+		}
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("invalid answers file %s: expected a mapping of configuration paths to answers", f)
+		}
+		if errs := schema.Validate(s, root); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid answers file %s: %w", f, joinErrors(errs))
+		}
+		merge(a.root, root)
+	}
+
+	for _, kv := range sets {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid answer %q, expected path=value", kv)
+		}
+		// answers are set in place, rather than merged, so that list items can be answered field by field
+		if err := set(a.root, schema.SplitPath(k), parseValue(v)); err != nil {
+			return nil, fmt.Errorf("invalid answer %q: %w", kv, err)
+		}
+		if errs := schema.Validate(s, a.root); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid answer %q: %s: %s", kv, errs[0].Path, errs[0].Message)
+		}
+	}
+
+	return a, nil
+}
+
+func newMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func joinErrors(errs []schema.Error) error {
+	joined := make([]error, 0, len(errs))
+	for _, e := range errs {
+		joined = append(joined, e)
+	}
+	return errors.Join(joined...)
+}
+
+// parseValue parses the value of a path=value pair as YAML, falling back to a string if it isn't valid YAML
+func parseValue(v string) *yaml.Node {
+	n := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(v), n); err != nil || len(n.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	}
+	return n.Content[0]
+}
+
+// set sets the node at a path within a YAML document, creating any missing mappings or list items
+func set(n *yaml.Node, segs []string, v *yaml.Node) error {
+	if len(segs) == 0 {
+		*n = *v
+		return nil
+	}
+	seg := segs[0]
+	if n.Kind == 0 || isNull(n) {
+		*n = *newMapping()
+		if _, err := strconv.Atoi(seg); err == nil {
+			*n = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		if child := mappingValue(n, seg); child != nil {
+			return set(child, segs[1:], v)
+		}
+		k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: seg}
+		child := &yaml.Node{}
+		n.Content = append(n.Content, k, child)
+		return set(child, segs[1:], v)
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i > len(n.Content) {
+			return fmt.Errorf("invalid list index %s", seg)
+		}
+		if i == len(n.Content) {
+			n.Content = append(n.Content, &yaml.Node{})
+		}
+		return set(n.Content[i], segs[1:], v)
+	}
+	return fmt.Errorf("%s is not a mapping or a list", seg)
+}
+
+// mappingValue returns the value of a key within a mapping, if any
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// merge merges src into dst. Mappings are merged recursively. All other nodes are replaced.
+func merge(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		*dst = *src
+		return
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		k, v := src.Content[i], src.Content[i+1]
+		if d := mappingValue(dst, k.Value); d != nil {
+			merge(d, v)
+			continue
+		}
+		dst.Content = append(dst.Content, k, v)
+	}
+}
+
+// Lookup returns the answer for a configuration path, e.g., plugins.aws.validator.iamRoleRules[0].iamRoleName,
+// and whether the path is answered
+func (a *Answers) Lookup(key string) (*yaml.Node, bool) {
+	if a == nil {
+		return nil, false
+	}
+	return schema.Lookup(a.root, key)
+}
+
+// Apply applies the answers to a validator configuration, overriding any existing values.
+// Null answers accept a prompt's default, so they're not applied.
+func (a *Answers) Apply(v any) error {
+	if a == nil {
+		return nil
+	}
+	bytes, err := yaml.Marshal(withoutNulls(a.root))
+	if err != nil {
+		return fmt.Errorf("failed to marshal answers: %w", err)
+	}
+	if err := yamlv2.Unmarshal(bytes, v); err != nil {
+		return fmt.Errorf("failed to apply answers: %w", err)
+	}
+	return nil
+}
+
+// withoutNulls returns a copy of a mapping without any null values
+func withoutNulls(n *yaml.Node) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return n
+	}
+	c := *n
+	c.Content = make([]*yaml.Node, 0, len(n.Content))
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if !isNull(n.Content[i+1]) {
+			c.Content = append(c.Content, n.Content[i], withoutNulls(n.Content[i+1]))
+		}
+	}
+	return &c
+}
+
+var (
+	// installed are the answers used to answer prompts, if any
+	installed *Answers
+	// nonInteractive is true if prompts without an answer fail rather than waiting for user input
+	nonInteractive bool
+	// pending maps the label of each prompt which is about to be displayed to its configuration path
+	pending = make(map[string]string)
+)
+
+// Install replaces the TUI and the text editor used by the prompts package with ones which use the provided answers.
+// In non-interactive mode, prompts without an answer fail rather than waiting for user input. Installing answers
+// again replaces the installed answers.
+func Install(a *Answers, ni bool) {
+	installed, nonInteractive = a, ni
+
+	if _, ok := prompts.Tui.(*TUI); ok {
+		return
+	}
+	prompts.Tui = NewTUI(prompts.Tui)
+	prompts.GetCmdExecutor = NewEditorExecutorFunc(prompts.GetCmdExecutor)
+}
+
+// Apply applies the installed answers, if any, to a validator configuration
+func Apply(v any) error {
+	return installed.Apply(v)
+}
+
+// NonInteractive returns true if prompts without an answer fail rather than waiting for user input
+func NonInteractive() bool {
+	return nonInteractive
+}
+
+// For associates the prompt with the provided label with a configuration path, e.g., kindConfig.useKindCluster,
+// so that it's answered using the answer for the path. It returns the label, so that it can be passed to the prompt.
+//
+// A bool prompt may be associated with a path which isn't a bool, e.g., a list of rules which is only
+// configured if the prompt is answered yes, in which case it's answered yes if the path's answer is non-empty.
+func For(key, label string) string {
+	if _, ok := prompts.Tui.(*TUI); ok {
+		pending[label] = key
+	}
+	return label
+}
+
+// Answered returns true if a configuration path is answered, in which case the answer
+// has already been applied to the validator configuration
+func Answered(key string) bool {
+	_, ok := installed.Lookup(key)
+	return ok
+}
+
+// Skip returns true if the prompt(s) which configure a path can be skipped, because the path is answered
+// and the answer has already been applied to the validator configuration. In non-interactive mode,
+// a MissingAnswerError is returned if the path isn't answered.
+func Skip(key, prompt string) (bool, error) {
+	if Answered(key) {
+		log.Debug("Using answer for %s", key)
+		return true, nil
+	}
+	if nonInteractive {
+		return false, MissingAnswerError{Key: key, Prompt: prompt}
+	}
+	return false, nil
+}
+
+// TUI answers prompts using the installed answers. Prompts without an answer are delegated to
+// the wrapped TUI, unless non-interactive, in which case a MissingAnswerError is returned.
+type TUI struct {
+	next prompts.TUI
+}
+
+// NewTUI returns a new TUI
+func NewTUI(next prompts.TUI) *TUI {
+	return &TUI{next: next}
+}
+
+// answer returns the answer for a prompt, if any. A nil answer indicates that the prompt should
+// be delegated to the wrapped TUI.
+func (t *TUI) answer(prompt string) (*yaml.Node, string, error) {
+	key, ok := pending[prompt]
+	delete(pending, prompt)
+	if ok && key != "" {
+		if n, ok := installed.Lookup(key); ok {
+			log.Debug("Using answer for %s", key)
+			return n, key, nil
+		}
+	}
+	if nonInteractive {
+		return nil, key, MissingAnswerError{Key: key, Prompt: prompt}
+	}
+	return nil, key, nil
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// GetBool implements prompts.TUI
+func (t *TUI) GetBool(prompt string, defaultVal bool) (bool, error) {
+	n, _, err := t.answer(prompt)
+	if err != nil {
+		return false, err
+	} else if n == nil {
+		return t.next.GetBool(prompt, defaultVal)
+	}
+	switch {
+	case isNull(n):
+		return defaultVal, nil
+	case n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode:
+		return len(n.Content) > 0, nil
+	}
+	switch strings.ToLower(n.Value) {
+	case "y", "yes", "on":
+		return true, nil
+	case "n", "no", "off":
+		return false, nil
+	}
+	if b, err := strconv.ParseBool(n.Value); err == nil {
+		return b, nil
+	}
+	// answers are validated against the schema on load, so this is a path which isn't a bool
+	return n.Value != "", nil
+}
+
+// GetText implements prompts.TUI. A list answer is joined by commas.
+func (t *TUI) GetText(prompt, defaultVal, mask string, optional bool, validate func(string) error) (string, error) {
+	n, key, err := t.answer(prompt)
+	if err != nil {
+		return "", err
+	} else if n == nil {
+		return t.next.GetText(prompt, defaultVal, mask, optional, validate)
+	}
+	s := defaultVal
+	if !isNull(n) {
+		values, err := scalars(n, key)
+		if err != nil {
+			return "", err
+		}
+		s = strings.Join(values, ",")
+	}
+	if validate == nil {
+		return s, nil
+	}
+	if err := validate(s); err != nil {
+		return "", fmt.Errorf("invalid answer for %s: %w", key, err)
+	}
+	return s, nil
+}
+
+// GetTextSlice implements prompts.TUI. A string answer is split on commas and newlines.
+func (t *TUI) GetTextSlice(prompt, defaultVal string, optional bool, validate func([]string) error) ([]string, error) {
+	n, key, err := t.answer(prompt)
+	if err != nil {
+		return nil, err
+	} else if n == nil {
+		return t.next.GetTextSlice(prompt, defaultVal, optional, validate)
+	}
+	values := split(defaultVal)
+	if !isNull(n) {
+		if values, err = scalars(n, key); err != nil {
+			return nil, err
+		}
+	}
+	if validate == nil {
+		return values, nil
+	}
+	if err := validate(values); err != nil {
+		return nil, fmt.Errorf("invalid answer for %s: %w", key, err)
+	}
+	return values, nil
+}
+
+// GetSelection implements prompts.TUI. Answers are matched to options case-insensitively.
+func (t *TUI) GetSelection(prompt string, options []string) (string, error) {
+	n, key, err := t.answer(prompt)
+	if err != nil {
+		return "", err
+	} else if n == nil {
+		return t.next.GetSelection(prompt, options)
+	}
+	return selection(n.Value, options, key)
+}
+
+// GetMultiSelection implements prompts.TUI. Answers are matched to options case-insensitively.
+func (t *TUI) GetMultiSelection(prompt string, options []string, minSelections int) ([]string, error) {
+	n, key, err := t.answer(prompt)
+	if err != nil {
+		return nil, err
+	} else if n == nil {
+		return t.next.GetMultiSelection(prompt, options, minSelections)
+	}
+	values, err := scalars(n, key)
+	if err != nil {
+		return nil, err
+	}
+	selections := make([]string, 0, len(values))
+	for _, v := range values {
+		s, err := selection(v, options, key)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+	if len(selections) < minSelections {
+		return nil, fmt.Errorf("invalid answer for %s: at least %d selection(s) required", key, minSelections)
+	}
+	return selections, nil
+}
+
+// scalars returns the values of a scalar answer, split on commas and newlines, or of a list of scalars
+func scalars(n *yaml.Node, key string) ([]string, error) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if isNull(n) {
+			return []string{}, nil
+		}
+		return split(n.Value), nil
+	case yaml.SequenceNode:
+		values := make([]string, 0, len(n.Content))
+		for _, e := range n.Content {
+			if e.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("invalid answer for %s: expected a list of strings", key)
+			}
+			values = append(values, e.Value)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("invalid answer for %s: expected a string or a list of strings", key)
+}
+
+func selection(s string, options []string, key string) (string, error) {
+	i := slices.IndexFunc(options, func(o string) bool { return strings.EqualFold(o, s) })
+	if i < 0 {
+		return "", fmt.Errorf("invalid answer for %s: %q is not one of %v", key, s, options)
+	}
+	return options[i], nil
+}
+
+func split(s string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if v := strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// failedExecutor fails to open a text editor
+type failedExecutor struct {
+	err error
+}
+
+// Start implements mocks.CommandExecutor
+func (e *failedExecutor) Start() error {
+	return e.err
+}
+
+// Wait implements mocks.CommandExecutor
+func (e *failedExecutor) Wait() error {
+	return e.err
+}
+
+// NewEditorExecutorFunc returns a function which fails to open a text editor in non-interactive mode,
+// and otherwise delegates to the provided function. Text editor prompts are answered by skipping them
+// via Skip.
+func NewEditorExecutorFunc(next func(string, string) mocks.CommandExecutor) func(string, string) mocks.CommandExecutor {
+	return func(editor, filename string) mocks.CommandExecutor {
+		if nonInteractive {
+			return &failedExecutor{err: MissingAnswerError{Prompt: "text editor"}}
+		}
+		return next(editor, filename)
+	}
+}
@@ -0,0 +1,222 @@
+package answers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spectrocloud-labs/prompts-tui/prompts"
+	"github.com/spectrocloud-labs/prompts-tui/prompts/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/validator-labs/validatorctl/pkg/utils/schema"
+)
+
+type testRule struct {
+	Name  string   `yaml:"name"`
+	Hosts []string `yaml:"hosts"`
+}
+
+type testConfig struct {
+	KindConfig struct {
+		UseKindCluster  bool   `yaml:"useKindCluster"`
+		KindClusterName string `yaml:"kindClusterName"`
+	} `yaml:"kindConfig"`
+	Kubeconfig string            `yaml:"kubeconfig"`
+	Plugin     string            `yaml:"plugin"`
+	Plugins    []string          `yaml:"plugins"`
+	Rules      []testRule        `yaml:"rules"`
+	Values     map[string]string `yaml:"values"`
+	HelmConfig *struct {
+		Registry string `yaml:"registry"`
+	} `yaml:"helmConfig"`
+}
+
+// withAnswers installs answers, restoring the prompts package once the test completes
+func withAnswers(t *testing.T, a *Answers, ni bool, tui prompts.TUI) {
+	tuiOrig, executorOrig := prompts.Tui, prompts.GetCmdExecutor
+	t.Cleanup(func() {
+		prompts.Tui, prompts.GetCmdExecutor = tuiOrig, executorOrig
+		installed, nonInteractive = nil, false
+		pending = make(map[string]string)
+	})
+	prompts.Tui = tui
+	Install(a, ni)
+}
+
+func TestLoad(t *testing.T) {
+	s := schema.Of(testConfig{})
+	dir := t.TempDir()
+	valuesFile := filepath.Join(dir, "answers.yaml")
+	require.NoError(t, os.WriteFile(valuesFile, []byte(`kindConfig:
+  useKindCluster: true
+  kindClusterName: validator
+kubeconfig: /tmp/kubeconfig
+values:
+  a: b
+helmConfig: null
+`), 0600))
+
+	a, err := Load(s, []string{valuesFile}, []string{
+		"kindConfig.kindClusterName=kind",
+		"rules[0].name=rule",
+		"rules.0.hosts=[a, b]",
+		"values.c=123",
+	})
+	require.NoError(t, err)
+
+	n, ok := a.Lookup("kindConfig.useKindCluster")
+	assert.True(t, ok)
+	assert.Equal(t, "true", n.Value)
+	_, ok = a.Lookup("kindConfig.missing")
+	assert.False(t, ok)
+
+	c := &testConfig{Plugin: "aws", Values: map[string]string{"d": "e"}}
+	c.HelmConfig = &struct {
+		Registry string `yaml:"registry"`
+	}{Registry: "oci://registry"}
+	require.NoError(t, a.Apply(c))
+	// null answers aren't applied
+	assert.Equal(t, "oci://registry", c.HelmConfig.Registry)
+	assert.True(t, c.KindConfig.UseKindCluster)
+	assert.Equal(t, "kind", c.KindConfig.KindClusterName)
+	assert.Equal(t, "/tmp/kubeconfig", c.Kubeconfig)
+	assert.Equal(t, "aws", c.Plugin)
+	assert.Equal(t, []testRule{{Name: "rule", Hosts: []string{"a", "b"}}}, c.Rules)
+	assert.Equal(t, map[string]string{"a": "b", "c": "123", "d": "e"}, c.Values)
+
+	// unset answers are a no-op
+	var none *Answers
+	assert.NoError(t, none.Apply(c))
+	_, ok = none.Lookup("kubeconfig")
+	assert.False(t, ok)
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		sets  []string
+		err   string
+	}{
+		{name: "missing value", sets: []string{"kubeconfig"}, err: "expected path=value"},
+		{name: "invalid type", sets: []string{"kindConfig.useKindCluster=maybe"}, err: "kindConfig.useKindCluster: expected boolean, got string"},
+		{name: "unknown field", sets: []string{"kindConfig.name=kind"}, err: `unknown field "name"`},
+		{name: "invalid index", sets: []string{"rules[1].name=rule"}, err: "invalid list index 1"},
+		{name: "invalid file", files: map[string]string{"list.yaml": "- a\n"}, err: "expected a mapping of configuration paths to answers"},
+		{name: "invalid file field", files: map[string]string{"answers.yaml": "kindConfig:\n  useKindClustr: true\n"}, err: `did you mean "useKindCluster"?`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := make([]string, 0)
+			for name, data := range tt.files {
+				f := filepath.Join(t.TempDir(), name)
+				require.NoError(t, os.WriteFile(f, []byte(data), 0600))
+				files = append(files, f)
+			}
+			_, err := Load(s, files, tt.sets)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	_, err = Load(s, []string{filepath.Join(dir, "missing.yaml")}, nil)
+	assert.ErrorContains(t, err, "failed to read answers file")
+}
+
+func TestTUI(t *testing.T) {
+	a, err := Load(schema.Of(testConfig{}), nil, []string{
+		"kindConfig.useKindCluster=yes",
+		"kindConfig.kindClusterName=kind",
+		"kubeconfig=bad",
+		"plugin=AWS",
+		"plugins=[aws, azure]",
+		"rules[0].name=rule",
+		"values=null",
+	})
+	require.NoError(t, err)
+	withAnswers(t, a, false, &mocks.MockTUI{Values: []string{"interactive", "y"}})
+
+	b, err := prompts.ReadBool(For("kindConfig.useKindCluster", "Provision & use kind cluster"), false)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	// a bool prompt which configures a list is answered yes if the list is non-empty
+	b, err = prompts.ReadBool(For("rules", "Enable rules"), false)
+	assert.NoError(t, err)
+	assert.True(t, b)
+	b, err = prompts.ReadBool(For("values", "Enable values"), true)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	s, err := prompts.ReadText(For("kindConfig.kindClusterName", "Cluster name"), "validator", false, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind", s)
+
+	_, err = prompts.Tui.GetText(For("kubeconfig", "Kubeconfig"), "", "", false, func(string) error { return errors.New("invalid") })
+	assert.ErrorContains(t, err, "invalid answer for kubeconfig: invalid")
+
+	s, err = prompts.Select(For("plugin", "Plugin"), []string{"aws", "azure"})
+	assert.NoError(t, err)
+	assert.Equal(t, "aws", s)
+
+	ss, err := prompts.Tui.GetMultiSelection(For("plugins", "Plugins"), []string{"aws", "azure", "oci"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aws", "azure"}, ss)
+	_, err = prompts.Tui.GetMultiSelection(For("plugins", "Plugins"), []string{"aws", "oci"}, 1)
+	assert.ErrorContains(t, err, `invalid answer for plugins: "azure" is not one of [aws oci]`)
+
+	// prompts which aren't associated with an answered path are delegated
+	s, err = prompts.ReadText(For("kindConfig.missing", "Missing"), "", false, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, "interactive", s)
+	b, err = prompts.ReadBool("Untagged", false)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	skip, err := Skip("rules", "Rules")
+	assert.NoError(t, err)
+	assert.True(t, skip)
+	skip, err = Skip("kindConfig.missing", "Missing")
+	assert.NoError(t, err)
+	assert.False(t, skip)
+	assert.False(t, NonInteractive())
+}
+
+func TestNonInteractive(t *testing.T) {
+	a, err := Load(schema.Of(testConfig{}), nil, []string{"kubeconfig=/tmp/kubeconfig"})
+	require.NoError(t, err)
+	withAnswers(t, a, true, &mocks.MockTUI{})
+	assert.True(t, NonInteractive())
+
+	s, err := prompts.ReadText(For("kubeconfig", "Kubeconfig"), "", false, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/kubeconfig", s)
+
+	_, err = prompts.ReadBool(For("kindConfig.useKindCluster", "Provision & use kind cluster"), false)
+	var missing MissingAnswerError
+	require.True(t, errors.As(err, &missing))
+	assert.Equal(t, "kindConfig.useKindCluster", missing.Key)
+	assert.ErrorContains(t, err, "--set kindConfig.useKindCluster=<value>")
+
+	_, err = prompts.ReadBool("Untagged", false)
+	assert.ErrorContains(t, err, `no answer provided for prompt "Untagged", which can't be answered via --set or --values`)
+
+	_, err = Skip("rules", "Rules")
+	assert.ErrorContains(t, err, "no answer provided for rules")
+
+	executor := prompts.GetCmdExecutor("vi", "file.yaml")
+	assert.ErrorContains(t, executor.Start(), `no answer provided for prompt "text editor"`)
+}
+
+func TestEditorExecutor(t *testing.T) {
+	editor := &mocks.MockFileEditor{FileContents: []string{"key: value"}}
+	filename := filepath.Join(t.TempDir(), "file.yaml")
+
+	executor := NewEditorExecutorFunc(editor.GetCmdExecutor)("vi", filename)
+	assert.NoError(t, executor.Start())
+	assert.NoError(t, executor.Wait())
+
+	data, err := os.ReadFile(filename) // #nosec
+	assert.NoError(t, err)
+	assert.Equal(t, "key: value", string(data))
+}
@@ -3,6 +3,8 @@ package embed
 
 import (
 	"embed"
+	"io/fs"
+	"path"
 
 	"github.com/spectrocloud-labs/embeddedfs/pkg/embeddedfs"
 )
@@ -12,3 +14,8 @@ var resources embed.FS
 
 // EFS is validatorctl's embedded file system
 var EFS = embeddedfs.NewEmbeddedFS("resources", resources)
+
+// ReadDir returns the entries of a directory within the embedded file system, sorted by filename
+func ReadDir(dir string) ([]fs.DirEntry, error) {
+	return resources.ReadDir(path.Join("resources", dir))
+}
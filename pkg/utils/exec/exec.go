@@ -36,11 +36,6 @@ var (
 	// KindBin is a Binary struct that references the kind binary.
 	KindBin = Binary{"kind", &Kind}
 
-	// Kubectl references to the kubectl binary.
-	Kubectl string
-	// KubectlBin is a Binary struct that references the kubectl binary.
-	KubectlBin = Binary{"kubectl", &Kubectl}
-
 	// Nslookup references to the nslookup binary.
 	Nslookup string
 	// NslookupBin is a Binary struct that references the nslookup binary.
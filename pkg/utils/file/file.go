@@ -2,8 +2,12 @@
 package file
 
 import (
+	"fmt"
 	"io/fs"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // GetFilesInDir walks the file tree from dir and returns a list of all files found in lexical order.
@@ -26,3 +30,63 @@ func GetFilesInDir(dir string) ([]string, error) {
 
 	return files, nil
 }
+
+// GetFilteredFilesInDir walks the file tree from dir and returns a list of the files found in lexical order
+// which match any of the include patterns and none of the exclude patterns. All files match if no include
+// patterns are provided. Directories matching an exclude pattern are skipped. See MatchesAny.
+func GetFilteredFilesInDir(dir string, include, exclude []string) ([]string, error) {
+	for _, p := range slices.Concat(include, exclude) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", p, err)
+		}
+	}
+
+	files := make([]string, 0)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if MatchesAny(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || (len(include) > 0 && !MatchesAny(rel, include)) {
+			return nil
+		}
+
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// MatchesAny reports whether a path, relative to some directory, matches any of the provided glob patterns.
+// Patterns containing a '/' are matched against the entire relative path, e.g., 'aws/*.yaml'.
+// All other patterns are matched against the base name, e.g., '*.yaml'.
+func MatchesAny(rel string, patterns []string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, p := range patterns {
+		target := path.Base(rel)
+		if strings.Contains(p, "/") {
+			target = rel
+		}
+		if ok, _ := path.Match(p, target); ok {
+			return true
+		}
+	}
+	return false
+}
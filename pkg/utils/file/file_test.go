@@ -3,6 +3,7 @@ package file
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -99,3 +100,67 @@ func TestGetFilesInDir(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFilteredFilesInDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"a.yaml", "b.json", "README.md", "aws/c.yaml", "aws/d.yml", "testdata/e.yaml", "network/testdata/f.yaml"} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, f), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "no patterns",
+			expected: []string{"README.md", "a.yaml", "aws/c.yaml", "aws/d.yml", "b.json", "network/testdata/f.yaml", "testdata/e.yaml"},
+		},
+		{
+			name:     "include base name patterns",
+			include:  []string{"*.yaml", "*.yml"},
+			expected: []string{"a.yaml", "aws/c.yaml", "aws/d.yml", "network/testdata/f.yaml", "testdata/e.yaml"},
+		},
+		{
+			name:     "include relative path pattern",
+			include:  []string{"aws/*"},
+			expected: []string{"aws/c.yaml", "aws/d.yml"},
+		},
+		{
+			name:     "exclude directories",
+			include:  []string{"*.yaml"},
+			exclude:  []string{"testdata"},
+			expected: []string{"a.yaml", "aws/c.yaml"},
+		},
+		{
+			name:     "exclude files",
+			exclude:  []string{"*.md", "aws/*.yml"},
+			expected: []string{"a.yaml", "aws/c.yaml", "b.json", "network/testdata/f.yaml", "testdata/e.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := GetFilteredFilesInDir(dir, tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("GetFilteredFilesInDir() error = %v", err)
+			}
+			expected := make([]string, 0, len(tt.expected))
+			for _, f := range tt.expected {
+				expected = append(expected, filepath.Join(dir, f))
+			}
+			if !reflect.DeepEqual(files, expected) {
+				t.Errorf("Expected files %v, got %v", expected, files)
+			}
+		})
+	}
+
+	if _, err := GetFilteredFilesInDir(dir, []string{"[a-"}, nil); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
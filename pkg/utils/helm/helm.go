@@ -0,0 +1,245 @@
+// Package helm installs, upgrades and uninstalls Helm releases using the Helm v3 SDK,
+// such that the helm binary is not required.
+package helm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	vhelm "github.com/validator-labs/validator/pkg/helm"
+
+	log "github.com/validator-labs/validatorctl/pkg/logging"
+)
+
+// helmDriver is the storage driver for Helm release information, i.e., the helm binary's default
+const helmDriver = "secret"
+
+// Client installs, upgrades and uninstalls Helm releases.
+// It is implemented by both the validator Helm CLI client and SDKClient.
+type Client interface {
+	Delete(name, namespace string) error
+	Upgrade(name, namespace string, options vhelm.Options) error
+}
+
+// SDKClient is a Helm client which uses the Helm v3 SDK
+type SDKClient struct {
+	kubeconfig string
+
+	// actionConfig returns the configuration for Helm actions within a namespace
+	actionConfig func(namespace string) (*action.Configuration, error)
+}
+
+// NewSDKClient returns a Helm SDK client for the cluster referenced by a kubeconfig file
+func NewSDKClient(kubeconfig string) *SDKClient {
+	c := &SDKClient{kubeconfig: kubeconfig}
+	c.actionConfig = c.newActionConfig
+	return c
+}
+
+func (c *SDKClient) newActionConfig(namespace string) (*action.Configuration, error) {
+	flags := genericclioptions.NewConfigFlags(false)
+	flags.KubeConfig = &c.kubeconfig
+	flags.Namespace = &namespace
+
+	ac := &action.Configuration{}
+	if err := ac.Init(flags, namespace, helmDriver, log.Debug); err != nil {
+		return nil, fmt.Errorf("failed to initialize Helm configuration: %w", err)
+	}
+	return ac, nil
+}
+
+// Upgrade upgrades a Helm release, installing it if it does not exist, i.e., 'helm upgrade --install'.
+// The chart is loaded from a local path, an OCI registry (oci://) or a chart repository.
+func (c *SDKClient) Upgrade(name, namespace string, options vhelm.Options) error {
+	ac, err := c.actionConfig(namespace)
+	if err != nil {
+		return err
+	}
+	ac.RegistryClient, err = newRegistryClient(options)
+	if err != nil {
+		return err
+	}
+	values, err := chartutil.ReadValues([]byte(options.Values))
+	if err != nil {
+		return fmt.Errorf("failed to parse Helm values: %w", err)
+	}
+
+	history := action.NewHistory(ac)
+	history.Max = 1
+	_, err = history.Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		install := action.NewInstall(ac)
+		install.ReleaseName = name
+		install.Namespace = namespace
+		install.CreateNamespace = options.CreateNamespace
+		install.Atomic = options.Atomic
+
+		ch, err := loadChart(&install.ChartPathOptions, options)
+		if err != nil {
+			return err
+		}
+		log.Info("installing Helm release %s/%s using chart %s-%s", namespace, name, ch.Name(), ch.Metadata.Version)
+		if _, err := install.Run(ch, values); err != nil {
+			return fmt.Errorf("failed to install Helm release %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get history of Helm release %s/%s: %w", namespace, name, err)
+	}
+
+	upgrade := action.NewUpgrade(ac)
+	upgrade.Namespace = namespace
+	upgrade.Atomic = options.Atomic
+	upgrade.Force = options.Force
+
+	ch, err := loadChart(&upgrade.ChartPathOptions, options)
+	if err != nil {
+		return err
+	}
+	log.Info("upgrading Helm release %s/%s using chart %s-%s", namespace, name, ch.Name(), ch.Metadata.Version)
+	if _, err := upgrade.Run(name, ch, values); err != nil {
+		return fmt.Errorf("failed to upgrade Helm release %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// Delete uninstalls a Helm release
+func (c *SDKClient) Delete(name, namespace string) error {
+	ac, err := c.actionConfig(namespace)
+	if err != nil {
+		return err
+	}
+	if _, err := action.NewUninstall(ac).Run(name); err != nil {
+		return fmt.Errorf("failed to uninstall Helm release %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// Values returns the user-supplied values of a Helm release as YAML. ok is false if the release is not installed.
+func (c *SDKClient) Values(name, namespace string) (values string, ok bool, err error) {
+	ac, err := c.actionConfig(namespace)
+	if err != nil {
+		return "", false, err
+	}
+	vals, err := action.NewGetValues(ac).Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("failed to get values of Helm release %s/%s: %w", namespace, name, err)
+	}
+	bs, err := yaml.Marshal(vals)
+	if err != nil {
+		return "", false, err
+	}
+	return string(bs), true, nil
+}
+
+// chartRef returns the reference used to locate a chart, along with its version. The options are
+// interpreted in the same way as by the validator Helm CLI client: charts are located via a local
+// path, an OCI registry, i.e., <registry>/<chart>, or a chart repository, i.e., <registry>/<repo>.
+func chartRef(options vhelm.Options) (ref, repoURL, version string) {
+	switch {
+	case options.Path != "":
+		return options.Path, "", ""
+	case registry.IsOCI(options.Registry):
+		// OCI chart tags omit the 'v' prefix
+		return fmt.Sprintf("%s/%s", strings.TrimSuffix(options.Registry, "/"), options.Chart), "", strings.TrimPrefix(options.Version, "v")
+	}
+	return options.Chart, fmt.Sprintf("%s/%s", options.Registry, options.Repo), options.Version
+}
+
+// loadChart downloads a chart, if required, and loads it. Chart repository indexes and charts
+// are cached within a temporary directory, rather than the user's Helm cache.
+func loadChart(cpo *action.ChartPathOptions, options vhelm.Options) (*chart.Chart, error) {
+	ref, repoURL, version := chartRef(options)
+	cpo.RepoURL = repoURL
+	cpo.Version = version
+	cpo.Username = options.Username
+	cpo.Password = options.Password
+	cpo.CaFile = options.CaFile
+	cpo.InsecureSkipTLSverify = options.InsecureSkipTLSVerify
+
+	dir, err := os.MkdirTemp("", "validatorctl-helm-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	settings := cli.New()
+	settings.RepositoryConfig = filepath.Join(dir, "repositories.yaml")
+	settings.RepositoryCache = filepath.Join(dir, "cache")
+
+	path, err := cpo.LocateChart(ref, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate Helm chart %s: %w", ref, err)
+	}
+	ch, err := loader.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Helm chart %s: %w", ref, err)
+	}
+	return ch, nil
+}
+
+// newRegistryClient returns an OCI registry client which trusts the CA file, and uses the basic auth
+// credentials provided via the options. Otherwise, credentials are read from the Helm registry config
+// file and the docker config file.
+func newRegistryClient(options vhelm.Options) (*registry.Client, error) {
+	tlsConfig, err := newTLSConfig(options.CaFile, options.InsecureSkipTLSVerify)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	opts := []registry.ClientOption{
+		registry.ClientOptWriter(io.Discard),
+		registry.ClientOptHTTPClient(&http.Client{Transport: transport}),
+	}
+	if options.Username != "" || options.Password != "" {
+		opts = append(opts, registry.ClientOptBasicAuth(options.Username, options.Password))
+	}
+	client, err := registry.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Helm registry client: %w", err)
+	}
+	return client, nil
+}
+
+// newTLSConfig returns a TLS configuration trusting the system CAs and the CA file, if provided
+func newTLSConfig(caFile string, insecureSkipTLSVerify bool) (*tls.Config, error) {
+	caCertPool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load system cert pool: %w", err)
+	}
+	if caFile != "" {
+		bs, err := os.ReadFile(caFile) // #nosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		if ok := caCertPool.AppendCertsFromPEM(bs); !ok {
+			return nil, fmt.Errorf("failed to append CA cert(s) from %s", caFile)
+		}
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            caCertPool,
+		InsecureSkipVerify: insecureSkipTLSVerify, // #nosec G402
+	}, nil
+}
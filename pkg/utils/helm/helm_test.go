@@ -0,0 +1,213 @@
+package helm
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	stdlog "log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	hregistry "helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	vhelm "github.com/validator-labs/validator/pkg/helm"
+)
+
+// newTestClient returns an SDK client which stores releases in memory and doesn't contact a cluster
+func newTestClient() *SDKClient {
+	releases := storage.Init(driver.NewMemory())
+	return &SDKClient{
+		actionConfig: func(string) (*action.Configuration, error) {
+			return &action.Configuration{
+				Releases:     releases,
+				KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+				Capabilities: chartutil.DefaultCapabilities,
+				Log:          func(string, ...interface{}) {},
+			}, nil
+		},
+	}
+}
+
+// newTestChart returns a chart w/ a single ConfigMap template
+func newTestChart(version string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       "validator",
+			Version:    version,
+		},
+		Templates: []*chart.File{{
+			Name: "templates/configmap.yaml",
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: validator\ndata:\n  key: {{ .Values.key | quote }}\n"),
+		}},
+	}
+}
+
+func TestChartRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		options vhelm.Options
+		ref     string
+		repoURL string
+		version string
+	}{
+		{
+			name:    "local path",
+			options: vhelm.Options{Path: "/tmp/charts/validator", Version: "v0.1.0"},
+			ref:     "/tmp/charts/validator",
+		},
+		{
+			name:    "OCI registry",
+			options: vhelm.Options{Chart: "validator", Registry: "oci://registry.example.com/charts/", Version: "v0.1.0"},
+			ref:     "oci://registry.example.com/charts/validator",
+			version: "0.1.0",
+		},
+		{
+			name:    "chart repository",
+			options: vhelm.Options{Chart: "validator", Registry: "https://validator-labs.github.io", Repo: "validator", Version: "v0.1.0"},
+			ref:     "validator",
+			repoURL: "https://validator-labs.github.io/validator",
+			version: "v0.1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, repoURL, version := chartRef(tt.options)
+			assert.Equal(t, tt.ref, ref)
+			assert.Equal(t, tt.repoURL, repoURL)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestSDKClientLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestClient()
+
+	_, ok, err := c.Values("validator", "validator")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for i, version := range []string{"0.1.0", "0.2.0"} {
+		path := filepath.Join(dir, version)
+		require.NoError(t, chartutil.SaveDir(newTestChart(version), path))
+
+		opts := vhelm.Options{Path: filepath.Join(path, "validator"), Values: "key: " + version}
+		require.NoError(t, c.Upgrade("validator", "validator", opts))
+
+		ac, err := c.actionConfig("validator")
+		require.NoError(t, err)
+		rel, err := ac.Releases.Last("validator")
+		require.NoError(t, err)
+		assert.Equal(t, i+1, rel.Version)
+		assert.Equal(t, version, rel.Chart.Metadata.Version)
+		assert.Contains(t, rel.Manifest, "key: \""+version+"\"")
+
+		values, ok, err := c.Values("validator", "validator")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "key: "+version+"\n", values)
+	}
+
+	require.NoError(t, c.Delete("validator", "validator"))
+	_, ok, err = c.Values("validator", "validator")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	err = c.Delete("validator", "validator")
+	assert.ErrorContains(t, err, "failed to uninstall Helm release validator/validator")
+}
+
+func TestSDKClientUpgradeOCI(t *testing.T) {
+	reg := registry.New(registry.Logger(stdlog.New(io.Discard, "", 0)))
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	// Helm never uses TLS for loopback registries, so serve the registry as example.com, which the
+	// test server's certificate is valid for
+	host := "example.com"
+	transport := http.DefaultTransport.(*http.Transport)
+	dialContext := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == host+":443" {
+			addr = srv.Listener.Addr().String()
+		}
+		return dialContext(ctx, network, addr)
+	}
+	t.Cleanup(func() { transport.DialContext = dialContext })
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+
+	opts := vhelm.Options{
+		Chart:    "validator",
+		Registry: "oci://" + host + "/charts",
+		Version:  "v0.1.0",
+		Values:   "key: oci",
+		Username: "user",
+		Password: "pass",
+		CaFile:   caFile,
+	}
+
+	// push the chart to the registry directly
+	pushSrv := httptest.NewServer(reg)
+	t.Cleanup(pushSrv.Close)
+	path, err := chartutil.Save(newTestChart("0.1.0"), dir)
+	require.NoError(t, err)
+	data, err := os.ReadFile(path) // #nosec
+	require.NoError(t, err)
+	rc, err := hregistry.NewClient(hregistry.ClientOptPlainHTTP(), hregistry.ClientOptWriter(io.Discard))
+	require.NoError(t, err)
+	_, err = rc.Push(data, strings.TrimPrefix(pushSrv.URL, "http://")+"/charts/validator:0.1.0")
+	require.NoError(t, err)
+
+	c := newTestClient()
+	require.NoError(t, c.Upgrade("validator", "validator", opts))
+	values, ok, err := c.Values("validator", "validator")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "key: oci\n", values)
+
+	t.Run("untrusted CA", func(t *testing.T) {
+		untrusted := opts
+		untrusted.CaFile = ""
+		err := newTestClient().Upgrade("validator", "validator", untrusted)
+		assert.ErrorContains(t, err, "failed to locate Helm chart")
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		insecure := opts
+		insecure.CaFile = ""
+		insecure.InsecureSkipTLSVerify = true
+		assert.NoError(t, newTestClient().Upgrade("validator", "validator", insecure))
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		anonymous := opts
+		anonymous.Username, anonymous.Password = "", ""
+		err := newTestClient().Upgrade("validator", "validator", anonymous)
+		assert.ErrorContains(t, err, "failed to locate Helm chart")
+	})
+}
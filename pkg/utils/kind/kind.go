@@ -17,6 +17,7 @@ import (
 	"github.com/validator-labs/validatorctl/pkg/components"
 	cfg "github.com/validator-labs/validatorctl/pkg/config"
 	log "github.com/validator-labs/validatorctl/pkg/logging"
+	"github.com/validator-labs/validatorctl/pkg/utils/answers"
 	"github.com/validator-labs/validatorctl/pkg/utils/embed"
 	exec_utils "github.com/validator-labs/validatorctl/pkg/utils/exec"
 )
@@ -32,6 +33,10 @@ func ValidateClusters(action string) error {
 	if err != nil {
 		return err
 	}
+	if clusters != nil && answers.NonInteractive() {
+		log.Warn("Existing kind cluster(s) %s detected. This may cause too many open files errors.", clusters)
+		return nil
+	}
 	if clusters != nil {
 		prompt := fmt.Sprintf(
 			"Existing kind cluster(s) %s detected. This may cause too many open files errors. Proceed with %s",
@@ -0,0 +1,139 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
+	toolsWatch "k8s.io/client-go/tools/watch"
+	"sigs.k8s.io/yaml"
+)
+
+// FieldManager is the field manager used when applying objects
+const FieldManager = "validatorctl"
+
+// GetResourceClient returns a dynamic client for objects of the given kind.
+// For namespaced kinds, the client is scoped to the given namespace.
+func GetResourceClient(kubeconfigPath string, gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	config, err := getConfigFromKubeconfig(kubeconfigPath, "")
+	if err != nil {
+		return nil, err
+	}
+	dc, err := getDynamicClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s to a resource: %w", gvk, err)
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dc.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return dc.Resource(mapping.Resource), nil
+}
+
+// ApplyManifest server-side applies a YAML manifest containing a single object
+func ApplyManifest(kubeconfigPath string, manifest []byte) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(manifest, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	client, err := GetResourceClient(kubeconfigPath, obj.GroupVersionKind(), obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	force := true
+	applied, err := client.Patch(context.Background(), obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return applied, nil
+}
+
+// CreateNamespace creates a namespace, if it does not already exist
+func CreateNamespace(client kubernetes.Interface, ns *corev1.Namespace) error {
+	_, err := client.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil && !apierrs.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", ns.Name, err)
+	}
+	return nil
+}
+
+// CreateSecret creates a secret, if it does not already exist
+func CreateSecret(client kubernetes.Interface, secret *corev1.Secret) error {
+	_, err := client.CoreV1().Secrets(secret.Namespace).Create(context.Background(), secret, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil && !apierrs.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// WaitForDeployment watches a deployment until it is available, or the context is done
+func WaitForDeployment(ctx context.Context, client kubernetes.Interface, namespace, name string) error {
+	deployments := client.AppsV1().Deployments(namespace)
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return deployments.List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return deployments.Watch(ctx, opts)
+		},
+	}
+
+	_, err := toolsWatch.UntilWithSync(ctx, lw, &appsv1.Deployment{}, nil, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Deleted {
+			return false, nil
+		}
+		d, ok := event.Object.(*appsv1.Deployment)
+		if !ok {
+			return false, nil
+		}
+		return deploymentAvailable(d), nil
+	})
+	if err != nil {
+		return fmt.Errorf("deployment %s/%s did not become available: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// deploymentAvailable returns true if a deployment's Available condition is true
+func deploymentAvailable(d *appsv1.Deployment) bool {
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentAvailable {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
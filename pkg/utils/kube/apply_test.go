@@ -0,0 +1,76 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateSecret(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "validator"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "validator"},
+		StringData: map[string]string{"username": "bob"},
+	}
+
+	// creating existing objects must succeed
+	for i := 0; i < 2; i++ {
+		if err := CreateNamespace(client, ns); err != nil {
+			t.Fatalf("CreateNamespace: %v", err)
+		}
+		if err := CreateSecret(client, secret); err != nil {
+			t.Fatalf("CreateSecret: %v", err)
+		}
+	}
+	if _, err := client.CoreV1().Secrets("validator").Get(context.Background(), "creds", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected secret to exist: %v", err)
+	}
+}
+
+func TestWaitForDeployment(t *testing.T) {
+	deployment := func(status corev1.ConditionStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "manager", Namespace: "validator"},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: status}},
+			},
+		}
+	}
+
+	t.Run("available", func(t *testing.T) {
+		client := fake.NewSimpleClientset(deployment(corev1.ConditionTrue))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := WaitForDeployment(ctx, client, "validator", "manager"); err != nil {
+			t.Fatalf("expected deployment to be available: %v", err)
+		}
+	})
+
+	t.Run("becomes available", func(t *testing.T) {
+		client := fake.NewSimpleClientset(deployment(corev1.ConditionFalse))
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, _ = client.AppsV1().Deployments("validator").UpdateStatus(context.Background(), deployment(corev1.ConditionTrue), metav1.UpdateOptions{})
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := WaitForDeployment(ctx, client, "validator", "manager"); err != nil {
+			t.Fatalf("expected deployment to become available: %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client := fake.NewSimpleClientset(deployment(corev1.ConditionFalse))
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		if err := WaitForDeployment(ctx, client, "validator", "manager"); err == nil {
+			t.Fatal("expected timeout error")
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	diagnosticsTimeout    = 30 * time.Second
+	diagnosticsEvents     = 100
+	diagnosticsLogLines   = int64(200)
+	diagnosticsLogsDir    = "logs"
+	diagnosticsDeployYAML = "deployments.yaml"
+	diagnosticsEventsTxt  = "events.txt"
+)
+
+// CollectDiagnostics writes the status of each Deployment, recent events and recent pod logs
+// from a namespace to a directory, to aid in troubleshooting. The paths of all files written are returned.
+func CollectDiagnostics(client kubernetes.Interface, namespace, dir string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+
+	if err := os.MkdirAll(filepath.Join(dir, diagnosticsLogsDir), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create diagnostics directory: %w", err)
+	}
+	files := make([]string, 0)
+	write := func(name string, data []byte) error {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+		return nil
+	}
+
+	// deployment status
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return files, fmt.Errorf("failed to list deployments in namespace %s: %w", namespace, err)
+	}
+	statuses := make(map[string]appsv1.DeploymentStatus, len(deployments.Items))
+	for _, d := range deployments.Items {
+		statuses[d.Name] = d.Status
+	}
+	data, err := yaml.Marshal(statuses)
+	if err != nil {
+		return files, fmt.Errorf("failed to marshal deployment status: %w", err)
+	}
+	if err := write(diagnosticsDeployYAML, data); err != nil {
+		return files, err
+	}
+
+	// recent events
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return files, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+	}
+	if err := write(diagnosticsEventsTxt, []byte(formatEvents(events.Items))); err != nil {
+		return files, err
+	}
+
+	// recent pod logs
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return files, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+	tailLines := diagnosticsLogLines
+	for _, p := range pods.Items {
+		for _, c := range p.Spec.Containers {
+			logs, err := client.CoreV1().Pods(namespace).GetLogs(p.Name, &corev1.PodLogOptions{
+				Container: c.Name,
+				TailLines: &tailLines,
+			}).DoRaw(ctx)
+			if err != nil {
+				logs = []byte(fmt.Sprintf("failed to get logs: %v\n", err))
+			}
+			if err := write(filepath.Join(diagnosticsLogsDir, fmt.Sprintf("%s_%s.log", p.Name, c.Name)), logs); err != nil {
+				return files, err
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// formatEvents formats the most recent events, oldest first, one per line
+func formatEvents(events []corev1.Event) string {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	if len(events) > diagnosticsEvents {
+		events = events[len(events)-diagnosticsEvents:]
+	}
+	sb := &strings.Builder{}
+	for _, e := range events {
+		sb.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s/%s\t%s\n",
+			eventTime(e).Format(time.RFC3339), e.Type, e.Reason,
+			strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name, strings.TrimSpace(e.Message),
+		))
+	}
+	return sb.String()
+}
+
+// eventTime returns the time at which an event last occurred
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,64 @@
+package kube
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollectDiagnostics(t *testing.T) {
+	now := time.Now()
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "validator-plugin-aws", Namespace: "validator"},
+			Status:     appsv1.DeploymentStatus{UnavailableReplicas: 1},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: "validator"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "aws"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off pulling image",
+			LastTimestamp:  metav1.NewTime(now),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "validator"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "aws"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Scheduled",
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "validator"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+		},
+	)
+
+	dir := t.TempDir()
+	files, err := CollectDiagnostics(client, "validator", dir)
+	if err != nil {
+		t.Fatalf("CollectDiagnostics: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %v", files)
+	}
+
+	deployments, err := os.ReadFile(filepath.Join(dir, diagnosticsDeployYAML))
+	if err != nil || !strings.Contains(string(deployments), "validator-plugin-aws:") {
+		t.Errorf("expected deployment status, got %q (%v)", deployments, err)
+	}
+	events, err := os.ReadFile(filepath.Join(dir, diagnosticsEventsTxt))
+	if err != nil || strings.Index(string(events), "Scheduled") > strings.Index(string(events), "BackOff") {
+		t.Errorf("expected events sorted oldest first, got %q (%v)", events, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, diagnosticsLogsDir, "aws_manager.log")); err != nil {
+		t.Errorf("expected pod logs: %v", err)
+	}
+}
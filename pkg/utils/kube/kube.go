@@ -4,12 +4,9 @@ package kube
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"os/user"
 	"path/filepath"
-	"time"
 
-	"golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -18,34 +15,8 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
-
-	log "github.com/validator-labs/validatorctl/pkg/logging"
-	exec_utils "github.com/validator-labs/validatorctl/pkg/utils/exec"
 )
 
-// KubectlCmd represents a kubectl command
-type KubectlCmd struct {
-	Cmd      []string
-	Delay    *time.Duration
-	DelayMsg string
-}
-
-// KubectlCommand executes a kubectl command with the given parameters
-func KubectlCommand(params []string, kConfig string) (out, stderr string, err error) {
-	params = append(params, fmt.Sprintf("--kubeconfig=%s", kConfig))
-	cmd := exec.Command(exec_utils.Kubectl, params...) //#nosec
-
-	if slices.Contains(params, "secret") {
-		log.InfoCLI("\n==== Kubectl Command ==== Create Secret")
-	} else {
-		log.InfoCLI("\n==== Kubectl Command ====")
-		log.InfoCLI("%s", cmd.String())
-	}
-
-	out, stderr, err = exec_utils.Execute(true, cmd)
-	return
-}
-
 // GetKubeClientset returns a Kubernetes clientset
 func GetKubeClientset(kubeconfigPath string) (kubernetes.Interface, error) {
 	config, err := getConfigFromKubeconfig(kubeconfigPath, "")
@@ -0,0 +1,389 @@
+// Package schema generates JSON Schemas from Go types and validates YAML documents against them.
+//
+// Schemas are generated using the same field naming rules as gopkg.in/yaml.v2, so that they
+// describe the YAML documents which the Go types are unmarshaled from. Validation reports the
+// line and column of each error within the YAML document.
+package schema
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Draft is the JSON Schema draft which generated schemas conform to
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON Schema types
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema is a JSON Schema. Only the subset of JSON Schema required to describe Go types is supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// additionalProperties returns the schema for properties which aren't explicitly defined, if any
+func (s *Schema) additionalProperties() (*Schema, bool) {
+	if as, ok := s.AdditionalProperties.(*Schema); ok {
+		return as, true
+	}
+	return nil, false
+}
+
+var yaml11Bools = []string{"y", "yes", "n", "no", "on", "off"}
+
+var (
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	yamlUnmarshaler = reflect.TypeOf((*yamlv2.Unmarshaler)(nil)).Elem()
+)
+
+// Generate returns a JSON Schema describing the YAML representation of v
+func Generate(v any, title string) *Schema {
+	s := Of(v)
+	s.Schema = Draft
+	s.Title = title
+	return s
+}
+
+// Of returns a schema describing the YAML representation of v, for use within another schema
+func Of(v any) *Schema {
+	return generate(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+func generate(t reflect.Type, seen map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// types which unmarshal themselves, and recursive types, may be represented by anything
+	if seen[t] || reflect.PointerTo(t).Implements(yamlUnmarshaler) || reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: TypeString}
+		}
+		return &Schema{Type: TypeArray, Items: generate(t.Elem(), seen)}
+	case reflect.Map:
+		return &Schema{Type: TypeObject, AdditionalProperties: generate(t.Elem(), seen)}
+	case reflect.Struct:
+		seen[t] = true
+		defer delete(seen, t)
+
+		s := &Schema{Type: TypeObject, Properties: make(map[string]*Schema), AdditionalProperties: false}
+		addProperties(s, t, seen)
+		return s
+	}
+	return &Schema{}
+}
+
+// addProperties adds a property to s for each exported field of the struct type t, using yaml.v2's naming rules
+func addProperties(s *Schema, t reflect.Type, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(opts, ","), "inline") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addProperties(s, ft, seen)
+			} else {
+				s.AdditionalProperties = generate(ft.Elem(), seen)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		s.Properties[name] = generate(f.Type, seen)
+	}
+}
+
+// Error is an error at a particular location within a YAML document
+type Error struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements error
+func (e Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// Validate validates a YAML document, parsed using Parse, against a schema
+func Validate(s *Schema, root *yaml.Node) []Error {
+	return validate(s, root, "")
+}
+
+// Parse parses a YAML document, returning its root node
+func Parse(data []byte) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0], nil
+	}
+	return doc, nil
+}
+
+func validate(s *Schema, n *yaml.Node, path string) []Error {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if s.Type == "" || (n.Kind == yaml.ScalarNode && n.Tag == "!!null") {
+		return nil
+	}
+
+	switch s.Type {
+	case TypeObject:
+		if n.Kind != yaml.MappingNode {
+			return []Error{typeError(n, path, s.Type)}
+		}
+		errs := make([]Error, 0)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			p := join(path, k.Value)
+			if k.Value == "<<" {
+				errs = append(errs, validate(s, v, path)...)
+			} else if ps, ok := s.Properties[k.Value]; ok {
+				errs = append(errs, validate(ps, v, p)...)
+			} else if as, ok := s.additionalProperties(); ok {
+				errs = append(errs, validate(as, v, p)...)
+			} else {
+				errs = append(errs, unknownFieldError(s, k, path))
+			}
+		}
+		return errs
+
+	case TypeArray:
+		if n.Kind != yaml.SequenceNode {
+			return []Error{typeError(n, path, s.Type)}
+		}
+		errs := make([]Error, 0)
+		for i, e := range n.Content {
+			errs = append(errs, validate(s.Items, e, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	}
+
+	if n.Kind != yaml.ScalarNode {
+		return []Error{typeError(n, path, s.Type)}
+	}
+	var ok bool
+	switch s.Type {
+	case TypeString:
+		ok = true
+	case TypeBoolean:
+		// yaml.v2 implements YAML 1.1, which also supports y/yes/n/no/on/off
+		ok = n.Tag == "!!bool" || slices.Contains(yaml11Bools, strings.ToLower(n.Value))
+	case TypeInteger:
+		ok = n.Tag == "!!int"
+	case TypeNumber:
+		ok = n.Tag == "!!int" || n.Tag == "!!float"
+	}
+	if !ok {
+		return []Error{typeError(n, path, s.Type)}
+	}
+	return nil
+}
+
+func typeError(n *yaml.Node, path, expected string) Error {
+	return Error{Path: path, Line: n.Line, Column: n.Column, Message: fmt.Sprintf("expected %s, got %s", expected, kind(n))}
+}
+
+func unknownFieldError(s *Schema, k *yaml.Node, path string) Error {
+	msg := fmt.Sprintf("unknown field %q", k.Value)
+	if suggestion := closest(k.Value, s.Properties); suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+	}
+	return Error{Path: path, Line: k.Line, Column: k.Column, Message: msg}
+}
+
+func kind(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return TypeObject
+	case yaml.SequenceNode:
+		return TypeArray
+	}
+	switch n.Tag {
+	case "!!bool":
+		return TypeBoolean
+	case "!!int":
+		return TypeInteger
+	case "!!float":
+		return TypeNumber
+	}
+	return TypeString
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// closest returns the property name most similar to s, provided that it's similar enough to be a likely typo
+func closest(s string, properties map[string]*Schema) string {
+	names := make([]string, 0, len(properties))
+	for p := range properties {
+		names = append(names, p)
+	}
+	slices.Sort(names)
+
+	best, bestDist := "", len(s)/2+1
+	for _, p := range names {
+		if d := distance(strings.ToLower(s), strings.ToLower(p)); d < bestDist {
+			best, bestDist = p, d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// Find returns the node at a path within a YAML document, e.g., plugins.oci.secrets[0].basicAuth.
+// If the path doesn't exist, its closest existing ancestor is returned.
+func Find(root *yaml.Node, path string) *yaml.Node {
+	n := root
+	for _, seg := range SplitPath(path) {
+		if n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+		next := child(n, seg)
+		if next == nil {
+			return n
+		}
+		n = next
+	}
+	return n
+}
+
+// Lookup returns the node at a path within a YAML document, e.g., plugins.oci.secrets[0].basicAuth,
+// and whether the path exists
+func Lookup(root *yaml.Node, path string) (*yaml.Node, bool) {
+	n := root
+	for _, seg := range SplitPath(path) {
+		if n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+		if n = child(n, seg); n == nil {
+			return nil, false
+		}
+	}
+	return n, true
+}
+
+func child(n *yaml.Node, seg string) *yaml.Node {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == seg {
+				return n.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(seg)
+		if err == nil && i >= 0 && i < len(n.Content) {
+			return n.Content[i]
+		}
+	}
+	return nil
+}
+
+// SplitPath splits a path such as a.b[0].c into its segments, i.e., [a b 0 c]
+func SplitPath(path string) []string {
+	segs := make([]string, 0)
+	for _, s := range strings.Split(path, ".") {
+		for s != "" {
+			i := strings.Index(s, "[")
+			if i < 0 {
+				segs = append(segs, s)
+				break
+			}
+			if i > 0 {
+				segs = append(segs, s[:i])
+			}
+			j := strings.Index(s, "]")
+			if j < i {
+				segs = append(segs, s[i:])
+				break
+			}
+			segs = append(segs, s[i+1:j])
+			s = s[j+1:]
+		}
+	}
+	return segs
+}
+
+// ErrorAt returns an Error for the node at a path within a YAML document
+func ErrorAt(root *yaml.Node, path, msg string) Error {
+	n := Find(root, path)
+	return Error{Path: path, Line: n.Line, Column: n.Column, Message: msg}
+}
@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	Name     string            `yaml:"name"`
+	Enabled  bool              `yaml:"enabled"`
+	Replicas int               `yaml:"replicas,omitempty"`
+	Ratio    float64           `yaml:"ratio"`
+	Labels   map[string]string `yaml:"labels"`
+	Items    []*testItem       `yaml:"items"`
+	Untagged string
+	Ignored  string `yaml:"-"`
+	Inline   `yaml:",inline"`
+	internal string
+}
+
+type testItem struct {
+	Key  string    `yaml:"key"`
+	Next *testItem `yaml:"next"`
+}
+
+type Inline struct {
+	Extra string `yaml:"extra"`
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(testConfig{internal: ""}, "test")
+
+	assert.Equal(t, Draft, s.Schema)
+	assert.Equal(t, TypeObject, s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+
+	names := make([]string, 0)
+	for k := range s.Properties {
+		names = append(names, k)
+	}
+	assert.ElementsMatch(t, []string{"name", "enabled", "replicas", "ratio", "labels", "items", "untagged", "extra"}, names)
+
+	assert.Equal(t, TypeBoolean, s.Properties["enabled"].Type)
+	assert.Equal(t, TypeInteger, s.Properties["replicas"].Type)
+	assert.Equal(t, TypeNumber, s.Properties["ratio"].Type)
+	assert.Equal(t, &Schema{Type: TypeString}, s.Properties["labels"].AdditionalProperties)
+	assert.Equal(t, TypeArray, s.Properties["items"].Type)
+
+	// recursive types may be represented by anything
+	assert.Equal(t, &Schema{}, s.Properties["items"].Items.Properties["next"])
+}
+
+func TestValidate(t *testing.T) {
+	s := Generate(testConfig{}, "test")
+
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{
+			name: "valid",
+			data: `name: test
+enabled: yes
+replicas: 3
+ratio: 1
+labels:
+  a: b
+items:
+- key: a
+  next:
+    anything: goes
+extra: inline
+`,
+			expected: []string{},
+		},
+		{
+			name: "unknown fields",
+			data: `nmae: test
+items:
+- key: a
+  value: b
+`,
+			expected: []string{
+				`1:1: unknown field "nmae" (did you mean "name"?)`,
+				`4:3: items[0]: unknown field "value"`,
+			},
+		},
+		{
+			name: "wrong types",
+			data: `enabled: maybe
+replicas: 1.5
+labels: [a]
+items:
+  key: a
+`,
+			expected: []string{
+				"1:10: enabled: expected boolean, got string",
+				"2:11: replicas: expected integer, got number",
+				"3:9: labels: expected object, got array",
+				"5:3: items: expected array, got object",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := Parse([]byte(tt.data))
+			assert.NoError(t, err)
+
+			errs := make([]string, 0)
+			for _, e := range Validate(s, root) {
+				errs = append(errs, e.Error())
+			}
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root, err := Parse([]byte(`items:
+- key: a
+- key: b
+  next:
+    key: c
+`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "c", Find(root, "items[1].next.key").Value)
+	assert.Equal(t, 3, Find(root, "items[1].missing").Line)
+	assert.Equal(t, Error{Path: "items[0].key", Line: 2, Column: 8, Message: "invalid"}, ErrorAt(root, "items[0].key", "invalid"))
+
+	n, ok := Lookup(root, "items[1].next.key")
+	assert.True(t, ok)
+	assert.Equal(t, "c", n.Value)
+	_, ok = Lookup(root, "items[1].missing")
+	assert.False(t, ok)
+	_, ok = Lookup(root, "items[2]")
+	assert.False(t, ok)
+}
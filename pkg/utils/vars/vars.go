@@ -0,0 +1,157 @@
+// Package vars renders variables within validator configuration files and plugin custom resources,
+// enabling the same files to be used across environments.
+//
+// Variables are referenced using ${NAME} placeholders, or Go templates, e.g., {{ .NAME }}.
+// Write $${NAME} for a literal ${NAME}. Variables are read from the environment, followed by
+// YAML files, followed by key=value pairs. Variables from later sources take precedence.
+package vars
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	placeholder = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	missingKey  = regexp.MustCompile(`^template: (\S+?): executing .* map has no entry for key "([^"]+)"`)
+)
+
+// UndefinedError is returned when a file references one or more variables which are not defined
+type UndefinedError struct {
+	File string
+	// Refs are the undefined variables, with their locations, e.g., REGION (line 3)
+	Refs []string
+}
+
+// Error implements error
+func (e UndefinedError) Error() string {
+	return fmt.Sprintf("undefined variable(s) in %s: %s. Provide values via --var, --var-file or the environment", e.File, strings.Join(e.Refs, ", "))
+}
+
+// Vars maps variable names to values
+type Vars map[string]string
+
+// Load reads variables from the environment, YAML files and key=value pairs, in that order.
+// Within a variables file, each value must be a scalar.
+func Load(files, sets []string) (Vars, error) {
+	v := make(Vars)
+
+	for _, e := range os.Environ() {
+		k, val, _ := strings.Cut(e, "=")
+		v[k] = val
+	}
+
+	for _, f := range files {
+		bs, err := os.ReadFile(f) // #nosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variables file: %w", err)
+		}
+		values := make(map[string]any)
+		if err := yaml.Unmarshal(bs, &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal variables file %s: %w", f, err)
+		}
+		for k, val := range values {
+			switch t := val.(type) {
+			case nil:
+				v[k] = ""
+			case string, bool, int, int64, uint64, float64:
+				v[k] = fmt.Sprintf("%v", t)
+			default:
+				return nil, fmt.Errorf("invalid value for variable %s in %s: unsupported type %T", k, f, val)
+			}
+		}
+	}
+
+	for _, s := range sets {
+		k, val, ok := strings.Cut(s, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", s)
+		}
+		v[k] = val
+	}
+
+	return v, nil
+}
+
+// References returns true if a value references any variables, i.e., it contains a ${NAME}
+// placeholder or a Go template action
+func References(s string) bool {
+	if strings.Contains(s, "{{") {
+		return true
+	}
+	for _, m := range placeholder.FindAllStringSubmatch(s, -1) {
+		if m[1] == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Escape escapes any ${NAME} placeholders within a value, so that it's rendered as is
+func Escape(s string) string {
+	return placeholder.ReplaceAllString(s, "$$$$$1{$2}")
+}
+
+// Render replaces the variables referenced within a file's contents with their values.
+// Go templates are only executed if the file contains template actions, i.e., '{{'.
+func (v Vars) Render(file string, data []byte) ([]byte, error) {
+	isTemplate := bytes.Contains(data, []byte("{{"))
+
+	data, err := v.replacePlaceholders(file, data)
+	if err != nil {
+		return nil, err
+	}
+	if !isTemplate {
+		return data, nil
+	}
+
+	tmpl, err := template.New(file).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", file, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, v); err != nil {
+		if m := missingKey.FindStringSubmatch(err.Error()); m != nil {
+			return nil, UndefinedError{File: file, Refs: []string{fmt.Sprintf("%s (%s)", m[2], m[1])}}
+		}
+		return nil, fmt.Errorf("failed to render template %s: %w", file, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// replacePlaceholders replaces ${NAME} placeholders, reporting all undefined variables at once
+func (v Vars) replacePlaceholders(file string, data []byte) ([]byte, error) {
+	var undefined []string
+	out := &bytes.Buffer{}
+	last := 0
+	for _, m := range placeholder.FindAllSubmatchIndex(data, -1) {
+		out.Write(data[last:m[0]])
+		last = m[1]
+
+		name := string(data[m[4]:m[5]])
+		if m[3] > m[2] {
+			// escaped, i.e., $${NAME}
+			out.WriteString("${" + name + "}")
+			continue
+		}
+		val, ok := v[name]
+		if !ok {
+			line := bytes.Count(data[:m[0]], []byte("\n")) + 1
+			undefined = append(undefined, fmt.Sprintf("%s (line %d)", name, line))
+			continue
+		}
+		out.WriteString(val)
+	}
+	out.Write(data[last:])
+
+	if len(undefined) > 0 {
+		return nil, UndefinedError{File: file, Refs: undefined}
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package vars
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Setenv("VALIDATOR_TEST_REGION", "us-west-2")
+	t.Setenv("VALIDATOR_TEST_ACCOUNT", "111111111111")
+
+	varsFile := filepath.Join(t.TempDir(), "vars.yaml")
+	assert.NoError(t, os.WriteFile(varsFile, []byte("VALIDATOR_TEST_ACCOUNT: 222222222222\nport: 443\nverify: true\nempty:\n"), 0600))
+
+	v, err := Load([]string{varsFile}, []string{"VALIDATOR_TEST_ACCOUNT=333333333333", "host=a=b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", v["VALIDATOR_TEST_REGION"])
+	assert.Equal(t, "333333333333", v["VALIDATOR_TEST_ACCOUNT"])
+	assert.Equal(t, "443", v["port"])
+	assert.Equal(t, "true", v["verify"])
+	assert.Equal(t, "", v["empty"])
+	assert.Equal(t, "a=b", v["host"])
+
+	_, err = Load(nil, []string{"no-value"})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = Load([]string{filepath.Join(t.TempDir(), "missing.yaml")}, nil)
+	assert.ErrorContains(t, err, "failed to read variables file")
+
+	assert.NoError(t, os.WriteFile(varsFile, []byte("regions:\n- us-east-1\n"), 0600))
+	_, err = Load([]string{varsFile}, nil)
+	assert.ErrorContains(t, err, "invalid value for variable regions")
+}
+
+func TestRender(t *testing.T) {
+	v := Vars{"REGION": "us-east-1", "ACCOUNT": "111111111111", "EMPTY": ""}
+
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+		err      string
+	}{
+		{name: "none", data: "defaultRegion: us-east-1\n", expected: "defaultRegion: us-east-1\n"},
+		{name: "placeholders", data: "defaultRegion: ${REGION}\narn: arn:aws:iam::${ACCOUNT}:role/${EMPTY}x\n", expected: "defaultRegion: us-east-1\narn: arn:aws:iam::111111111111:role/x\n"},
+		{name: "escaped", data: "name: $${REGION} $REGION\n", expected: "name: ${REGION} $REGION\n"},
+		{name: "template", data: "defaultRegion: {{ .REGION }}\n{{- if .ACCOUNT }}\naccount: ${ACCOUNT}\n{{- end }}\n", expected: "defaultRegion: us-east-1\naccount: 111111111111\n"},
+		{name: "undefined placeholders", data: "a: ${A}\nb: ${REGION}\nc: ${C}\n", err: "undefined variable(s) in rules.yaml: A (line 1), C (line 3)"},
+		{name: "undefined template variable", data: "a: b\nc: {{ .C }}\n", err: "undefined variable(s) in rules.yaml: C (rules.yaml:2:6)"},
+		{name: "invalid template", data: "a: {{ .A\n", err: "failed to parse template rules.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := v.Render("rules.yaml", []byte(tt.data))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(out))
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	assert.True(t, References("${REGION}"))
+	assert.True(t, References("arn:aws:iam::${ACCOUNT}:role/validator"))
+	assert.True(t, References("{{ .REGION }}"))
+	assert.False(t, References("$${REGION}"))
+	assert.False(t, References("us-east-1"))
+	assert.False(t, References("$REGION"))
+}
+
+func TestEscape(t *testing.T) {
+	v := Vars{"REGION": "us-east-1"}
+	for _, s := range []string{"${REGION}", "a ${REGION} b", "$${REGION}", "us-east-1"} {
+		escaped := Escape(s)
+		assert.False(t, References(escaped))
+
+		rendered, err := v.Render("file.yaml", []byte(escaped))
+		assert.NoError(t, err)
+		assert.Equal(t, s, string(rendered))
+	}
+}